@@ -0,0 +1,118 @@
+// bench.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/aide-family/moonbeam/pkg/generator"
+)
+
+// benchSpec 基准测试使用的一个文档
+type benchSpec struct {
+	name   string
+	source string // 传给生成器的来源，决定输入格式和头部注释
+	data   []byte
+}
+
+// runBench 执行 moonbeam bench 子命令：用 testing.Benchmark 运行 generator.BenchmarkSpec，与 go test -bench 的 BenchmarkGenerate 相同，
+// 按 go test -bench 的格式输出每次生成的耗时和内存分配，结果可以用 benchstat 比较两个版本；生成选项来自 -config，与生成代码相同
+func runBench(args []string) {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	var files stringList
+	var config, lang, benchTime string
+	var count int
+	fs.Var(&files, "f", "Spec file to benchmark, repeatable; without -f the built-in synthetic specs small (20 operations), medium (200) and large (2000) are used")
+	fs.StringVar(&config, "config", "", "Config file; its generation options apply, its inputs are not used")
+	fs.StringVar(&lang, "lang", "", "Target language (default: the config file's lang, else typescript)")
+	fs.StringVar(&benchTime, "benchtime", "1s", "Time to generate each spec for, such as 2s, or a fixed number of generations such as 100x, as with go test -benchtime")
+	fs.IntVar(&count, "count", 1, "Run each benchmark this many times, for benchstat")
+	fs.StringVar(&cpuProfile, "cpuprofile", "", "Write a CPU profile of the benchmarks to this file, for go tool pprof")
+	fs.StringVar(&memProfile, "memprofile", "", "Write a heap profile to this file when the benchmarks finish, for go tool pprof")
+	fs.Parse(args)
+	rejectArgs(fs)
+	loadFlagsFromConfig(flag.CommandLine, config)
+	if count < 1 {
+		fatalUsage("-count must be at least 1", "count", count)
+	}
+	// testing.Benchmark 按 -test.benchtime 决定运行多久
+	testing.Init()
+	if err := flag.Set("test.benchtime", benchTime); err != nil {
+		fatalUsage("invalid -benchtime", "benchtime", benchTime, "err", err)
+	}
+
+	if err := loadHeaderFile(); err != nil {
+		fatalUsage("read header file failed", "err", err)
+	}
+	o := opts
+	if lang != "" {
+		o.Lang = lang
+	}
+	// 文档中的警告在每次生成时都会出现，不输出
+	o.Logger = slog.New(slog.DiscardHandler)
+	if err := o.Validate(); err != nil {
+		fatalUsage("invalid options", "err", err)
+	}
+
+	specs, err := benchSpecs(files)
+	if err != nil {
+		fatal("failed to read API file", "err", err)
+	}
+	if err := startProfiling(); err != nil {
+		fatalUsage("start profiling failed", "err", err)
+	}
+	defer stopProfiling()
+
+	fmt.Printf("goos: %s\ngoarch: %s\npkg: github.com/aide-family/moonbeam\n", runtime.GOOS, runtime.GOARCH)
+	for _, spec := range specs {
+		so := o
+		so.Source = spec.source
+		if spec.source != "" {
+			so.ProtoPaths = append(so.ProtoPaths[:len(so.ProtoPaths):len(so.ProtoPaths)], filepath.Dir(spec.source))
+			so.BundleRefs = !isURL(spec.source)
+		}
+		g := generator.New(so)
+		// 先生成一次，文档无法生成时给出原因，不进入基准
+		if _, err := g.Generate(spec.data); err != nil {
+			fatal("generate failed", "spec", spec.name, "err", err)
+		}
+		for i := 0; i < count; i++ {
+			result := testing.Benchmark(func(b *testing.B) { generator.BenchmarkSpec(b, g, spec.data) })
+			if result.N == 0 {
+				fatal("benchmark failed", "spec", spec.name)
+			}
+			fmt.Printf("BenchmarkGenerate/%s\t%s\t%s\n", spec.name, result.String(), result.MemString())
+		}
+	}
+}
+
+// benchSpecs 读取 -f 指定的文档，没有指定时返回内置的合成文档
+func benchSpecs(files []string) ([]benchSpec, error) {
+	if len(files) == 0 {
+		var specs []benchSpec
+		for _, size := range generator.SyntheticSizes {
+			specs = append(specs, benchSpec{name: size.Name, data: generator.SyntheticSpec(size.Operations, size.Schemas)})
+		}
+		return specs, nil
+	}
+	specFiles, err := expandSpecFiles(files)
+	if err != nil {
+		return nil, err
+	}
+	var specs []benchSpec
+	for _, file := range specFiles {
+		data, err := readSpec(file)
+		if err != nil {
+			return nil, err
+		}
+		// 基准名称中不能有空白
+		name := strings.Join(strings.Fields(specName(file)), "_")
+		specs = append(specs, benchSpec{name: name, source: file, data: data})
+	}
+	return specs, nil
+}
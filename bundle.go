@@ -0,0 +1,15 @@
+// bundle.go
+package main
+
+import (
+	"github.com/aide-family/moonbeam/pkg/generator"
+)
+
+// runBundle 执行 moonbeam bundle：把生成使用的 OpenAPI 文档写到 -o 指定的文件，没有指定时写到标准输出；
+// proto 和 Postman 输入写出转换后的文档
+func runBundle(_ string, args []string) {
+	given := parseFlags(args)
+	writeSpecOutput("bundle", given, func(g *generator.Generator, data []byte) ([]byte, error) {
+		return g.OpenAPI(data)
+	})
+}
@@ -0,0 +1,107 @@
+// cache.go
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var (
+	// cacheDir -cache-dir 远程文档的缓存目录，为空时使用用户缓存目录下的 moonbeam/specs
+	cacheDir string
+	// noCache -no-cache 不使用也不更新远程文档的缓存
+	noCache bool
+)
+
+// specCacheEntry 一个远程文档的缓存：内容保存在 <key>.spec，条件请求需要的响应头保存在 <key>.json
+type specCacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Fetched      time.Time `json:"fetched"`
+
+	path string // 不含扩展名的缓存文件路径
+	data []byte // 缓存的内容，没有缓存时为 nil
+}
+
+// specCacheDir 返回缓存目录，-no-cache 或无法确定用户缓存目录时返回空字符串
+func specCacheDir() string {
+	if noCache {
+		return ""
+	}
+	if cacheDir != "" {
+		return cacheDir
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "moonbeam", "specs")
+}
+
+// loadSpecCache 读取 rawURL 的缓存，按 URL 的 SHA-256 命名；不使用缓存时返回 nil，没有缓存或缓存损坏时 data 为空
+func loadSpecCache(rawURL string) *specCacheEntry {
+	dir := specCacheDir()
+	if dir == "" {
+		return nil
+	}
+	sum := sha256.Sum256([]byte(rawURL))
+	entry := &specCacheEntry{URL: rawURL, path: filepath.Join(dir, hex.EncodeToString(sum[:16]))}
+	meta, err := os.ReadFile(entry.path + ".json")
+	if err != nil {
+		return entry
+	}
+	var cached specCacheEntry
+	if json.Unmarshal(meta, &cached) != nil || cached.URL != rawURL {
+		return entry
+	}
+	data, err := os.ReadFile(entry.path + ".spec")
+	if err != nil {
+		return entry
+	}
+	cached.path, cached.data = entry.path, data
+	return &cached
+}
+
+// store 保存新下载的内容；先写内容再写响应头，两者都通过临时文件改名写入，中断时不会留下不一致的缓存
+func (e *specCacheEntry) store(data []byte, etag, lastModified string) error {
+	e.data, e.ETag, e.LastModified, e.Fetched = data, etag, lastModified, time.Now().UTC()
+	if err := os.MkdirAll(filepath.Dir(e.path), 0o700); err != nil {
+		return err
+	}
+	meta, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return err
+	}
+	// 文档可能需要认证才能下载，缓存只对当前用户可读
+	if err := writeFileAtomic(e.path+".spec", data); err != nil {
+		return err
+	}
+	return writeFileAtomic(e.path+".json", meta)
+}
+
+// writeFileAtomic 写入同目录下的临时文件后改名
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// color.go
+package main
+
+import (
+	"os"
+	"strings"
+)
+
+// noColor -no-color 不使用颜色，与设置 NO_COLOR 环境变量相同
+var noColor bool
+
+// ANSI 颜色
+const (
+	colorRed    = "31"
+	colorGreen  = "32"
+	colorYellow = "33"
+	colorCyan   = "36"
+	colorBold   = "1"
+	colorDim    = "2"
+)
+
+// style 一个输出流（stdout 或 stderr）的显示方式：终端上使用符号（❌、⚠️ 等）和颜色，
+// 重定向到文件或管道时输出不含转义序列的纯文本，便于 grep 和 CI 日志
+type style struct {
+	terminal bool // 输出到终端，使用符号和进度条
+	color    bool // 使用颜色：输出到终端，且没有 -no-color、NO_COLOR 和 TERM=dumb
+}
+
+var (
+	stdoutStyle = detectStyle(os.Stdout)
+	stderrStyle = detectStyle(os.Stderr)
+)
+
+// detectStyle 根据 f 是否为终端以及 -no-color、NO_COLOR、TERM 决定显示方式
+func detectStyle(f *os.File) style {
+	terminal := isTerminal(f)
+	return style{
+		terminal: terminal,
+		color:    terminal && !noColor && os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb",
+	}
+}
+
+// setupStyles 解析参数后重新检测，使 -no-color 生效
+func setupStyles() {
+	stdoutStyle, stderrStyle = detectStyle(os.Stdout), detectStyle(os.Stderr)
+}
+
+// paint 使用颜色时用 code 给 s 着色
+func (s style) paint(code, text string) string {
+	if !s.color || text == "" {
+		return text
+	}
+	return "\033[" + code + "m" + text + "\033[0m"
+}
+
+// symbol 终端上返回符号，否则返回纯文本
+func (s style) symbol(symbol, plain string) string {
+	if s.terminal {
+		return symbol
+	}
+	return plain
+}
+
+// paintDiff 给统一 diff 的各行着色：文件头加粗，@@ 青色，删除红色，新增绿色
+func (s style) paintDiff(diff string) string {
+	if !s.color {
+		return diff
+	}
+	lines := strings.SplitAfter(diff, "\n")
+	for i, line := range lines {
+		text := strings.TrimSuffix(line, "\n")
+		newline := line[len(text):]
+		switch {
+		case strings.HasPrefix(text, "--- "), strings.HasPrefix(text, "+++ "):
+			lines[i] = s.paint(colorBold, text) + newline
+		case strings.HasPrefix(text, "@@"):
+			lines[i] = s.paint(colorCyan, text) + newline
+		case strings.HasPrefix(text, "-"):
+			lines[i] = s.paint(colorRed, text) + newline
+		case strings.HasPrefix(text, "+"):
+			lines[i] = s.paint(colorGreen, text) + newline
+		}
+	}
+	return strings.Join(lines, "")
+}
@@ -0,0 +1,296 @@
+// config.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configFiles 未指定 -config 时在当前目录依次查找的配置文件
+var configFiles = []string{"moonbeam.yaml", "moonbeam.yml", "moonbeam.json"}
+
+// Config moonbeam.yaml / moonbeam.json 配置文件，每个字段通过 flag 标签对应一个命令行参数，命令行参数优先
+type Config struct {
+	Input             stringList `yaml:"input" json:"input" flag:"f"`
+	Output            string     `yaml:"output" json:"output" flag:"o"`
+	Force             bool       `yaml:"force" json:"force" flag:"force"`
+	Prune             bool       `yaml:"prune" json:"prune" flag:"prune"`
+	Merge             bool       `yaml:"merge" json:"merge" flag:"merge"`
+	Pagination        string     `yaml:"pagination" json:"pagination" flag:"pagination"`
+	Client            string     `yaml:"client" json:"client" flag:"client"`
+	Hooks             string     `yaml:"hooks" json:"hooks" flag:"hooks"`
+	Classes           bool       `yaml:"classes" json:"classes" flag:"classes"`
+	Validators        string     `yaml:"validators" json:"validators" flag:"validators"`
+	Forms             string     `yaml:"forms" json:"forms" flag:"forms"`
+	JSONSchema        string     `yaml:"jsonSchema" json:"jsonSchema" flag:"json-schema"`
+	Mocks             bool       `yaml:"mocks" json:"mocks" flag:"mocks"`
+	ContractTests     string     `yaml:"contractTests" json:"contractTests" flag:"contract-tests"`
+	UnitTests         string     `yaml:"unitTests" json:"unitTests" flag:"unit-tests"`
+	ValidateResponses string     `yaml:"validateResponses" json:"validateResponses" flag:"validate-responses"`
+	Lang              string     `yaml:"lang" json:"lang" flag:"lang"`
+	GoPackage         string     `yaml:"goPackage" json:"goPackage" flag:"go-package"`
+	PackageName       string     `yaml:"packageName" json:"packageName" flag:"package-name"`
+	PackageVersion    string     `yaml:"packageVersion" json:"packageVersion" flag:"package-version"`
+	Workspace         bool       `yaml:"workspace" json:"workspace" flag:"workspace"`
+	InputFormat       string     `yaml:"inputFormat" json:"inputFormat" flag:"input-format"`
+	ProtoPaths        stringList `yaml:"protoPaths" json:"protoPaths" flag:"proto-path"`
+	Headers           stringList `yaml:"headers" json:"headers" flag:"header"`
+	Insecure          bool       `yaml:"insecure" json:"insecure" flag:"insecure"`
+	CACert            string     `yaml:"caCert" json:"caCert" flag:"ca-cert"`
+	CacheDir          string     `yaml:"cacheDir" json:"cacheDir" flag:"cache-dir"`
+	NoCache           bool       `yaml:"noCache" json:"noCache" flag:"no-cache"`
+	Watch             bool       `yaml:"watch" json:"watch" flag:"watch"`
+	DryRun            bool       `yaml:"dryRun" json:"dryRun" flag:"dry-run"`
+	Quiet             bool       `yaml:"quiet" json:"quiet" flag:"quiet"`
+	NoProgress        bool       `yaml:"noProgress" json:"noProgress" flag:"no-progress"`
+	Jobs              int        `yaml:"jobs" json:"jobs" flag:"jobs"`
+	NoColor           bool       `yaml:"noColor" json:"noColor" flag:"no-color"`
+	Verbose           bool       `yaml:"verbose" json:"verbose" flag:"verbose"`
+	LogFormat         string     `yaml:"logFormat" json:"logFormat" flag:"log-format"`
+	DiagnosticsFormat string     `yaml:"diagnosticsFormat" json:"diagnosticsFormat" flag:"diagnostics-format"`
+	Templates         string     `yaml:"templates" json:"templates" flag:"templates"`
+	HeaderFile        string     `yaml:"headerFile" json:"headerFile" flag:"header-file"`
+	OperationName     string     `yaml:"operationName" json:"operationName" flag:"operation-name"`
+	GroupBy           string     `yaml:"groupBy" json:"groupBy" flag:"group-by"`
+	GroupTypes        bool       `yaml:"groupTypes" json:"groupTypes" flag:"group-types"`
+	GroupEnums        bool       `yaml:"groupEnums" json:"groupEnums" flag:"group-enums"`
+	GroupTypesBy      string     `yaml:"groupTypesBy" json:"groupTypesBy" flag:"group-types-by"`
+	Layout            string     `yaml:"layout" json:"layout" flag:"layout"`
+	APIVersions       string     `yaml:"apiVersions" json:"apiVersions" flag:"api-versions"`
+	Provenance        bool       `yaml:"provenance" json:"provenance" flag:"provenance"`
+	TypeMappings      stringList `yaml:"typeMappings" json:"typeMappings" flag:"type-mapping"`
+	ImportAliases     stringList `yaml:"importAliases" json:"importAliases" flag:"import-alias"`
+	Strict            bool       `yaml:"strict" json:"strict" flag:"strict"`
+	WarningsAsErrors  bool       `yaml:"warningsAsErrors" json:"warningsAsErrors" flag:"warnings-as-errors"`
+	Diagnostics       stringList `yaml:"diagnostics" json:"diagnostics" flag:"diagnostic"`
+	UnsupportedReport string     `yaml:"unsupportedReport" json:"unsupportedReport" flag:"unsupported-report"`
+	FunctionCase      string     `yaml:"functionCase" json:"functionCase" flag:"function-case"`
+	TypePrefix        string     `yaml:"typePrefix" json:"typePrefix" flag:"type-prefix"`
+	TypeSuffix        string     `yaml:"typeSuffix" json:"typeSuffix" flag:"type-suffix"`
+	DirCase           string     `yaml:"dirCase" json:"dirCase" flag:"dir-case"`
+	EnumCase          string     `yaml:"enumCase" json:"enumCase" flag:"enum-case"`
+	Namespaces        string     `yaml:"namespaces" json:"namespaces" flag:"namespaces"`
+	SingleFile        string     `yaml:"singleFile" json:"singleFile" flag:"single-file"`
+	Ext               string     `yaml:"ext" json:"ext" flag:"ext"`
+	EmitJS            bool       `yaml:"emitJs" json:"emitJs" flag:"emit-js"`
+	ImportStyle       string     `yaml:"importStyle" json:"importStyle" flag:"import-style"`
+	TSC               string     `yaml:"tsc" json:"tsc" flag:"tsc"`
+	Plugins           stringList `yaml:"plugins" json:"plugins" flag:"plugin"`
+	PluginTimeout     string     `yaml:"pluginTimeout" json:"pluginTimeout" flag:"plugin-timeout"`
+	PostCmd           string     `yaml:"postCmd" json:"postCmd" flag:"post-cmd"`
+	SnapshotFile      string     `yaml:"snapshotFile" json:"snapshotFile" flag:"snapshot-file"`
+	Diff              bool       `yaml:"diff" json:"diff" flag:"diff"`
+	IncludeTags       stringList `yaml:"includeTags" json:"includeTags" flag:"include-tags"`
+	ExcludeTags       stringList `yaml:"excludeTags" json:"excludeTags" flag:"exclude-tags"`
+	IncludePaths      stringList `yaml:"includePaths" json:"includePaths" flag:"include-paths"`
+	ExcludePaths      stringList `yaml:"excludePaths" json:"excludePaths" flag:"exclude-paths"`
+	IncludeOperations stringList `yaml:"includeOperations" json:"includeOperations" flag:"include-operations"`
+	ExcludeOperations stringList `yaml:"excludeOperations" json:"excludeOperations" flag:"exclude-operations"`
+}
+
+// findConfig 返回配置文件路径，path 为空时自动查找，找不到返回空字符串
+func findConfig(path string) (string, error) {
+	if path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return "", err
+		}
+		return path, nil
+	}
+	for _, name := range configFiles {
+		if _, err := os.Stat(name); err == nil {
+			return name, nil
+		}
+	}
+	return "", nil
+}
+
+// loadConfig 读取配置文件，.json 使用 JSON 解析，其余按 YAML 解析
+// input、output、protoPaths、caCert、cacheDir、templates 为相对路径时相对于配置文件所在目录
+func loadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// 未知的键多半是拼错的选项，直接报错而不是静默忽略
+	var config Config
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&config)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err = decoder.Decode(&config); errors.Is(err, io.EOF) {
+			err = nil // 空文件
+		}
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) {
+			for i, message := range typeErr.Errors {
+				typeErr.Errors[i] = unknownYAMLField.ReplaceAllString(message, `unknown field "$1"`)
+			}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	// 先展开环境变量，展开后的 input 可能是 URL，路径再相对于配置文件解析
+	if err := expandConfigEnv(&config); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	resolve := func(p string) string {
+		if p != "" && !filepath.IsAbs(p) {
+			return filepath.Join(dir, p)
+		}
+		return p
+	}
+	for i, input := range config.Input {
+		if !isURL(input) {
+			config.Input[i] = resolve(input)
+		}
+	}
+	for i, dir := range config.ProtoPaths {
+		config.ProtoPaths[i] = resolve(dir)
+	}
+	config.CACert = resolve(config.CACert)
+	config.CacheDir = resolve(config.CacheDir)
+	config.Templates = resolve(config.Templates)
+	config.HeaderFile = resolve(config.HeaderFile)
+	config.SnapshotFile = resolve(config.SnapshotFile)
+	config.Output = resolve(config.Output)
+	// 只有路径形式的 tsc 相对于配置文件解析，命令名仍从 PATH 查找
+	if strings.ContainsAny(config.TSC, `/\`) {
+		config.TSC = resolve(config.TSC)
+	}
+	// 路径形式的插件同样相对于配置文件解析
+	for i, plugin := range config.Plugins {
+		if name, parameter, ok := strings.Cut(plugin, ":"); strings.ContainsAny(name, `/\`) {
+			config.Plugins[i] = resolve(name)
+			if ok {
+				config.Plugins[i] += ":" + parameter
+			}
+		}
+	}
+	return &config, nil
+}
+
+// unknownYAMLField yaml.v3 报告未知键的错误，改成与 encoding/json 相同的说法，不暴露 Go 类型名
+var unknownYAMLField = regexp.MustCompile(`field (\S+) not found in type \S+`)
+
+// envPattern 配置中的环境变量引用 ${NAME} 或 ${NAME:-默认值}，$${NAME} 表示字面的 ${NAME}
+var envPattern = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// expandConfigEnv 展开配置中所有字符串值里的环境变量引用，同一份配置可以用于不同的环境；
+// 变量未设置（或为空）且没有默认值时返回错误，而不是把空值当作路径或 URL 使用
+func expandConfigEnv(config *Config) error {
+	v := reflect.ValueOf(config).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		var values []string
+		switch value := field.Addr().Interface().(type) {
+		case *string:
+			values = []string{*value}
+		case *stringList:
+			values = *value
+		default:
+			continue
+		}
+		for j, value := range values {
+			expanded, err := expandEnv(value)
+			if err != nil {
+				return fmt.Errorf("config %s: %w", t.Field(i).Tag.Get("yaml"), err)
+			}
+			values[j] = expanded
+		}
+		if field.Kind() == reflect.String {
+			field.SetString(values[0])
+		}
+	}
+	return nil
+}
+
+// expandEnv 展开 s 中的环境变量引用，见 envPattern
+func expandEnv(s string) (string, error) {
+	var missing []string
+	expanded := envPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		if strings.HasPrefix(ref, "$$") {
+			return ref[1:]
+		}
+		m := envPattern.FindStringSubmatch(ref)
+		if value := os.Getenv(m[1]); value != "" {
+			return value
+		}
+		if strings.Contains(ref, ":-") {
+			return m[2]
+		}
+		missing = append(missing, m[1])
+		return ref
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variable %s is not set", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+// applyConfig 将配置写入 fs 中未在命令行显式设置的参数，fs 未定义的参数忽略
+func applyConfig(fs *flag.FlagSet, config *Config) error {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	v := reflect.ValueOf(config).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("flag")
+		if name == "" || explicit[name] || fs.Lookup(name) == nil {
+			continue
+		}
+		field := v.Field(i)
+		if field.IsZero() {
+			continue
+		}
+		values := []string{fmt.Sprint(field.Interface())}
+		if list, ok := field.Interface().(stringList); ok {
+			values = list
+		}
+		for _, value := range values {
+			if err := fs.Set(name, value); err != nil {
+				return fmt.Errorf("config %s: %w", t.Field(i).Tag.Get("yaml"), err)
+			}
+		}
+	}
+	return nil
+}
+
+// loadFlagsFromConfig 查找并应用配置文件，返回使用的配置文件路径
+func loadFlagsFromConfig(fs *flag.FlagSet, path string) string {
+	configPath, err := findConfig(path)
+	if err != nil {
+		fatalUsage("failed to read config file", "err", err)
+	}
+	if configPath == "" {
+		return ""
+	}
+	config, err := loadConfig(configPath)
+	if err == nil {
+		err = applyConfig(fs, config)
+	}
+	if err != nil {
+		fatalUsage("failed to load config file", "err", err)
+	}
+	return configPath
+}
@@ -0,0 +1,163 @@
+// diagnostics.go
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	// diagnosticsFormat -diagnostics-format 警告和错误的输出格式：text 与其他日志相同，json 每行一个诊断对象，
+	// github 为 GitHub Actions 的 ::warning / ::error 注释
+	diagnosticsFormat string
+	// diagnosticSpec 当前检查或生成的文档，诊断中没有 spec 时使用
+	diagnosticSpec string
+)
+
+// diagnostic -diagnostics-format json 输出的一条警告或错误
+type diagnostic struct {
+	Severity string            `json:"severity"`           // warning、error
+	Rule     string            `json:"rule,omitempty"`     // 诊断规则，见 -diagnostic；不属于任何规则时为空
+	Spec     string            `json:"spec,omitempty"`     // 文档文件或 URL
+	Location string            `json:"location,omitempty"` // 文档中的 JSON Pointer
+	Line     int64             `json:"line,omitempty"`
+	Message  string            `json:"message"`
+	Details  map[string]string `json:"details,omitempty"` // 日志中的其他字段，例如 feature、ref、err
+}
+
+// setupDiagnostics 按 -diagnostics-format 包装 logger：警告和错误按诊断格式输出到 stderr，其他日志不变
+func setupDiagnostics(format string) error {
+	switch format {
+	case "", "text":
+		return nil
+	case "json", "github":
+		logger = slog.New(&diagnosticsHandler{Handler: logger.Handler(), format: format, mu: &sync.Mutex{}, w: stderr})
+		return nil
+	}
+	return fmt.Errorf("unsupported diagnostics format %q, expected one of text, json, github", format)
+}
+
+// diagnosticsHandler 把警告和错误写为诊断，其余日志交给原来的 Handler
+type diagnosticsHandler struct {
+	slog.Handler
+	format string // json、github
+	mu     *sync.Mutex
+	w      io.Writer
+	attrs  []slog.Attr
+}
+
+func (h *diagnosticsHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= slog.LevelWarn || h.Handler.Enabled(ctx, level)
+}
+
+func (h *diagnosticsHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < slog.LevelWarn {
+		return h.Handler.Handle(ctx, r)
+	}
+	d := diagnostic{Severity: "warning", Spec: diagnosticSpec, Message: r.Message}
+	if r.Level >= slog.LevelError {
+		d.Severity = "error"
+	}
+	read := func(a slog.Attr) bool {
+		switch a.Key {
+		case "rule":
+			d.Rule = a.Value.String()
+		case "spec":
+			d.Spec = a.Value.String()
+		case "at":
+			d.Location = a.Value.String()
+		case "line":
+			if a.Value.Kind() == slog.KindInt64 {
+				d.Line = a.Value.Int64()
+				break
+			}
+			fallthrough
+		default:
+			if d.Details == nil {
+				d.Details = make(map[string]string)
+			}
+			d.Details[a.Key] = a.Value.String()
+		}
+		return true
+	}
+	for _, a := range h.attrs {
+		read(a)
+	}
+	r.Attrs(read)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.format == "github" {
+		_, err := io.WriteString(h.w, githubAnnotation(d))
+		return err
+	}
+	// 消息中的 -> 等字符保持原样，便于直接阅读
+	encoder := json.NewEncoder(h.w)
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(d)
+}
+
+func (h *diagnosticsHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.Handler = h.Handler.WithAttrs(attrs)
+	clone.attrs = append(append([]slog.Attr(nil), h.attrs...), attrs...)
+	return &clone
+}
+
+func (h *diagnosticsHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.Handler = h.Handler.WithGroup(name)
+	return &clone
+}
+
+// githubAnnotation GitHub Actions 的注释命令，例如 ::warning file=openapi.yaml,line=36,title=any-type::message；
+// 文档是本地文件时标注在文件的行上，显示在修改了文档的 PR 中；其他字段附在消息之后
+func githubAnnotation(d diagnostic) string {
+	var properties []string
+	if d.Spec != "" && !isURL(d.Spec) {
+		properties = append(properties, "file="+githubEscapeProperty(filepath.ToSlash(d.Spec)))
+		if d.Line > 0 {
+			properties = append(properties, fmt.Sprintf("line=%d", d.Line))
+		}
+	}
+	title := "moonbeam"
+	if d.Rule != "" {
+		title += " " + d.Rule
+	}
+	properties = append(properties, "title="+githubEscapeProperty(title))
+
+	message := d.Message
+	if d.Location != "" {
+		message += " at " + d.Location
+	}
+	keys := make([]string, 0, len(d.Details))
+	for key := range d.Details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		message += fmt.Sprintf(" %s=%s", key, formatAttrValue(slog.StringValue(d.Details[key])))
+	}
+	return fmt.Sprintf("::%s %s::%s\n", d.Severity, strings.Join(properties, ","), githubEscapeData(message))
+}
+
+// githubEscapeData、githubEscapeProperty 按 GitHub Actions 注释命令的规则转义消息和属性
+var (
+	githubDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+func githubEscapeData(s string) string {
+	return githubDataEscaper.Replace(s)
+}
+
+func githubEscapeProperty(s string) string {
+	return githubPropertyEscaper.Replace(s)
+}
@@ -0,0 +1,186 @@
+// diff.go
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext 统一 diff 中变更前后保留的上下文行数
+const diffContext = 3
+
+// diffOp 单行编辑操作，Kind 为 ' '、'-' 或 '+'
+type diffOp struct {
+	Kind byte
+	Line string
+}
+
+// printDiff 输出预览结果相对现有输出目录的统一 diff
+func printDiff(changes []fileChange) {
+	changed := 0
+	for _, change := range changes {
+		if change.Action == "unchanged" {
+			continue
+		}
+		changed++
+		oldName, newName := "a/"+change.Path, "b/"+change.Path
+		switch change.Action {
+		case "create":
+			oldName = "/dev/null"
+		case "delete":
+			newName = "/dev/null"
+		}
+		fmt.Print(stdoutStyle.paintDiff(unifiedDiff(oldName, newName, string(change.Old), string(change.New))))
+	}
+	if changed == 0 {
+		fmt.Println(stdoutStyle.symbol("✅ ", "") + "no changes")
+	}
+}
+
+// unifiedDiff 生成两个文本的统一 diff，内容相同时返回空字符串
+func unifiedDiff(oldName, newName, oldText, newText string) string {
+	ops := diffLines(splitLines(oldText), splitLines(newText))
+
+	var b strings.Builder
+	oldLine, newLine := 1, 1
+	for start := 0; start < len(ops); {
+		// 找到下一处变更
+		first := start
+		for first < len(ops) && ops[first].Kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		// 合并间隔不超过 2*diffContext 行的变更
+		last := first
+		for i := first; i < len(ops); i++ {
+			if ops[i].Kind != ' ' {
+				last = i
+			} else if i-last > 2*diffContext {
+				break
+			}
+		}
+
+		from := first - diffContext
+		if from < start {
+			from = start
+		}
+		to := last + diffContext + 1
+		if to > len(ops) {
+			to = len(ops)
+		}
+		// start 到 from 之间均为未变化的行
+		oldLine += from - start
+		newLine += from - start
+
+		if b.Len() == 0 {
+			fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+		}
+		oldCount, newCount := 0, 0
+		for _, op := range ops[from:to] {
+			if op.Kind != '+' {
+				oldCount++
+			}
+			if op.Kind != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(oldLine, oldCount), hunkRange(newLine, newCount))
+		for _, op := range ops[from:to] {
+			fmt.Fprintf(&b, "%c%s\n", op.Kind, op.Line)
+		}
+		oldLine += oldCount
+		newLine += newCount
+		start = to
+	}
+	return b.String()
+}
+
+// hunkRange 格式化 hunk 头中的行号范围，空范围的起始行为前一行
+func hunkRange(start, count int) string {
+	if count == 0 {
+		start--
+	}
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// splitLines 按行拆分文本，忽略末尾换行
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines 使用 Myers 算法计算最短编辑序列
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+	// trace[d] 保存第 d 轮开始前 k ∈ [-d-1, d+1] 的 v 值
+	var trace [][]int
+
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrackDiff(trace, a, b)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrackDiff 从 trace 反向还原编辑序列
+func backtrackDiff(trace [][]int, a, b []string) []diffOp {
+	var ops []diffOp
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		at := func(k int) int { return v[k+d+1] }
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			ops = append(ops, diffOp{' ', a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				ops = append(ops, diffOp{'+', b[y-1]})
+				y--
+			} else {
+				ops = append(ops, diffOp{'-', a[x-1]})
+				x--
+			}
+		}
+	}
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
@@ -0,0 +1,53 @@
+// docs.go
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/aide-family/moonbeam/pkg/generator"
+)
+
+// runDocs 执行 moonbeam docs：把 Markdown 格式的接口参考文档写到 -o 指定的文件，没有指定时写到标准输出
+func runDocs(_ string, args []string) {
+	given := parseFlags(args)
+	writeSpecOutput("docs", given, func(g *generator.Generator, data []byte) ([]byte, error) {
+		return g.Docs(data)
+	})
+}
+
+// writeSpecOutput 读取唯一的文档，把 render 的结果写到命令行 -o 指定的文件，没有指定或为 - 时写到标准输出；
+// 配置文件中的 output 是代码的输出目录，这里不使用
+func writeSpecOutput(command string, given map[string]bool, render func(g *generator.Generator, data []byte) ([]byte, error)) {
+	if err := opts.Validate(); err != nil {
+		fatalUsage("invalid options", "err", err)
+	}
+	specFiles, err := expandSpecFiles(apiFiles)
+	if err != nil {
+		fatal("invalid spec input", "err", err)
+	}
+	if len(specFiles) != 1 {
+		fatalUsage(command+" supports a single spec", "specs", len(specFiles))
+	}
+	specFile := specFiles[0]
+	diagnosticSpec = specFile
+	data, err := readSpec(specFile)
+	if err != nil {
+		fatal("failed to read API file", "err", err)
+	}
+	result, err := render(generator.New(specOptions(specFile)), data)
+	if err != nil {
+		fatal(command+" failed", "spec", specFile, "err", err)
+	}
+	if !given["o"] || outputDir == stdoutOutput {
+		os.Stdout.Write(result)
+		return
+	}
+	if err := makeDir(filepath.Dir(outputDir)); err != nil {
+		fatal("create output directory failed", "err", err)
+	}
+	if err := os.WriteFile(outputDir, result, 0644); err != nil {
+		fatal("write file failed", "file", outputDir, "err", err)
+	}
+	logger.Info(command+" written", "file", outputDir)
+}
@@ -0,0 +1,249 @@
+// interactive.go
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator"
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// interactive -interactive 生成之前在终端上勾选要生成的 tag 和接口，勾选结果转换为 -include-tags 和 -include-operations
+var interactive bool
+
+// checkItem 勾选列表中的一项
+type checkItem struct {
+	label   string
+	checked bool
+}
+
+// selectInteractively 列出 specFile 中（经过已有的过滤条件）的 tag 和接口供勾选，把勾选结果写入 opts 的过滤条件；
+// 提示和列表输出到 stderr，标准输出可以用于 -o -
+func selectInteractively(specFile string) {
+	if !isTerminal(os.Stdin) {
+		fatalUsage("-interactive needs a terminal on stdin")
+	}
+	data, err := readSpec(specFile)
+	if err != nil {
+		fatal("failed to read API file", "err", err)
+	}
+	api, err := generator.New(specOptions(specFile)).IR(data)
+	if err != nil {
+		fatal("parse spec failed", "spec", specFile, "err", err)
+	}
+	if len(api.Operations) == 0 {
+		fatal("no operations to select", "spec", specFile)
+	}
+
+	in := bufio.NewReader(os.Stdin)
+	tags, counts, untagged := operationTags(api.Operations)
+	selectedTags := tags
+	if len(tags) > 1 {
+		items := make([]checkItem, len(tags))
+		for i, tag := range tags {
+			items[i] = checkItem{label: fmt.Sprintf("%s (%d)", tag, counts[tag]), checked: true}
+		}
+		title := "Tags"
+		if untagged > 0 {
+			title += fmt.Sprintf(" (%d operations without a tag are kept only while every tag is selected)", untagged)
+		}
+		checked := checklist(in, os.Stderr, title, items)
+		selectedTags = nil
+		for i, tag := range tags {
+			if checked[i] {
+				selectedTags = append(selectedTags, tag)
+			}
+		}
+	}
+	allTags := len(selectedTags) == len(tags)
+
+	// 所选 tag 中的接口，只有带 operationId 的接口可以单独选择
+	var candidates []ir.Operation
+	withoutID := 0
+	for _, op := range api.Operations {
+		if !allTags && !hasAny(op.Tags, selectedTags) {
+			continue
+		}
+		if op.ID == "" {
+			withoutID++
+			continue
+		}
+		candidates = append(candidates, op)
+	}
+	var selectedIDs []string
+	if len(candidates) > 1 && confirm(in, os.Stderr, "Choose individual operations?") {
+		if withoutID > 0 {
+			fmt.Fprintf(os.Stderr, "%d operations without an operationId cannot be chosen individually and are left out\n", withoutID)
+		}
+		width := 0
+		for _, op := range candidates {
+			width = max(width, len(op.Path))
+		}
+		items := make([]checkItem, len(candidates))
+		for i, op := range candidates {
+			label := fmt.Sprintf("%-6s %-*s  %s", op.Method, width, op.Path, op.ID)
+			if op.Summary != "" {
+				label += "  " + op.Summary
+			}
+			items[i] = checkItem{label: label, checked: true}
+		}
+		checked := checklist(in, os.Stderr, "Operations", items)
+		all := withoutID == 0
+		for i, op := range candidates {
+			if checked[i] {
+				selectedIDs = append(selectedIDs, op.ID)
+			} else {
+				all = false
+			}
+		}
+		if all {
+			// 全部选中时不需要按 operationId 过滤
+			selectedIDs = nil
+		}
+	}
+
+	// 已有的 include 条件在列出接口时已经生效，替换为勾选的结果，exclude 条件保持不变
+	var flags []string
+	if !allTags {
+		opts.IncludeTags = []string{filterPatterns(selectedTags)}
+		flags = append(flags, "-include-tags '"+opts.IncludeTags[0]+"'")
+	}
+	if len(selectedIDs) > 0 {
+		opts.IncludeOperations = []string{filterPatterns(selectedIDs)}
+		flags = append(flags, "-include-operations '"+opts.IncludeOperations[0]+"'")
+	}
+	if len(flags) == 0 {
+		logger.Info("generating every operation")
+		return
+	}
+	// 输出等价的参数，以后可以直接使用或写入配置文件
+	logger.Info("selected operations", "flags", strings.Join(flags, " "))
+}
+
+// operationTags 接口的 tag 及每个 tag 的接口数量，tag 按名称排序；untagged 是没有 tag 的接口数量
+func operationTags(operations []ir.Operation) (tags []string, counts map[string]int, untagged int) {
+	counts = make(map[string]int)
+	for _, op := range operations {
+		if len(op.Tags) == 0 {
+			untagged++
+		}
+		for _, tag := range op.Tags {
+			if counts[tag] == 0 {
+				tags = append(tags, tag)
+			}
+			counts[tag]++
+		}
+	}
+	sort.Strings(tags)
+	return tags, counts, untagged
+}
+
+// checklist 显示勾选列表，输入编号或范围切换勾选，直到输入空行；至少要勾选一项
+func checklist(in *bufio.Reader, w io.Writer, title string, items []checkItem) []bool {
+	for {
+		fmt.Fprintf(w, "\n%s:\n", title)
+		for i, item := range items {
+			mark := " "
+			if item.checked {
+				mark = "x"
+			}
+			fmt.Fprintf(w, "  [%s] %*d  %s\n", mark, len(strconv.Itoa(len(items))), i+1, item.label)
+		}
+		fmt.Fprint(w, "Toggle numbers or ranges (1,3-5), a = all, n = none, Enter = done: ")
+		line := readLine(in)
+		switch line {
+		case "":
+			checked := make([]bool, len(items))
+			selected := false
+			for i, item := range items {
+				checked[i] = item.checked
+				selected = selected || item.checked
+			}
+			if selected {
+				return checked
+			}
+			fmt.Fprintln(w, "Select at least one.")
+		case "a", "n":
+			for i := range items {
+				items[i].checked = line == "a"
+			}
+		default:
+			numbers, err := parseSelection(line, len(items))
+			if err != nil {
+				fmt.Fprintln(w, err)
+				continue
+			}
+			for _, n := range numbers {
+				items[n-1].checked = !items[n-1].checked
+			}
+		}
+	}
+}
+
+// confirm 询问是或否，默认为否
+func confirm(in *bufio.Reader, w io.Writer, question string) bool {
+	fmt.Fprintf(w, "\n%s [y/N]: ", question)
+	answer := strings.ToLower(readLine(in))
+	return answer == "y" || answer == "yes"
+}
+
+// readLine 读取一行输入，输入结束（Ctrl-D）时取消生成
+func readLine(in *bufio.Reader) string {
+	line, err := in.ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Fprintln(os.Stderr)
+		fatalUsage("interactive selection cancelled")
+	}
+	return strings.TrimSpace(line)
+}
+
+// parseSelection 解析逗号或空格分隔的编号和范围，例如 1,3-5，编号从 1 到 n
+func parseSelection(input string, n int) ([]int, error) {
+	var numbers []int
+	for _, field := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' }) {
+		from, to, isRange := strings.Cut(field, "-")
+		if !isRange {
+			to = from
+		}
+		first, err1 := strconv.Atoi(from)
+		last, err2 := strconv.Atoi(to)
+		if err1 != nil || err2 != nil || first < 1 || last > n || first > last {
+			return nil, fmt.Errorf("invalid selection %q, expected numbers from 1 to %d", field, n)
+		}
+		for i := first; i <= last; i++ {
+			numbers = append(numbers, i)
+		}
+	}
+	return numbers, nil
+}
+
+// filterPatterns 把名称转换为逗号分隔的过滤模式，名称中的 glob 字符和逗号用 re: 转义
+func filterPatterns(names []string) string {
+	patterns := make([]string, len(names))
+	for i, name := range names {
+		if strings.ContainsAny(name, "*?,") || strings.HasPrefix(name, "re:") || strings.TrimSpace(name) != name {
+			name = "re:" + strings.ReplaceAll(regexp.QuoteMeta(name), ",", `\x2c`)
+		}
+		patterns[i] = name
+	}
+	return strings.Join(patterns, ",")
+}
+
+// hasAny values 中是否有 set 中的值
+func hasAny(values, set []string) bool {
+	for _, value := range values {
+		for _, s := range set {
+			if value == s {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+// logger.go
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// logger 全局日志，输出到 stderr，由 -quiet、-verbose、-log-format 配置
+// 生成结果（-diff、-dry-run 等）仍输出到 stdout
+var logger = slog.New(newTextHandler(stderr, slog.LevelInfo))
+
+// setupLogger 根据参数配置日志级别和格式：quiet 只输出警告和错误，verbose 额外输出每个生成的文件
+func setupLogger(quiet, verbose bool, format string) error {
+	level := slog.LevelInfo
+	switch {
+	case quiet:
+		level = slog.LevelWarn
+	case verbose:
+		level = slog.LevelDebug
+	}
+	switch format {
+	case "", "text":
+		logger = slog.New(newTextHandler(stderr, level))
+	case "json":
+		logger = slog.New(slog.NewJSONHandler(stderr, &slog.HandlerOptions{Level: level}))
+	default:
+		return fmt.Errorf("unsupported log format: %s", format)
+	}
+	return nil
+}
+
+// 退出码
+const (
+	exitError = 1 // 生成失败：文档无法读取或解析、生成过程中有错误（-warnings-as-errors 时包括警告）、写入失败
+	exitUsage = 2 // 命令行参数或配置文件无效，与 flag 包解析失败时相同
+)
+
+// fatal 输出错误日志并退出
+func fatal(msg string, args ...any) {
+	logger.Error(msg, args...)
+	exit(exitError)
+}
+
+// fatalUsage 输出错误日志并以 exitUsage 退出，用于参数和配置错误
+func fatalUsage(msg string, args ...any) {
+	logger.Error(msg, args...)
+	exit(exitUsage)
+}
+
+// textHandler 面向终端的紧凑文本格式：前缀 消息 key=value ...
+type textHandler struct {
+	mu    *sync.Mutex
+	w     io.Writer
+	level slog.Level
+	attrs []slog.Attr
+}
+
+func newTextHandler(w io.Writer, level slog.Level) *textHandler {
+	return &textHandler{mu: &sync.Mutex{}, w: w, level: level}
+}
+
+func (h *textHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *textHandler) Handle(_ context.Context, r slog.Record) error {
+	// 终端上用符号和颜色区分级别，重定向时用 error: / warning: 前缀
+	var b strings.Builder
+	switch {
+	case r.Level >= slog.LevelError:
+		b.WriteString(stderrStyle.paint(colorRed, stderrStyle.symbol("❌ ", "error: ")+r.Message))
+	case r.Level >= slog.LevelWarn:
+		b.WriteString(stderrStyle.paint(colorYellow, stderrStyle.symbol("⚠️ ", "warning: ")+r.Message))
+	case r.Level < slog.LevelInfo:
+		b.WriteString("  " + stderrStyle.paint(colorDim, r.Message))
+	default:
+		b.WriteString(r.Message)
+	}
+	write := func(a slog.Attr) bool {
+		fmt.Fprintf(&b, " %s=%s", stderrStyle.paint(colorDim, a.Key), formatAttrValue(a.Value))
+		return true
+	}
+	for _, a := range h.attrs {
+		write(a)
+	}
+	r.Attrs(write)
+	b.WriteString("\n")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, b.String())
+	return err
+}
+
+func (h *textHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append(append([]slog.Attr(nil), h.attrs...), attrs...)
+	return &clone
+}
+
+func (h *textHandler) WithGroup(string) slog.Handler {
+	return h
+}
+
+// formatAttrValue 包含空格或引号的值加引号
+func formatAttrValue(v slog.Value) string {
+	s := v.String()
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
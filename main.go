@@ -2,856 +2,512 @@
 package main
 
 import (
-	"bytes"
-	"embed"
 	"flag"
 	"fmt"
-	"io/ioutil"
-	"log"
 	"os"
 	"path"
 	"path/filepath"
-	"regexp"
 	"sort"
-	"strings"
-	"text/template"
 	"time"
-)
 
-//go:embed templates/*.tmpl
-var templateFS embed.FS
+	"github.com/aide-family/moonbeam/pkg/generator"
+)
 
 var (
-	outputDir string
-	apiFile   string
-	version   bool
-	force     bool
+	outputDir  string
+	apiFiles   stringList
+	version    bool
+	force      bool
+	configFile string
+	headers    stringList
+	insecure   bool
+	caCert     string
+	watch      bool
+	dryRun     bool
+	showDiff   bool
+
+	quiet     bool
+	verbose   bool
+	logFormat string
+	postCmd   string
+
+	unsupportedReport string
+	headerFile        string
+
+	// opts 生成选项，由命令行参数和配置文件设置
+	opts generator.Options
 )
 
+// stdoutOutput -o - 表示将合并后的文件写到标准输出
+const stdoutOutput = "-"
+
 func init() {
-	flag.StringVar(&outputDir, "o", path.Join("output", fmt.Sprintf("api-%d", time.Now().Unix())), "Output directory")
-	flag.StringVar(&apiFile, "f", "openapi.yaml", "API file")
-	flag.BoolVar(&version, "v", false, "Version")
-	flag.BoolVar(&force, "force", false, "Force overwrite output directory; default is false; if true, the output directory will be overwritten")
+	flag.StringVar(&outputDir, "o", path.Join("output", fmt.Sprintf("api-%d", time.Now().Unix())), "Output directory; '-' writes a single bundled file to stdout (implies -single-file)")
+	flag.Var(&apiFiles, "f", "API file or glob pattern, repeatable (default openapi.yaml); multiple specs are generated into <output>/<spec name>")
+	flag.BoolVar(&version, "v", false, "Print the version, and the commit and date it was built from when known")
+	flag.StringVar(&opts.Lang, "lang", "typescript", "Target language: typescript, go for a Go client package (structs, typed enum constants, Client methods using net/http with context.Context), python for a package of pydantic models and an httpx client, or dart for json_serializable models and a Dio client")
+	flag.StringVar(&opts.PackageName, "package-name", "", "Also emit package.json (with an exports map and a build script) and tsconfig.json so the output can be built with tsc and published as this npm package, e.g. @acme/api-client; requires -client")
+	flag.StringVar(&opts.PackageVersion, "package-version", "", "Version written to package.json (default: the spec's info.version, else 0.0.0)")
+	flag.BoolVar(&opts.Workspace, "workspace", false, "With -package-name, generate an npm workspace instead: one package per module (packages/<module>-api) sharing packages/api-common with the types and runtime")
+	flag.StringVar(&opts.GoPackage, "go-package", "api", "Package name of the generated Go client (-lang go)")
+	flag.StringVar(&opts.InputFormat, "input-format", "", "Input format: openapi, proto (.proto source), descriptor-set (protoc --descriptor_set_out / buf image) or postman (v2.1 collection JSON); defaults to detection by file extension and content")
+	flag.Var((*stringList)(&opts.ProtoPaths), "proto-path", "Directory searched for .proto imports, repeatable (like protoc -I); the spec's own directory is searched last")
+	flag.Var(&headers, "header", "HTTP header 'Name: value' sent when -f is a URL, repeatable")
+	flag.BoolVar(&insecure, "insecure", false, "Skip TLS certificate verification when -f is a URL")
+	flag.StringVar(&caCert, "ca-cert", "", "PEM file with extra CA certificates trusted when -f is a URL")
+	flag.StringVar(&cacheDir, "cache-dir", "", "Directory caching specs fetched from a URL, revalidated with ETag and Last-Modified (default: the user cache directory's moonbeam/specs)")
+	flag.BoolVar(&noCache, "no-cache", false, "Always download specs from a URL in full and do not cache them")
+	flag.BoolVar(&watch, "watch", false, "Regenerate when the spec or files it references change")
+	flag.BoolVar(&interactive, "interactive", false, "Before generating, pick the tags and operations to generate from checklists in the terminal; the choice becomes -include-tags and -include-operations")
+	flag.BoolVar(&dryRun, "dry-run", false, "Parse and render but only print which files would be created, updated or deleted")
+	flag.BoolVar(&showDiff, "diff", false, "Render into memory and print a unified diff against the existing output directory; nothing is written")
+	flag.Var((*stringList)(&opts.IncludeTags), "include-tags", "Only generate operations with a matching tag; glob or re:<regex>, comma separated, repeatable")
+	flag.Var((*stringList)(&opts.ExcludeTags), "exclude-tags", "Skip operations with a matching tag")
+	flag.Var((*stringList)(&opts.IncludePaths), "include-paths", "Only generate operations whose path matches, e.g. '/users/**'")
+	flag.Var((*stringList)(&opts.ExcludePaths), "exclude-paths", "Skip operations whose path matches")
+	flag.Var((*stringList)(&opts.IncludeOperations), "include-operations", "Only generate operations whose operationId matches")
+	flag.Var((*stringList)(&opts.ExcludeOperations), "exclude-operations", "Skip operations whose operationId matches")
+	flag.BoolVar(&quiet, "quiet", false, "Only log warnings and errors")
+	flag.BoolVar(&noColor, "no-color", false, "Do not color the output; also disabled by the NO_COLOR environment variable and when output is not a terminal")
+	flag.BoolVar(&noProgress, "no-progress", false, "Do not report progress on large specs (a progress bar on a terminal, otherwise a log line every few seconds)")
+	flag.BoolVar(&verbose, "verbose", false, "Also log every generated file")
+	flag.StringVar(&logFormat, "log-format", "text", "Log format written to stderr: text, json")
+	flag.StringVar(&diagnosticsFormat, "diagnostics-format", "text", "Format of warnings and errors written to stderr: text (like other logs), json (one object per line with severity, rule, spec, location, line and message) or github (GitHub Actions ::warning/::error annotations on the spec's lines)")
+	flag.StringVar(&opts.OperationName, "operation-name", "strip-tag", "How function names are derived from operationId: strip-tag (Team_GetRole -> getRole), last (pkg.Service/GetRole -> getRole), full, or a template such as '{{ .OperationID | trimPrefix \"api_\" }}'")
+	flag.StringVar(&opts.GroupBy, "group-by", "tag", "Module grouping of functions: tag, path-prefix, x-module, operation-prefix; falls back to the first tag")
+	flag.BoolVar(&opts.GroupTypes, "group-types", false, "Generate types used by only one module into <module>/types/index.ts instead of the shared types/index.ts")
+	flag.BoolVar(&opts.GroupEnums, "group-enums", false, "Generate enums used by only one module into <module>/enum.ts instead of the shared types/enum.ts, which re-exports them")
+	flag.StringVar(&opts.GroupTypesBy, "group-types-by", "usage", "How -group-types and -group-enums pick the module of a type: usage (the only module using it), namespace (the first namespace segment of the schema name, team.v1.Team goes to team)")
+	flag.StringVar(&opts.APIVersions, "api-versions", "mixed", "How operations under versioned paths such as /v2/users are told apart: mixed (one module, renamed on clashes), suffix (listUsersV2) or split (a v2/ directory per version)")
+	flag.StringVar(&opts.Layout, "layout", "modules", "Output layout: modules (a directory per module, types in types/index.ts) or split (models/<Type>.ts per type and enum, api/<module>.ts per module)")
+	flag.Var((*stringList)(&opts.TypeMappings), "type-mapping", "Generate a TypeScript type for a spec type and format, '<type>[/<format>]=<TypeScript type>[ from <module>]', repeatable, e.g. 'string/decimal=Big from big.js'; a module starting with . is relative to the output directory")
+	flag.Var((*stringList)(&opts.ImportAliases), "import-alias", "Import generated files across directories through a tsconfig path alias instead of ../, repeatable: '<alias>' for the output directory (e.g. '@/api'), '<directory>=<alias>' for one top-level directory such as types or a module")
+	flag.BoolVar(&opts.Provenance, "provenance", false, "Add an @see JSDoc tag to every generated function and interface with its spec location (method and path or schema, and the spec file and line)")
+	flag.BoolVar(&opts.Strict, "strict", false, "Fail on spec warnings, such as an unresolved $ref or an undeclared path parameter, instead of only logging them")
+	flag.BoolVar(&opts.WarningsAsErrors, "warnings-as-errors", false, "Fail (exit code 1) when generation logs any warning, such as an unsupported feature or a renamed operation")
+	flag.Var((*stringList)(&opts.Diagnostics), "diagnostic", "Level of a diagnostic rule, '<rule>=ignore|warn|error', repeatable; rules: missing-operation-id (default ignore), unresolved-ref, any-type, duplicate-name, unsupported (default warn); error fails the run")
+	flag.StringVar(&opts.Naming.FunctionCase, "function-case", "camel", "Function name casing: camel, snake")
+	flag.StringVar(&opts.Naming.TypePrefix, "type-prefix", "", "Prefix added to every generated interface name, e.g. I")
+	flag.StringVar(&opts.Naming.TypeSuffix, "type-suffix", "", "Suffix added to every generated interface name, e.g. Dto")
+	flag.StringVar(&opts.Naming.DirCase, "dir-case", "lower", "Module directory casing derived from the tag: lower, kebab, snake, camel")
+	flag.StringVar(&opts.Naming.EnumCase, "enum-case", "preserve", "Enum member name casing, values are unchanged: preserve, upper, pascal, camel")
+	flag.StringVar(&opts.Naming.Namespaces, "namespaces", "strip", "Schema names with a namespace such as api.v1.Team: strip keeps Team, prefix generates ApiV1Team, namespace also exports the TypeScript namespace api.v1 with Team")
+	flag.StringVar(&opts.SingleFile, "single-file", "", "Bundle enums, types, validators, runtime and all module functions into this one file inside the output directory, e.g. api.ts; modules become namespaces")
+	flag.StringVar(&opts.Ext, "ext", ".ts", "Extension of generated TypeScript files and relative imports: .ts, .mts, .cts, or .d.ts for declarations only (requires tsc)")
+	flag.BoolVar(&opts.EmitJS, "emit-js", false, "Compile the generated code with tsc into .js + .d.ts pairs (.mjs/.cjs with -ext .mts/.cts)")
+	flag.StringVar(&opts.ImportStyle, "import-style", "source", "How relative imports are written: source (extension of the generated file, e.g. ./index.ts), js (compiled extension for NodeNext, ./index.js), none (./index), directory (index files as their directory, ../types)")
+	flag.StringVar(&opts.TSC, "tsc", "", "TypeScript compiler used by -emit-js and -ext .d.ts; defaults to node_modules/.bin/tsc, then tsc on PATH")
+	flag.Var((*stringList)(&opts.Plugins), "plugin", "External generator 'name[:parameter]' run after the built-in output, repeatable; runs moonbeam-plugin-<name> from PATH (or the given executable path) with the IR as JSON on stdin and reads generated files as JSON from stdout")
+	flag.DurationVar(&opts.PluginTimeout, "plugin-timeout", time.Minute, "Stop a -plugin that runs longer than this and fail the generation, e.g. 30s or 5m")
+	flag.StringVar(&unsupportedReport, "unsupported-report", "", "Also write everything that was not generated or was generated as any (oneOf, inline bodies, unknown formats, ...) with its spec location to this JSON file")
+	flag.StringVar(&postCmd, "post-cmd", "", "Command run through the shell after files are written, e.g. 'prettier --write {out}'; {out} is the output directory, {files} the files written in this run; skipped when nothing changed")
+	flag.StringVar(&headerFile, "header-file", "", "File whose content (e.g. a copyright or license notice) is put at the top of every generated source file; plain text is commented out line by line")
+	flag.StringVar(&opts.TemplateDir, "templates", "", "Directory with custom templates; a file named like a built-in template (e.g. function.tmpl) replaces it, other templates keep the built-in version")
+	flag.StringVar(&snapshotFile, "snapshot-file", "moonbeam.snapshot.json", "Snapshot file of the snapshot command, recording a hash of every generated file")
+	flag.BoolVar(&updateSnapshot, "update", false, "snapshot: regenerate and write the hashes to the snapshot file")
+	flag.BoolVar(&checkSnapshot, "check", false, "snapshot: regenerate and fail (exit code 1) when any file was added, removed or changed compared to the snapshot file")
+	flag.StringVar(&configFile, "config", "", "Config file; defaults to moonbeam.yaml, moonbeam.yml or moonbeam.json in the current directory when present; command line flags take precedence")
+	flag.BoolVar(&force, "force", false, "Force clean output directory; default is false; if true, files in the output directory that are no longer generated will be removed")
+	flag.BoolVar(&merge, "merge", false, "Keep declarations, imports and re-exports that were added by hand to generated TypeScript files; generated declarations are updated from the spec")
+	flag.BoolVar(&prune, "prune", false, "Remove files listed in the output directory's .moonbeam-manifest.json by a previous run that are no longer generated; other files are left alone")
+	flag.StringVar(&opts.Pagination, "pagination", "", "Pagination envelope pattern '<items regex>:<meta regex>', e.g. 'list|items:total|page|pageSize'; matching replies are generated as Paginated<T>; empty disables")
+	flag.StringVar(&opts.Client, "client", "", "Client runtime: empty uses the external ../request.ts helper; 'axios' generates http.ts with a shared Axios instance; 'fetch' generates a zero-dependency Fetch API client")
+	flag.StringVar(&opts.Hooks, "hooks", "", "Hooks layer generated per module as hooks.ts: 'react-query' (TanStack Query) or 'swr' (GET operations only); empty disables")
+	flag.StringVar(&opts.Validators, "validators", "", "Runtime validators generated as types/schemas.ts: 'zod' or 'io-ts'; empty disables")
+	flag.StringVar(&opts.Forms, "forms", "", "Form validation schemas for request types generated as types/forms.ts: 'yup'; empty disables")
+	flag.StringVar(&opts.JSONSchema, "json-schema", "", "Also emit JSON Schema (draft 2020-12) for component schemas: 'split' writes schemas/<Name>.json, 'bundle' writes schemas.json; empty disables")
+	flag.BoolVar(&opts.Mocks, "mocks", false, "Also generate faker-based mock factories mockXxx(overrides?) in types/mocks.ts")
+	flag.StringVar(&opts.UnitTests, "unit-tests", "", "Generate unit test scaffolds in __tests__/<module>.spec.ts that mock the HTTP layer and check each function's method, path and params: vitest, jest")
+	flag.StringVar(&opts.ContractTests, "contract-tests", "", "Generate consumer contract tests in contract/: vitest, jest (requires -client and -validators)")
+	flag.StringVar(&opts.ValidateResponses, "validate-responses", "", "Validate responses with the generated validators outside production: warn, throw (requires -validators)")
+	flag.BoolVar(&opts.Classes, "classes", false, "Also generate one API class per module (tag) in api.ts, instantiated with a config object")
+	flag.IntVar(&opts.Jobs, "jobs", 0, "Render with at most N goroutines, e.g. the CPU quota of a CI container; 0 uses GOMAXPROCS, which defaults to the number of CPUs")
+	flag.StringVar(&cpuProfile, "cpuprofile", "", "Write a CPU profile of the run to this file, for go tool pprof")
+	flag.StringVar(&memProfile, "memprofile", "", "Write a heap profile to this file when moonbeam exits, for go tool pprof")
 }
 
-func main() {
-	flag.Parse()
-	if version {
-		fmt.Printf("moonbeam version %s\n", "v0.0.2")
-		os.Exit(0)
-	}
-	// 读取上传的文件内容
-	data, err := os.ReadFile(apiFile)
-	if err != nil {
-		fmt.Printf("❌ failed to read API file: %v\n", err)
-		log.Fatal(err)
-	}
+// command 子命令
+type command struct {
+	summary string
+	run     func(name string, args []string)
+}
 
-	api, err := ParseOpenAPI(data)
-	if err != nil {
-		fmt.Printf("❌ failed to parse OpenAPI: %v\n", err)
-		log.Fatal(err)
-	}
-	if force {
-		os.RemoveAll(outputDir)
-	}
-	// 创建输出目录
-	err = os.MkdirAll(outputDir, 0755)
-	if err != nil {
-		fmt.Printf("❌ create output directory failed: %v\n", err)
-		log.Fatal("create output directory failed:", err)
-	}
+// commands 子命令；generate、validate、diff、clean、snapshot、docs 和 bundle 共用生成的参数和配置文件（见 parseFlags），
+// 其余子命令有各自的参数；第一个参数不是子命令时按 generate 处理，兼容没有子命令的用法
+var commands map[string]command
 
-	// 加载模板
-	interfaceDefTmpl, err := template.ParseFS(templateFS, "templates/interface-definition.tmpl")
-	if err != nil {
-		fmt.Printf("❌ failed to parse interface-definition template: %v\n", err)
-		log.Fatal(err)
-	}
+func init() {
+	commands = map[string]command{
+		"generate":   {"Generate the client code (the default command)", runGenerate},
+		"validate":   {"Check the spec and report problems and unsupported features without generating", runValidate},
+		"diff":       {"Print a unified diff of what generate would change in the output directory", runGenerate},
+		"clean":      {"Remove files that a previous run generated and this run would not", runGenerate},
+		"snapshot":   {"Record (-update) or verify (-check) hashes of the generated files without writing them", runSnapshot},
+		"docs":       {"Write a Markdown reference of the operations and types", runDocs},
+		"bundle":     {"Write the OpenAPI document that generation uses, with other inputs converted", runBundle},
+		"mock":       {"Serve example responses for every operation", func(_ string, args []string) { runMock(args) }},
+		"collection": {"Export the spec as a Postman, Insomnia or Bruno collection", func(_ string, args []string) { runCollection(args) }},
+		"serve":      {"Expose the generator over HTTP", func(_ string, args []string) { runServe(args) }},
+		"bench":      {"Benchmark generation", func(_ string, args []string) { runBench(args) }},
+	}
+	flag.Usage = usage
+}
 
-	interfaceTmpl, err := template.ParseFS(templateFS, "templates/interface.tmpl")
-	if err != nil {
-		fmt.Printf("❌ failed to parse interface template: %v\n", err)
-		log.Fatal(err)
-	}
+// usage 列出子命令和共用的参数
+func usage() {
+	w := flag.CommandLine.Output()
+	fmt.Fprintf(w, "Usage: moonbeam [command] [flags]\n\nCommands:\n")
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-11s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintf(w, "\nRun 'moonbeam <command> -h' for the flags of mock, collection, serve and bench.\n")
+	fmt.Fprintf(w, "Flags of generate, validate, diff, clean, snapshot, docs and bundle:\n")
+	flag.PrintDefaults()
+}
 
-	functionTmpl, err := template.ParseFS(templateFS, "templates/function.tmpl")
-	if err != nil {
-		fmt.Printf("❌ failed to parse function template: %v\n", err)
-		log.Fatal(err)
+func main() {
+	name, args := "generate", os.Args[1:]
+	if len(args) > 0 {
+		if _, ok := commands[args[0]]; ok {
+			name, args = args[0], args[1:]
+		}
 	}
+	commands[name].run(name, args)
+}
 
-	fileTmpl, err := template.ParseFS(templateFS, "templates/file.tmpl")
-	if err != nil {
-		fmt.Printf("❌ failed to parse file template: %v\n", err)
-		log.Fatal(err)
+// rejectArgs 参数解析在第一个不是 flag 的参数处停止，其后的参数（例如 moonbeam -f x clean 中的 clean）会被忽略，
+// 因此有剩余参数时以 exitUsage 退出，而不是执行另一个命令
+func rejectArgs(fs *flag.FlagSet) {
+	if fs.NArg() == 0 {
+		return
 	}
-
-	indexTmpl, err := template.ParseFS(templateFS, "templates/index.tmpl")
-	if err != nil {
-		fmt.Printf("❌ failed to parse index template: %v\n", err)
-		log.Fatal(err)
+	if _, ok := commands[fs.Arg(0)]; ok {
+		fatalUsage("unexpected argument after the flags, the command must come first, e.g. 'moonbeam "+fs.Arg(0)+" -f openapi.yaml'", "arg", fs.Arg(0))
 	}
+	fatalUsage("unexpected argument after the flags, every flag needs a leading '-' and commands must come first", "arg", fs.Arg(0))
+}
 
-	// 按模块组织数据
-	modules := make(map[string]*ModuleData)
-	interfacesByModule := make(map[string]map[string]string) // module -> interfaceName -> interfaceCode
-	functionsByModule := make(map[string]map[string]string)  // module -> functionName -> functionCode
-	functionOrder := make(map[string]int)                    // 记录函数处理顺序
-
-	// 缓存所有枚举类型
-	enumTypes := make(map[string]bool)
-	for name, schema := range api.Components.Schemas {
-		if len(schema.Enum) > 0 {
-			// 只存储原始名称，保持完整的 ref 名称
-			enumTypes[name] = true
-		}
+// parseFlags 解析共用的参数并加载配置文件，配置日志；返回命令行上给出的参数，配置文件中的不算
+func parseFlags(args []string) map[string]bool {
+	flag.CommandLine.Parse(args)
+	given := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	usedConfig := loadFlagsFromConfig(flag.CommandLine, configFile)
+	setupStyles()
+	if err := setupLogger(quiet, verbose, logFormat); err != nil {
+		fatalUsage("invalid log format", "err", err)
 	}
-
-	// 处理所有接口定义
-	for name, schema := range api.Components.Schemas {
-		moduleName := getModuleFromSchemaName(name)
-		if _, exists := modules[moduleName]; !exists {
-			modules[moduleName] = &ModuleData{Name: moduleName}
-		}
-
-		// 初始化接口映射
-		if _, exists := interfacesByModule[moduleName]; !exists {
-			interfacesByModule[moduleName] = make(map[string]string)
+	if err := setupDiagnostics(diagnosticsFormat); err != nil {
+		fatalUsage("invalid diagnostics format", "err", err)
+	}
+	rejectArgs(flag.CommandLine)
+	if usedConfig != "" {
+		logger.Debug("using config file", "file", usedConfig)
+	}
+	if version {
+		fmt.Printf("moonbeam version %s\n", generator.Version)
+		if generator.Commit != "" {
+			fmt.Printf("commit %s\n", generator.Commit)
 		}
-
-		// 生成接口代码
-		interfaceCode := renderInterface(name, schema, interfaceDefTmpl, enumTypes)
-		// 只有当接口代码不为空时才添加到映射中
-		if interfaceCode != "" {
-			interfacesByModule[moduleName][name] = interfaceCode
+		if generator.Date != "" {
+			fmt.Printf("date %s\n", generator.Date)
 		}
+		exit(0)
 	}
+	opts.Logger = logger
+	return given
+}
 
-	// 处理所有API路径
-	processedFunctions := make(map[string]bool) // 用于去重
-	globalOrder := 0                            // 全局处理顺序计数器
-
-	// 先对路径进行排序，确保处理顺序的一致性
-	var sortedPaths []string
-	for path := range api.Paths {
-		sortedPaths = append(sortedPaths, path)
+// runGenerate 执行 moonbeam generate；diff 与 -diff 相同，clean 只删除不再生成的文件
+func runGenerate(name string, args []string) {
+	parseFlags(args)
+	if err := startProfiling(); err != nil {
+		fatalUsage("start profiling failed", "err", err)
 	}
-	sort.Strings(sortedPaths)
-
-	// 为有查询参数的请求生成请求类型（GET, DELETE 等）
-	generatedRequestTypes := make(map[string]bool)
-	for _, path := range sortedPaths {
-		pathItem := api.Paths[path]
-
-		// 处理所有HTTP方法的查询参数
-		operations := []struct {
-			op     *Operation
-			method string
-		}{
-			{pathItem.Get, "GET"},
-			{pathItem.Delete, "DELETE"},
-			{pathItem.Put, "PUT"},
-			{pathItem.Post, "POST"},
-		}
-
-		for _, opData := range operations {
-			if opData.op == nil || len(opData.op.Parameters) == 0 {
-				continue
-			}
-
-			// 只处理有查询参数的请求，且没有 RequestBody 的请求
-			if opData.op.RequestBody == nil {
-				requestTypeName := generateRequestTypeFromParameters(opData.op.Parameters, opData.op.OperationID)
-				if requestTypeName != "EmptyRequest" && !generatedRequestTypes[requestTypeName] {
-					generatedRequestTypes[requestTypeName] = true
-
-					// 生成请求类型接口
-					requestInterface := generateRequestInterfaceFromParameters(requestTypeName, opData.op.Parameters)
-					if requestInterface != "" {
-						moduleName := getModuleFromSchemaName("types")
-						if _, exists := interfacesByModule[moduleName]; !exists {
-							interfacesByModule[moduleName] = make(map[string]string)
-						}
-						interfacesByModule[moduleName][requestTypeName] = requestInterface
-					}
-				}
-			}
+	defer stopProfiling()
+	if updateSnapshot || checkSnapshot {
+		fatalUsage("-update and -check are only supported by the snapshot command")
+	}
+	clean := name == "clean"
+	if name == "diff" {
+		showDiff = true
+	}
+	if clean {
+		if outputDir == stdoutOutput {
+			fatalUsage("clean is not supported with -o -")
 		}
+		prune = true
 	}
-
-	for _, path := range sortedPaths {
-		pathItem := api.Paths[path]
-		// 处理所有HTTP方法，而不是只处理第一个
-		operations := []struct {
-			op     *Operation
-			method string
-		}{
-			{pathItem.Post, "POST"},
-			{pathItem.Get, "GET"},
-			{pathItem.Put, "PUT"},
-			{pathItem.Delete, "DELETE"},
+	if outputDir == stdoutOutput {
+		// 标准输出只能输出一个文件，隐含 -single-file
+		if opts.SingleFile == "" {
+			opts.SingleFile = "api.ts"
 		}
-
-		for _, opData := range operations {
-			if opData.op == nil {
-				continue
-			}
-
-			op := opData.op
-			method := opData.method
-
-			if op.OperationID == "" {
-				continue
-			}
-
-			moduleName := getModuleName(op.Tags)
-			if _, exists := modules[moduleName]; !exists {
-				modules[moduleName] = &ModuleData{Name: moduleName}
-			}
-
-			// 初始化函数映射
-			if _, exists := functionsByModule[moduleName]; !exists {
-				functionsByModule[moduleName] = make(map[string]string)
-			}
-
-			paramType := "EmptyRequest"
-
-			// 优先处理 RequestBody（POST/PUT 请求）
-			if op.RequestBody != nil {
-				for _, c := range op.RequestBody.Content {
-					if c.Schema.RefValue != "" {
-						paramType = cleanRef(c.Schema.RefValue)
-						break
-					}
-				}
-			} else if len(op.Parameters) > 0 {
-				// 处理 Parameters（GET 请求的查询参数）
-				paramType = generateRequestTypeFromParameters(op.Parameters, op.OperationID)
-			}
-
-			responseType := "EmptyReply"
-			if resp, ok := op.Responses["200"]; ok {
-				for _, c := range resp.Content {
-					if c.Schema.RefValue != "" {
-						responseType = cleanRef(c.Schema.RefValue)
-						break
-					}
-				}
-			}
-
-			summary := op.Summary
-			if summary == "" && len(op.Tags) > 0 {
-				summary = strings.Split(op.OperationID, "_")[1] + " " + strings.Join(op.Tags, ", ")
-			}
-
-			fnName := toCamel(strings.Split(op.OperationID, "_")[1])
-			fnName = strings.ToLower(fnName[:1]) + fnName[1:]
-
-			// 处理重复的函数名，自动添加编号
-			originalFnName := fnName
-			counter := 1
-			for {
-				// 检查这个函数名是否已经在这个模块中被使用过
-				fnNameExists := false
-				for key := range processedFunctions {
-					if strings.HasPrefix(key, fmt.Sprintf("%s_%s_", moduleName, fnName)) {
-						fnNameExists = true
-						break
-					}
-				}
-				if !fnNameExists {
-					break
-				}
-				// 如果存在，添加编号
-				counter++
-				fnName = fmt.Sprintf("%s%d", originalFnName, counter)
-			}
-
-			// 创建唯一标识符，用于去重 - 使用路径和操作ID的组合
-			uniqueKey := fmt.Sprintf("%s_%s_%s_%s", moduleName, fnName, method, path)
-
-			// 如果已经处理过这个函数，跳过
-			if processedFunctions[uniqueKey] {
-				continue
+		// 按固定顺序检查，同时设置多个选项时报告的总是同一个
+		for _, option := range []struct {
+			name string
+			set  bool
+		}{
+			{"-watch", watch}, {"-dry-run", dryRun}, {"-diff", showDiff}, {"-force", force}, {"-prune", prune}, {"-merge", merge}, {"-post-cmd", postCmd != ""},
+			{"-emit-js", opts.EmitJS}, {"-package-name", opts.PackageName != ""}, {"-plugin", len(opts.Plugins) > 0},
+		} {
+			if option.set {
+				fatalUsage(option.name + " is not supported with -o -")
 			}
-			processedFunctions[uniqueKey] = true
-
-			funcCode := renderFunction(FunctionData{
-				Summary:      summary,
-				FunctionName: fnName,
-				ParamType:    paramType,
-				ResponseType: responseType,
-				Method:       strings.ToUpper(method),
-				Path:         path,
-			}, functionTmpl)
-
-			// 将函数代码存储到临时映射中，使用函数名作为键
-			functionsByModule[moduleName][fnName] = funcCode
-
-			// 记录函数处理顺序，确保相同 OperationID 的接口按处理顺序排列
-			globalOrder++
-			functionOrder[fnName] = globalOrder
 		}
 	}
+	if err := loadHeaderFile(); err != nil {
+		fatalUsage("read header file failed", "err", err)
+	}
+	if err := opts.Validate(); err != nil {
+		fatalUsage("invalid options", "err", err)
+	}
+	if merge && force {
+		fatalUsage("-merge cannot be combined with -force, which removes hand-written files")
+	}
+	if merge && opts.Lang != "" && opts.Lang != generator.LangTypeScript {
+		fatalUsage("-merge only supports -lang typescript", "lang", opts.Lang)
+	}
 
-	// 首先生成所有接口文件
-	for moduleName, interfaces := range interfacesByModule {
-		if len(interfaces) == 0 {
-			continue
-		}
-
-		// 创建模块目录
-		moduleDir := filepath.Join(outputDir, moduleName)
-		err := os.MkdirAll(moduleDir, 0755)
+	var templateFiles []string
+	if opts.TemplateDir != "" {
+		overrides, ignored, err := generator.CheckTemplateDir(opts.TemplateDir)
 		if err != nil {
-			fmt.Printf("❌ create module directory failed %s: %v\n", moduleName, err)
-			log.Printf("create module directory failed %s: %v", moduleName, err)
-			continue
-		}
-
-		// 生成接口文件
-		var usedEnums []string
-		if moduleName == "types" {
-			usedEnums = extractUsedEnums(interfaces, enumTypes)
-		}
-
-		// 创建排序后的接口名称列表
-		var sortedNames []string
-		for name := range interfaces {
-			sortedNames = append(sortedNames, name)
+			fatal("invalid template directory", "err", err)
 		}
-		sort.Strings(sortedNames)
-
-		interfaceData := InterfaceFileData{
-			ModuleName:  moduleName,
-			Interfaces:  interfaces,
-			UsedEnums:   usedEnums,
-			SortedNames: sortedNames,
-		}
-
-		var buf bytes.Buffer
-		err = interfaceTmpl.Execute(&buf, interfaceData)
-		if err != nil {
-			fmt.Printf("❌ interface template execution failed %s: %v\n", moduleName, err)
-			log.Printf("interface template execution failed %s: %v", moduleName, err)
-			continue
+		for _, file := range ignored {
+			logger.Warn("template does not override a built-in template, ignored", "file", file)
 		}
-
-		filename := filepath.Join(moduleDir, "index.ts")
-		err = ioutil.WriteFile(filename, buf.Bytes(), 0644)
-		if err != nil {
-			fmt.Printf("❌ write interface file failed %s: %v\n", filename, err)
-			log.Printf("write interface file failed %s: %v", filename, err)
-		} else {
-			fmt.Printf("✅ generate interface file: %s\n", filename)
+		for _, file := range overrides {
+			logger.Debug("using template override", "file", file)
 		}
+		templateFiles = overrides
 	}
 
-	// 生成枚举文件
-	if len(api.Components.Schemas) > 0 {
-		// 收集所有枚举
-		var allEnums []EnumData
-		for name, schema := range api.Components.Schemas {
-			if len(schema.Enum) > 0 {
-				enumValues := make([]string, 0, len(schema.Enum))
-				for _, value := range schema.Enum {
-					if str, ok := value.(string); ok {
-						enumValues = append(enumValues, str)
-					}
-				}
-
-				typeName := cleanRef("#/" + name)
-				// 对于枚举类型，保持完整的 ref 名称，不进行简化
-
-				// 对枚举值进行排序
-				sort.Strings(enumValues)
-
-				enumData := EnumData{
-					SchemaName: name,
-					TypeName:   typeName,
-					EnumValues: enumValues,
-				}
-				allEnums = append(allEnums, enumData)
-			}
-		}
-
-		// 生成枚举文件
-		if len(allEnums) > 0 {
-			// 对枚举按TypeName排序
-			sort.Slice(allEnums, func(i, j int) bool {
-				return allEnums[i].TypeName < allEnums[j].TypeName
-			})
-
-			enumFileData := struct {
-				Enums []EnumData
-			}{
-				Enums: allEnums,
-			}
-
-			enumFileTmpl, err := template.ParseFS(templateFS, "templates/enum-file.tmpl")
-			if err == nil {
-				var buf bytes.Buffer
-				err = enumFileTmpl.Execute(&buf, enumFileData)
-				if err == nil {
-					typesDir := filepath.Join(outputDir, "types")
-					err := os.MkdirAll(typesDir, 0755)
-					if err == nil {
-						filename := filepath.Join(outputDir, "types", "enum.ts")
-						err = ioutil.WriteFile(filename, buf.Bytes(), 0644)
-						if err == nil {
-							fmt.Printf("✅ generate enum file: %s\n", filename)
-						}
-					}
-				}
-			}
-		}
+	specFiles, err := expandSpecFiles(apiFiles)
+	if err != nil {
+		fatal("invalid spec input", "err", err)
 	}
-
-	// 将临时映射中的函数按名称排序后添加到模块中
-	for moduleName, functions := range functionsByModule {
-		if _, exists := modules[moduleName]; !exists {
-			modules[moduleName] = &ModuleData{Name: moduleName}
-		}
-
-		// 创建排序后的函数名称列表
-		// 使用函数名和顺序号的组合来排序，确保相同 OperationID 的接口按处理顺序排列
-		type functionSortItem struct {
-			name  string
-			order int
-		}
-
-		var functionSortItems []functionSortItem
-		for functionName := range functions {
-			order := functionOrder[functionName]
-			functionSortItems = append(functionSortItems, functionSortItem{
-				name:  functionName,
-				order: order,
-			})
-		}
-
-		// 按函数名排序，如果函数名相同则按处理顺序排序
-		sort.Slice(functionSortItems, func(i, j int) bool {
-			if functionSortItems[i].name == functionSortItems[j].name {
-				return functionSortItems[i].order < functionSortItems[j].order
-			}
-			return functionSortItems[i].name < functionSortItems[j].name
-		})
-
-		var sortedFunctionNames []string
-		for _, item := range functionSortItems {
-			sortedFunctionNames = append(sortedFunctionNames, item.name)
-		}
-
-		// 按排序后的顺序添加函数到模块中
-		for _, functionName := range sortedFunctionNames {
-			modules[moduleName].Functions = append(modules[moduleName].Functions, functions[functionName])
+	if interactive {
+		if len(specFiles) != 1 {
+			fatalUsage("-interactive supports a single spec", "specs", len(specFiles))
 		}
+		selectInteractively(specFiles[0])
 	}
 
-	// 生成每个模块的API文件
-	for name, mod := range modules {
-		if len(mod.Functions) == 0 {
-			continue
+	// 多个文档时每个文档生成到 outputDir/<文档名> 下
+	root := outputDir
+	names := make(map[string]string)
+	for _, specFile := range specFiles {
+		name := specName(specFile)
+		if other, exists := names[name]; exists && len(specFiles) > 1 {
+			fatalUsage("specs would be generated into the same directory", "spec", other, "other", specFile, "dir", name)
 		}
-
-		// 创建模块目录（如果不存在）
-		moduleDir := filepath.Join(outputDir, name)
-		err := os.MkdirAll(moduleDir, 0755)
-		if err != nil {
-			fmt.Printf("❌ create module directory failed %s: %v\n", name, err)
-			log.Printf("create module directory failed %s: %v", name, err)
-			continue
+		names[name] = specFile
+	}
+	if outputDir == stdoutOutput {
+		if len(specFiles) > 1 {
+			fatalUsage("-o - supports a single spec", "specs", len(specFiles))
 		}
-
-		// 准备文件数据，包含导入语句
-		fileData := FileData{
-			ModuleName: name,
-			Functions:  mod.Functions,
-			Imports:    generateImports(name, interfacesByModule, mod.Functions),
+		if err := generateAll(specFiles, root); err != nil {
+			exit(exitError)
 		}
-
-		var buf bytes.Buffer
-		err = fileTmpl.Execute(&buf, fileData)
+		return
+	}
+	if dryRun || showDiff {
+		changes, err := runPreview(func() error {
+			return generateAll(specFiles, root)
+		})
 		if err != nil {
-			fmt.Printf("❌ template execution failed %s: %v\n", name, err)
-			log.Printf("template execution failed %s: %v", name, err)
-			continue
+			// 错误已在 generate 中输出
+			exit(exitError)
 		}
-
-		filename := filepath.Join(moduleDir, "index.ts")
-		err = ioutil.WriteFile(filename, buf.Bytes(), 0644)
-		if err != nil {
-			fmt.Printf("❌ write file failed %s: %v\n", filename, err)
-			log.Printf("write file failed %s: %v", filename, err)
+		if showDiff {
+			printDiff(changes)
 		} else {
-			fmt.Printf("✅ generate module file: %s\n", filename)
+			printDryRun(changes)
 		}
+		return
 	}
-
-	// 生成根目录的index.ts文件
-	rootIndexData := RootIndexData{
-		Modules: modules,
-	}
-
-	var buf bytes.Buffer
-	err = indexTmpl.Execute(&buf, rootIndexData)
-	if err != nil {
-		fmt.Printf("❌ root index template execution failed: %v\n", err)
-		log.Printf("root index template execution failed: %v", err)
-	} else {
-		filename := filepath.Join(outputDir, "index.ts")
-		err = ioutil.WriteFile(filename, buf.Bytes(), 0644)
-		if err != nil {
-			fmt.Printf("❌ write root index file failed: %v\n", err)
-			log.Printf("write root index file failed: %v", err)
-		} else {
-			fmt.Printf("✅ generate root index file: %s\n", filename)
+	if clean {
+		if err := runClean(func() error {
+			return generateAll(specFiles, root)
+		}); err != nil {
+			exit(exitError)
 		}
+		return
 	}
-}
-
-type ModuleData struct {
-	Name       string
-	Interfaces []string
-	Functions  []string
-}
-
-type FunctionData struct {
-	Summary      string
-	FunctionName string
-	ParamType    string
-	ResponseType string
-	Method       string
-	Path         string
-}
-
-type EnumData struct {
-	SchemaName string
-	TypeName   string
-	EnumValues []string
-}
-
-type InterfaceFileData struct {
-	ModuleName  string
-	Interfaces  map[string]string
-	UsedEnums   []string
-	SortedNames []string
-}
-
-type FileData struct {
-	ModuleName string
-	Functions  []string
-	Imports    []ImportData
-}
-
-type ImportData struct {
-	Module     string
-	Interfaces []string
-}
-
-type RootIndexData struct {
-	Modules map[string]*ModuleData
-}
-
-type ProcessedProperty struct {
-	Property   Property
-	TypeName   string
-	IsRequired bool
-}
-
-func renderInterface(schemaName string, schema Schema, tmpl *template.Template, enumTypes map[string]bool) string {
-	// 提取接口名称，不包含命名空间前缀
-	typeName := cleanRef("#/" + schemaName)
-	// 如果typeName包含点号，只取最后一部分
-	if strings.Contains(typeName, ".") {
-		parts := strings.Split(typeName, ".")
-		typeName = parts[len(parts)-1]
-	}
-
-	var buf bytes.Buffer
 
-	// 检查是否为枚举类型
-	if len(schema.Enum) > 0 {
-		// 枚举类型将在单独的enum.ts文件中生成，这里返回空字符串
-		return ""
+	regenerate := func() error {
+		// -watch 时声明文件的修改同样生效
+		if err := loadHeaderFile(); err != nil {
+			logger.Error("read header file failed", "err", err)
+			return err
+		}
+		written, err := runWrite(func() error {
+			return generateAll(specFiles, root)
+		})
+		if err != nil || postCmd == "" {
+			return err
+		}
+		return runPostCmd(postCmd, root, written)
 	}
-
-	// 确保Properties不为nil
-	properties := schema.Properties
-	if properties == nil {
-		properties = make(map[string]Property)
+	if err := regenerate(); err != nil {
+		exit(exitError)
 	}
-
-	// 预处理所有属性的类型名称
-	processedProperties := make(map[string]ProcessedProperty)
-	for key, prop := range properties {
-		processedProperties[key] = ProcessedProperty{
-			Property:   prop,
-			TypeName:   prop.TypeName(enumTypes),
-			IsRequired: prop.IsRequired(),
+	if watch {
+		// 自定义模板和声明文件变化同样触发重新生成
+		watched := append(specFiles, templateFiles...)
+		if headerFile != "" {
+			watched = append(watched, headerFile)
 		}
+		watchSpecs(watched, regenerate)
 	}
-
-	data := struct {
-		SchemaName string
-		TypeName   string
-		Properties map[string]ProcessedProperty
-	}{
-		SchemaName: schemaName,
-		TypeName:   typeName,
-		Properties: processedProperties,
-	}
-	tmpl.Execute(&buf, data)
-	return buf.String()
 }
 
-func renderFunction(data FunctionData, tmpl *template.Template) string {
-	// 处理类型名称，移除命名空间前缀
-	paramType := data.ParamType
-	if strings.Contains(paramType, ".") {
-		parts := strings.Split(paramType, ".")
-		paramType = parts[len(parts)-1]
-	}
-
-	responseType := data.ResponseType
-	if strings.Contains(responseType, ".") {
-		parts := strings.Split(responseType, ".")
-		responseType = parts[len(parts)-1]
-	}
-
-	// 创建新的FunctionData，使用处理后的类型名称
-	newData := FunctionData{
-		Summary:      data.Summary,
-		FunctionName: data.FunctionName,
-		ParamType:    paramType,
-		ResponseType: responseType,
-		Method:       data.Method,
-		Path:         data.Path,
+// loadHeaderFile 读取 -header-file 作为 Options.Header
+func loadHeaderFile() error {
+	if headerFile == "" {
+		return nil
 	}
-
-	var buf bytes.Buffer
-	err := tmpl.Execute(&buf, newData)
+	data, err := os.ReadFile(headerFile)
 	if err != nil {
-		fmt.Printf("❌ failed to execute function template for %s: %v\n", data.FunctionName, err)
-		log.Printf("failed to execute function template for %s: %v", data.FunctionName, err)
+		return err
 	}
-	return buf.String()
+	opts.Header = string(data)
+	return nil
 }
 
-func toCamel(s string) string {
-	parts := strings.Split(s, "_")
-	for i, p := range parts {
-		if i == 0 {
-			continue
+// generateAll 依次生成所有文档，多个文档时每个文档生成到 root/<文档名> 下
+func generateAll(specFiles []string, root string) error {
+	unsupported = nil
+	for _, specFile := range specFiles {
+		if len(specFiles) > 1 {
+			outputDir = filepath.Join(root, specName(specFile))
+			logger.Info("generate spec", "spec", specFile, "output", outputDir)
 		}
-		if len(p) > 0 {
-			parts[i] = strings.ToUpper(p[:1]) + strings.ToLower(p[1:])
+		if err := generateSpec(specFile); err != nil {
+			return err
 		}
 	}
-	return strings.Join(parts, "")
-}
-
-func getModuleFromSchemaName(schemaName string) string {
-	// 所有接口都归入同一个模块，让API调用时按tag来分组
-	return "types"
-}
-
-func generateImports(moduleName string, interfacesByModule map[string]map[string]string, functions []string) []ImportData {
-	var imports []ImportData
-
-	// 收集所有需要导入的接口（清理后的名称）
-	allInterfaces := make(map[string]map[string]string) // module -> originalName -> cleanName
-
-	for module, interfaces := range interfacesByModule {
-		cleanMap := make(map[string]string)
-		for originalName := range interfaces {
-			// 清理接口名称，移除命名空间前缀
-			cleanName := cleanRef("#/" + originalName)
-			if strings.Contains(cleanName, ".") {
-				parts := strings.Split(cleanName, ".")
-				cleanName = parts[len(parts)-1]
-			}
-			cleanMap[originalName] = cleanName
-		}
-		if len(cleanMap) > 0 {
-			allInterfaces[module] = cleanMap
+	if unsupportedReport != "" {
+		if err := writeUnsupportedReport(unsupportedReport); err != nil {
+			logger.Error("write unsupported report failed", "file", unsupportedReport, "err", err)
+			return err
 		}
 	}
+	return nil
+}
 
-	// 分析API函数中实际使用的接口类型
-	usedInterfaces := make(map[string]bool)
-
-	// 从函数代码中提取使用的类型
-	for _, funcCode := range functions {
-		// 提取参数类型和返回类型
-		extractUsedTypes(funcCode, usedInterfaces)
+// specOptions 处理 specFile 使用的选项
+func specOptions(specFile string) generator.Options {
+	o := opts
+	o.Source = specFile
+	if !isURL(specFile) {
+		// 与 protoc 不同，入口文件所在目录默认也是 import 路径
+		o.ProtoPaths = append(o.ProtoPaths[:len(o.ProtoPaths):len(o.ProtoPaths)], filepath.Dir(specFile))
+		o.BundleRefs = true
 	}
-
-	// 对于API模块，只导入实际使用的接口
-	if moduleName != "types" {
-		if interfaces, exists := allInterfaces["types"]; exists {
-			// 使用 map 来去重接口名称
-			uniqueInterfaces := make(map[string]bool)
-			var neededInterfaces []string
-
-			for _, cleanName := range interfaces {
-				if usedInterfaces[cleanName] && !uniqueInterfaces[cleanName] {
-					uniqueInterfaces[cleanName] = true
-					neededInterfaces = append(neededInterfaces, cleanName)
-				}
-			}
-
-			if len(neededInterfaces) > 0 {
-				// 对接口名称进行排序
-				sort.Strings(neededInterfaces)
-				imports = append(imports, ImportData{
-					Module:     "types",
-					Interfaces: neededInterfaces,
-				})
-			}
+	if watch {
+		// 重新生成时只渲染受文档修改影响的模块，每个文档使用各自的缓存
+		if watchCaches[specFile] == nil {
+			watchCaches[specFile] = generator.NewCache()
 		}
+		o.Cache = watchCaches[specFile]
 	}
-
-	return imports
+	return o
 }
 
-// extractUsedTypes 从函数代码中提取使用的类型名称
-// extractUsedEnums 从接口代码中提取使用的枚举类型
-func extractUsedEnums(interfaces map[string]string, enumTypes map[string]bool) []string {
-	usedEnums := make(map[string]bool)
-
-	// 遍历所有接口代码，查找使用的枚举类型
-	for _, code := range interfaces {
-		// 使用正则表达式匹配类型定义中的枚举类型
-		// 匹配模式：fieldName?: EnumTypeName 或 fieldName?: EnumTypeName[]
-		re := regexp.MustCompile(`\w+\??:\s*([A-Z][a-zA-Z_]*)(?:\[\])?`)
-		matches := re.FindAllStringSubmatch(code, -1)
-
-		for _, match := range matches {
-			if len(match) > 1 {
-				typeName := match[1]
-				// 检查是否为真正的枚举类型
-				if enumTypes[typeName] {
-					usedEnums[typeName] = true
-				}
-			}
-		}
-	}
-
-	// 转换为切片并排序
-	var result []string
-	for enumName := range usedEnums {
-		result = append(result, enumName)
-	}
-
-	// 使用标准库排序
-	sort.Strings(result)
-
-	return result
-}
+// watchCaches -watch 时每个文档的渲染缓存，文档 -> 缓存
+var watchCaches = make(map[string]*generator.Cache)
 
-func extractUsedTypes(funcCode string, usedInterfaces map[string]bool) {
-	// 提取参数类型：@param { TypeName } params
-	paramPattern := `@param\s*\{\s*([^}]+)\s*\}\s*params`
-	paramMatches := regexp.MustCompile(paramPattern).FindStringSubmatch(funcCode)
-	if len(paramMatches) > 1 {
-		typeName := strings.TrimSpace(paramMatches[1])
-		usedInterfaces[typeName] = true
-	}
-
-	// 提取返回类型：@returns {Promise<TypeName>}
-	returnPattern := `@returns\s*\{Promise<([^>]+)>\}`
-	returnMatches := regexp.MustCompile(returnPattern).FindStringSubmatch(funcCode)
-	if len(returnMatches) > 1 {
-		typeName := strings.TrimSpace(returnMatches[1])
-		usedInterfaces[typeName] = true
-	}
-
-	// 提取函数签名中的类型：function name(params: TypeName): Promise<TypeName>
-	sigPattern := `function\s+\w+\(params:\s*([^)]+)\):\s*Promise<([^>]+)>`
-	sigMatches := regexp.MustCompile(sigPattern).FindStringSubmatch(funcCode)
-	if len(sigMatches) > 2 {
-		paramType := strings.TrimSpace(sigMatches[1])
-		returnType := strings.TrimSpace(sigMatches[2])
-		usedInterfaces[paramType] = true
-		usedInterfaces[returnType] = true
-	}
-}
-
-// generateRequestTypeFromParameters 根据参数生成请求类型名称
-func generateRequestTypeFromParameters(parameters []Parameter, operationID string) string {
-	if len(parameters) == 0 {
-		return "EmptyRequest"
+// generateSpec 读取并生成单个文档，写入 outputDir，outputDir 为 - 时写到标准输出
+func generateSpec(specFile string) error {
+	data, err := readSpec(specFile)
+	if err != nil {
+		logger.Error("failed to read API file", "err", err)
+		return err
+	}
+	diagnosticSpec = specFile
+	o := specOptions(specFile)
+	update, stop := startProgressReporter()
+	o.Progress = update
+	files, err := generator.New(o).Generate(data)
+	stop()
+	if err != nil {
+		logger.Error("generate failed", "spec", specFile, "err", err)
+		return err
 	}
-
-	// 从 operationID 中提取操作名称，例如 "Team_GetTeamRole" -> "GetTeamRole"
-	parts := strings.Split(operationID, "_")
-	if len(parts) < 2 {
-		return "EmptyRequest"
+	if unsupportedReport != "" {
+		if err := collectUnsupported(o, specFile, data); err != nil {
+			logger.Error("find unsupported features failed", "spec", specFile, "err", err)
+			return err
+		}
 	}
 
-	operationName := parts[1]
-	return operationName + "Request"
-}
-
-// generateRequestInterfaceFromParameters 根据参数生成请求接口代码
-func generateRequestInterfaceFromParameters(typeName string, parameters []Parameter) string {
-	if len(parameters) == 0 {
-		return ""
+	var names []string
+	for name := range files {
+		names = append(names, name)
 	}
-
-	var properties []string
-	for _, param := range parameters {
-		if param.In != "query" {
-			continue // 只处理查询参数
+	sort.Strings(names)
+	if outputDir == stdoutOutput {
+		for _, name := range names {
+			if _, err := os.Stdout.Write(files[name]); err != nil {
+				return err
+			}
 		}
-
-		// 确定 TypeScript 类型
-		var tsType string
-		if param.Schema.Ref != "" {
-			tsType = cleanRef(param.Schema.Ref)
-		} else {
-			switch param.Schema.Type {
-			case "string":
-				tsType = "string"
-			case "integer", "number":
-				tsType = "number"
-			case "boolean":
-				tsType = "boolean"
-			default:
-				tsType = "any"
+		return nil
+	}
+	if force {
+		clearDir(outputDir)
+	}
+	var previous *manifest
+	if prune || merge {
+		if previous, err = readManifest(outputDir); err != nil {
+			logger.Error("read manifest failed", "dir", outputDir, "err", err)
+			return err
+		}
+	}
+	if prune {
+		generated := make(map[string]bool, len(names))
+		for _, name := range names {
+			generated[name] = true
+		}
+		pruneManifest(outputDir, previous, generated)
+	}
+	var declarations map[string][]string
+	if merge {
+		declarations = make(map[string][]string)
+		for _, name := range names {
+			if generator.CanMerge(name) {
+				declarations[name] = generator.Declarations(files[name])
+				if generated, ok := previous.Declarations[name]; ok {
+					output.generated[filepath.Join(outputDir, filepath.FromSlash(name))] = generated
+				}
 			}
 		}
-
-		// 生成属性定义
-		optional := "?"
-		if param.Required {
-			optional = ""
+	}
+	for _, name := range names {
+		filename := filepath.Join(outputDir, filepath.FromSlash(name))
+		if err := makeDir(filepath.Dir(filename)); err != nil {
+			logger.Error("create output directory failed", "err", err)
+			return err
 		}
-
-		description := ""
-		if param.Description != "" {
-			description = fmt.Sprintf("  /**\n   * %s\n   */\n  ", param.Description)
+		if err := writeFile(filename, files[name]); err != nil {
+			logger.Error("write file failed", "file", filename, "err", err)
+			return err
 		}
-
-		// 处理属性名中的点号，转换为下划线
-		propertyName := strings.ReplaceAll(param.Name, ".", "_")
-		property := fmt.Sprintf("%s%s%s: %s", description, propertyName, optional, tsType)
-		properties = append(properties, property)
 	}
-
-	if len(properties) == 0 {
-		return ""
+	// 清单记录本次生成的文件，下次 -prune 或 moonbeam clean 据此删除不再生成的文件
+	filename := filepath.Join(outputDir, manifestName)
+	if err := writeFile(filename, manifestData(names, declarations)); err != nil {
+		logger.Error("write file failed", "file", filename, "err", err)
+		return err
 	}
-
-	// 生成完整的接口代码
-	interfaceCode := fmt.Sprintf("/**\n * %s\n */\nexport interface %s {\n", typeName, typeName)
-	for _, prop := range properties {
-		interfaceCode += fmt.Sprintf("  %s\n", prop)
-	}
-	interfaceCode += "}\n"
-
-	return interfaceCode
+	return nil
 }
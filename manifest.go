@@ -0,0 +1,135 @@
+// manifest.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator"
+)
+
+// manifestName 输出目录中记录生成文件的清单
+const manifestName = ".moonbeam-manifest.json"
+
+var (
+	// prune -prune 删除上次生成（清单中）但这次不再生成的文件
+	prune bool
+	// merge -merge 保留用户在生成的 TypeScript 文件中添加的声明
+	merge bool
+)
+
+// manifest .moonbeam-manifest.json 的内容
+type manifest struct {
+	Generator string   `json:"generator"`
+	Files     []string `json:"files"` // 相对输出目录的路径，使用 /，按字母排序
+	// Declarations -merge 时记录每个 TypeScript 文件生成的顶层声明（见 generator.Declarations），
+	// 下次合并时据此区分不再生成的声明和用户添加的声明
+	Declarations map[string][]string `json:"declarations,omitempty"`
+}
+
+// manifestData 返回记录 names（已排序）的清单内容；清单只随文件列表（和 -merge 时的声明）变化，内容不变时不会改写
+func manifestData(names []string, declarations map[string][]string) []byte {
+	data, _ := json.MarshalIndent(manifest{Generator: "moonbeam " + generator.VersionInfo(), Files: names, Declarations: declarations}, "", "  ")
+	return append(data, '\n')
+}
+
+// readManifest 读取 dir 中上次生成的清单，Files 转换为本地路径；没有清单时返回空的清单
+func readManifest(dir string) (*manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestName))
+	if os.IsNotExist(err) {
+		return &manifest{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", manifestName, err)
+	}
+	var files []string
+	for _, name := range m.Files {
+		// 只删除输出目录内的文件，被改动的清单不能指向目录之外
+		clean := filepath.Clean(filepath.FromSlash(name))
+		if name == "" || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("invalid %s: %q is outside the output directory", manifestName, name)
+		}
+		files = append(files, clean)
+	}
+	m.Files = files
+	return &m, nil
+}
+
+// pruneManifest 标记 dir 中上次生成（previous）但这次（generated）不再生成的文件，生成结束后删除
+func pruneManifest(dir string, previous *manifest, generated map[string]bool) {
+	for _, name := range previous.Files {
+		if !generated[filepath.ToSlash(name)] {
+			pruneFile(filepath.Join(dir, name), dir)
+		}
+	}
+}
+
+// pruneFile 标记 -prune 需要删除的文件，root 是它所在的输出目录，删除后 root 下变空的目录一并删除
+func pruneFile(path, root string) {
+	if output == nil {
+		if os.Remove(path) == nil {
+			removeEmptyParents(path, root)
+		}
+		return
+	}
+	output.pruned = append(output.pruned, prunedFile{path: filepath.Clean(path), root: filepath.Clean(root)})
+}
+
+// removeEmptyParents 从 path 所在的目录向上删除空目录，直到 root（不包括 root）
+func removeEmptyParents(path, root string) {
+	for dir := filepath.Dir(path); dir != root && strings.HasPrefix(dir, root+string(filepath.Separator)); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			// 目录不为空
+			return
+		}
+	}
+}
+
+// runClean 执行 moonbeam clean：在内存中生成，删除清单中不再生成的文件并更新清单，其他文件不改写
+func runClean(generate func() error) error {
+	output = &outputSet{preview: true, files: make(map[string][]byte), generated: make(map[string][]string)}
+	defer func() { output = nil }()
+
+	if err := generate(); err != nil {
+		return err
+	}
+	stale := output.staleFiles()
+	removed := 0
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil {
+			logger.Error("remove stale file failed", "file", path, "err", err)
+			continue
+		}
+		logger.Debug("removed", "file", path)
+		removed++
+	}
+	output.removeEmptyDirs()
+	var manifests []string
+	for path := range output.files {
+		if filepath.Base(path) == manifestName {
+			manifests = append(manifests, path)
+		}
+	}
+	sort.Strings(manifests)
+	for _, path := range manifests {
+		// 只更新已有的清单，从未生成过的目录不创建
+		old, err := os.ReadFile(path)
+		if err != nil || generator.SameContent(old, output.files[path]) {
+			continue
+		}
+		if err := os.WriteFile(path, output.files[path], 0644); err != nil {
+			logger.Error("write manifest failed", "file", path, "err", err)
+			return err
+		}
+	}
+	logger.Info("cleaned", "removed", removed)
+	return nil
+}
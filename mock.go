@@ -0,0 +1,45 @@
+// mock.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"path/filepath"
+
+	"github.com/aide-family/moonbeam/pkg/generator"
+)
+
+// runMock 执行 moonbeam mock 子命令
+func runMock(args []string) {
+	fs := flag.NewFlagSet("mock", flag.ExitOnError)
+	var file string
+	var port int
+	var config string
+	fs.StringVar(&file, "f", "openapi.yaml", "OpenAPI file path; .proto files, descriptor sets and Postman collections are converted first")
+	fs.IntVar(&port, "port", 4010, "Port to listen on")
+	fs.StringVar(&config, "config", "", "Config file; the input spec is read from it unless -f is given")
+	fs.Parse(args)
+	rejectArgs(fs)
+	loadFlagsFromConfig(fs, config)
+
+	data, err := readSpec(file)
+	if err != nil {
+		fatal("failed to read API file", "err", err)
+	}
+	// proto 和 Postman 输入先转换为 OpenAPI，import 在文件所在目录中查找，本地文档合并引用的其他文件
+	data, err = generator.New(generator.Options{Source: file, ProtoPaths: []string{filepath.Dir(file)}, BundleRefs: !isURL(file), Logger: logger}).OpenAPI(data)
+	if err != nil {
+		fatal("failed to convert proto", "err", err)
+	}
+	server, err := generator.NewMockServer(data, logger)
+	if err != nil {
+		fatal("failed to parse OpenAPI", "err", err)
+	}
+
+	addr := fmt.Sprintf(":%d", port)
+	logger.Info("mock server listening", "url", "http://localhost"+addr, "routes", server.Routes())
+	if err := http.ListenAndServe(addr, server); err != nil {
+		fatal("mock server stopped", "err", err)
+	}
+}
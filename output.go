@@ -0,0 +1,258 @@
+// output.go
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/aide-family/moonbeam/pkg/generator"
+)
+
+// output 当前生成过程写入的文件，为 nil 时直接写入磁盘
+var output *outputSet
+
+// outputSet 一次生成过程中的输出文件
+type outputSet struct {
+	preview   bool                // -dry-run / -diff 只记录在内存中，不写入磁盘
+	files     map[string][]byte   // 本次生成的文件，预览模式下保存内容
+	cleared   []string            // -force 时需要清理旧文件的目录
+	pruned    []prunedFile        // -prune 时清单中不再生成的文件
+	generated map[string][]string // -merge 时清单中记录的各文件上次生成的声明
+	written   []string            // 实际写入磁盘的文件
+	unchanged int
+}
+
+// prunedFile -prune 需要删除的文件及其所在的输出目录
+type prunedFile struct {
+	path string
+	root string
+}
+
+// fileChange 预览模式下单个文件的变化
+type fileChange struct {
+	Action string // create、update、delete、unchanged
+	Path   string
+	Old    []byte
+	New    []byte
+}
+
+// makeDir 创建目录，预览模式下不做任何事
+func makeDir(dir string) error {
+	if output != nil && output.preview {
+		return nil
+	}
+	return os.MkdirAll(dir, 0755)
+}
+
+// writeFile 写入生成的文件；内容与磁盘上的文件相同时跳过写入，避免无变化的文件被改写；
+// 已有文件中的受保护区域保留在新内容中
+func writeFile(filename string, data []byte) error {
+	path := filepath.Clean(filename)
+	old, err := os.ReadFile(path)
+	if err != nil {
+		old = nil
+	}
+	if output != nil && !output.preview {
+		output.files[path] = nil
+		// 文件内容与头部注释中的哈希一致且哈希不变时跳过；手动修改过的文件重新写入
+		if old != nil && generator.SameContent(old, data) {
+			output.unchanged++
+			return nil
+		}
+	}
+	data, err = preserveUserCode(path, old, data)
+	if err != nil {
+		return err
+	}
+	// 放回受保护区域和 -merge 保留的声明之后与已有文件相同时，同样不改写
+	if output != nil && !output.preview && old != nil && generator.SameContent(old, data) {
+		output.unchanged++
+		return nil
+	}
+	if output == nil {
+		return os.WriteFile(path, data, 0644)
+	}
+	if output.preview {
+		output.files[path] = data
+		return nil
+	}
+	output.written = append(output.written, path)
+	return os.WriteFile(path, data, 0644)
+}
+
+// preserveUserCode 把已有文件中用户添加的代码放回新生成的内容：-merge 时保留用户添加的声明，
+// 并保留受保护区域（// moonbeam:keep-start 到 // moonbeam:keep-end）；old 为已有文件的内容，文件不存在时为 nil
+func preserveUserCode(path string, old, data []byte) ([]byte, error) {
+	if old == nil {
+		return data, nil
+	}
+	if merge && output != nil && generator.CanMerge(path) {
+		data = generator.MergeDeclarations(old, data, output.generated[path])
+	}
+	if !generator.HasKeepRegions(old) {
+		return data, nil
+	}
+	data, moved, err := generator.PreserveKeepRegions(old, data)
+	if err != nil {
+		return nil, fmt.Errorf("keep regions: %w", err)
+	}
+	for _, name := range moved {
+		logger.Warn("kept region moved to the end of the file, the code after it is no longer generated", "file", path, "region", name)
+	}
+	return data, nil
+}
+
+// clearDir 标记输出目录（-force），生成结束后删除其中未再生成的旧文件
+func clearDir(dir string) {
+	if output == nil {
+		os.RemoveAll(dir)
+		return
+	}
+	output.cleared = append(output.cleared, filepath.Clean(dir))
+}
+
+// staleFiles 返回 -force 目录中和 -prune 清单中本次未生成的文件
+func (o *outputSet) staleFiles() []string {
+	seen := make(map[string]bool)
+	var stale []string
+	add := func(path string) {
+		if _, generated := o.files[path]; !generated && !seen[path] {
+			seen[path] = true
+			stale = append(stale, path)
+		}
+	}
+	for _, dir := range o.cleared {
+		filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			add(path)
+			return nil
+		})
+	}
+	for _, file := range o.pruned {
+		// 已被手动删除的文件不再报告
+		if info, err := os.Stat(file.path); err == nil && !info.IsDir() {
+			add(file.path)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
+
+// removeEmptyDirs 删除旧文件后，删除 -force 目录中的空目录和 -prune 删除的文件所在的空目录
+func (o *outputSet) removeEmptyDirs() {
+	for _, dir := range o.cleared {
+		removeEmptyDirs(dir)
+	}
+	for _, file := range o.pruned {
+		removeEmptyParents(file.path, file.root)
+	}
+}
+
+// runWrite 执行 generate，只改写内容变化的文件，并删除 -force 目录和 -prune 清单中的旧文件，返回实际写入的文件
+func runWrite(generate func() error) ([]string, error) {
+	output = &outputSet{files: make(map[string][]byte), generated: make(map[string][]string)}
+	defer func() { output = nil }()
+
+	if err := generate(); err != nil {
+		return nil, err
+	}
+	stale := output.staleFiles()
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil {
+			logger.Error("remove stale file failed", "file", path, "err", err)
+		}
+	}
+	output.removeEmptyDirs()
+	logger.Info("generated", "written", len(output.written), "unchanged", output.unchanged, "removed", len(stale))
+	sort.Strings(output.written)
+	return output.written, nil
+}
+
+// removeEmptyDirs 删除 root 下的空目录（不包括 root 本身）
+func removeEmptyDirs(root string) {
+	var dirs []string
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() && path != root {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+	// 先删除更深的目录
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if entries, err := os.ReadDir(dirs[i]); err == nil && len(entries) == 0 {
+			os.Remove(dirs[i])
+		}
+	}
+}
+
+// changes 对比磁盘上已有的文件，返回按路径排序的变化
+// 只有 -force 目录和 -prune 清单中未再生成的文件才会被删除
+func (o *outputSet) changes() []fileChange {
+	var result []fileChange
+	for path, data := range o.files {
+		change := fileChange{Action: "create", Path: path, New: data}
+		if old, err := os.ReadFile(path); err == nil {
+			change.Old = old
+			change.Action = "update"
+			if generator.SameContent(old, data) {
+				change.Action = "unchanged"
+			}
+		}
+		result = append(result, change)
+	}
+	for _, path := range o.staleFiles() {
+		old, _ := os.ReadFile(path)
+		result = append(result, fileChange{Action: "delete", Path: path, Old: old})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+	return result
+}
+
+// runPreview 在预览模式下执行 generate，返回相对磁盘上已有文件的变化
+func runPreview(generate func() error) ([]fileChange, error) {
+	output = &outputSet{preview: true, files: make(map[string][]byte), generated: make(map[string][]string)}
+	defer func() { output = nil }()
+
+	if err := generate(); err != nil {
+		return nil, err
+	}
+	return output.changes(), nil
+}
+
+// actionColors 预览时各类变化的颜色
+var actionColors = map[string]string{"create": colorGreen, "update": colorYellow, "delete": colorRed}
+
+// printDryRun 输出将要创建、更新、删除的文件及大小
+func printDryRun(changes []fileChange) {
+	counts := make(map[string]int)
+	fmt.Println(stdoutStyle.symbol("📝 ", "") + "dry run, nothing written:")
+	for _, change := range changes {
+		counts[change.Action]++
+		action := stdoutStyle.paint(actionColors[change.Action], fmt.Sprintf("%-7s", change.Action))
+		switch change.Action {
+		case "unchanged":
+			continue
+		case "delete":
+			fmt.Printf("  %s %s (%s)\n", action, change.Path, formatSize(len(change.Old)))
+		default:
+			fmt.Printf("  %s %s (%s)\n", action, change.Path, formatSize(len(change.New)))
+		}
+	}
+	fmt.Printf("%d to create, %d to update, %d to delete, %d unchanged\n",
+		counts["create"], counts["update"], counts["delete"], counts["unchanged"])
+}
+
+// formatSize 格式化文件大小
+func formatSize(n int) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	return fmt.Sprintf("%.1f KB", float64(n)/1024)
+}
@@ -0,0 +1,92 @@
+// alias.go
+package generator
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// importAliasPattern 别名中不能有空白和引号，例如 @/api、~api
+var importAliasPattern = regexp.MustCompile(`^[^\s'"=]+$`)
+
+// aliasSourceExts 改写导入的文件类型，-ext 和 -emit-js 转换之后的 TypeScript 和 JavaScript 文件
+var aliasSourceExts = map[string]bool{".ts": true, ".tsx": true, ".mts": true, ".cts": true, ".js": true, ".mjs": true, ".cjs": true}
+
+// parseImportAliases 解析 -import-alias：<别名> 对应输出目录，<目录>=<别名> 对应输出目录中的一个顶层目录（模块目录或 types）
+func parseImportAliases(values []string) (map[string]string, error) {
+	aliases := make(map[string]string)
+	for _, value := range values {
+		dir, alias, found := strings.Cut(strings.TrimSpace(value), "=")
+		if !found {
+			dir, alias = "", dir
+		}
+		dir, alias = strings.Trim(dir, "/"), strings.TrimSuffix(alias, "/")
+		if !importAliasPattern.MatchString(alias) || strings.HasPrefix(alias, ".") || strings.ContainsAny(dir, `/\`) || (found && dir == "") {
+			return nil, fmt.Errorf("invalid -import-alias %q, expected '<alias>' for the output directory or '<directory>=<alias>' for one of its top-level directories, e.g. '@/api' or 'types=@/api-types'", value)
+		}
+		aliases[dir] = alias
+	}
+	return aliases, nil
+}
+
+// validateImportAliases 校验 -import-alias 及其组合
+func validateImportAliases(o Options) error {
+	if len(o.ImportAliases) == 0 {
+		return nil
+	}
+	if _, err := parseImportAliases(o.ImportAliases); err != nil {
+		return err
+	}
+	// 单个文件没有文件之间的导入；npm 包和 workspace 中的别名在使用方的项目中无法解析
+	switch {
+	case o.SingleFile != "":
+		return fmt.Errorf("-import-alias is not supported with -single-file")
+	case o.PackageName != "":
+		return fmt.Errorf("-import-alias is not supported with -package-name")
+	}
+	return nil
+}
+
+// aliasImports 把生成文件之间用 ../ 跨到另一个顶层目录的相对导入改为路径别名，例如 user/index.ts 中的 ../types/index.ts 改为 @/api/types/index.ts；
+// 以 ./ 开头的导入（例如根目录 index.ts 中的 ./types/index.ts）、同一顶层目录内的导入和指向输出目录之外的导入（例如 ../request.ts）保持不变
+func (r *run) aliasImports(files Files) Files {
+	result := make(Files, len(files))
+	for name, data := range files {
+		if !aliasSourceExts[path.Ext(name)] {
+			result[name] = data
+			continue
+		}
+		source := topDir(name)
+		result[name] = rewriteImports(data, func(imp moduleImport) string {
+			if !strings.HasPrefix(imp.Specifier, "../") {
+				return imp.Specifier
+			}
+			target := path.Join(path.Dir(name), imp.Specifier)
+			if target == ".." || strings.HasPrefix(target, "../") {
+				return imp.Specifier
+			}
+			top := topDir(target)
+			if top == source {
+				return imp.Specifier
+			}
+			if alias, ok := r.importAliases[top]; ok && top != "" {
+				return alias + "/" + strings.TrimPrefix(target, top+"/")
+			}
+			if alias, ok := r.importAliases[""]; ok {
+				return alias + "/" + target
+			}
+			return imp.Specifier
+		})
+	}
+	return result
+}
+
+// topDir 文件所在的顶层目录，输出目录根部的文件返回空字符串
+func topDir(name string) string {
+	if top, _, found := strings.Cut(name, "/"); found {
+		return top
+	}
+	return ""
+}
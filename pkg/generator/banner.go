@@ -0,0 +1,127 @@
+// banner.go
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// hashMarker 头部注释中记录内容哈希的行，位于注释符号之后
+const hashMarker = "moonbeam-hash: sha256:"
+
+// bannerComments 可以添加头部注释的文件类型及其注释符号，JSON 不支持注释
+var bannerComments = map[string]string{
+	".ts": "//", ".tsx": "//", ".mts": "//", ".cts": "//",
+	".js": "//", ".mjs": "//", ".cjs": "//",
+	".go": "//", ".dart": "//",
+	".py": "#",
+}
+
+// withBanner 为生成的文件添加头部注释，其中的哈希只覆盖注释之后的内容
+func (r *run) withBanner(filename string, data []byte, generated time.Time) []byte {
+	comment, ok := bannerComments[filepath.Ext(filename)]
+	if !ok {
+		return data
+	}
+	// 一次分配头部注释和内容的空间，大文件不会在写入时反复扩容
+	var buf bytes.Buffer
+	buf.Grow(len(data) + 256)
+	fmt.Fprintf(&buf, "%s Code generated by moonbeam %s from %s at %s. DO NOT EDIT.\n",
+		comment, VersionInfo(), bannerSource(r.bannerSpec), generated.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&buf, "%s %s%s\n", comment, hashMarker, contentHash(data))
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+// bannerTime 头部注释中的生成时间，一次生成的所有文件使用同一个时间；
+// 设置了 SOURCE_DATE_EPOCH 时使用它，使相同的输入得到逐字节相同的输出
+func bannerTime() time.Time {
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		if seconds, err := strconv.ParseInt(epoch, 10, 64); err == nil {
+			return time.Unix(seconds, 0)
+		}
+	}
+	return time.Now()
+}
+
+// contentHash 生成内容的哈希，取 sha256 的前 16 位
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// bannerHash 读取文件头部注释中的哈希，没有头部注释时返回空字符串
+func bannerHash(data []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for i := 0; i < 2 && scanner.Scan(); i++ {
+		line := strings.TrimLeft(scanner.Text(), "/# ")
+		if strings.HasPrefix(line, hashMarker) {
+			return strings.TrimPrefix(line, hashMarker)
+		}
+	}
+	return ""
+}
+
+// OutputHash 生成内容的哈希：带头部注释时使用其中的哈希，不受生成时间和受保护区域影响，否则为整个内容的哈希
+func OutputHash(data []byte) string {
+	if hash := bannerHash(data); hash != "" {
+		return hash
+	}
+	return contentHash(data)
+}
+
+// SameContent 判断新生成的内容与已有文件是否相同；两者都带头部注释时，已有文件头部注释之后的内容必须与其中的哈希一致，
+// 且哈希与新内容相同，生成时间不同不视为变化；文件内容被修改过时，只有与 data 头部注释之后的内容逐字节相同才视为相同，
+// 例如已放回受保护区域和 -merge 保留的声明之后的内容
+func SameContent(old, data []byte) bool {
+	hash := bannerHash(data)
+	if hash == "" {
+		return bytes.Equal(old, data)
+	}
+	if bannerHash(old) != hash {
+		return false
+	}
+	body, ok := bannerBody(old)
+	if !ok {
+		return false
+	}
+	if contentHash(body) == hash {
+		return true
+	}
+	generated, _ := bannerBody(data)
+	return bytes.Equal(body, generated)
+}
+
+// bannerBody 头部注释（withBanner 写入的两行）之后的内容，即哈希覆盖的范围；内容不足两行时返回 false
+func bannerBody(data []byte) ([]byte, bool) {
+	for i := 0; i < 2; i++ {
+		end := bytes.IndexByte(data, '\n')
+		if end < 0 {
+			return nil, false
+		}
+		data = data[end+1:]
+	}
+	return data, true
+}
+
+// bannerSource 头部注释中的文档来源，URL 去掉查询参数以免泄露 token
+func bannerSource(spec string) string {
+	if spec == "" {
+		return "OpenAPI spec"
+	}
+	if u, err := url.Parse(spec); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		u.RawQuery = ""
+		u.User = nil
+		return u.String()
+	}
+	return filepath.ToSlash(spec)
+}
@@ -0,0 +1,34 @@
+// bench_test.go
+package generator
+
+import (
+	"log/slog"
+	"testing"
+)
+
+// BenchmarkGenerate 用默认选项生成 SyntheticSizes 中的每个文档：go test ./pkg/generator -run '^$' -bench Generate
+func BenchmarkGenerate(b *testing.B) {
+	benchmarkSizes(b, Options{})
+}
+
+// BenchmarkGenerateFetchZod 同时生成 fetch 运行时、zod 校验、react-query hooks 和 mock 数据，覆盖大部分渲染阶段
+func BenchmarkGenerateFetchZod(b *testing.B) {
+	benchmarkSizes(b, Options{Client: "fetch", Validators: "zod", Hooks: "react-query", Mocks: true})
+}
+
+// BenchmarkGenerateGo 用 -lang go 生成 SyntheticSizes 中的每个文档
+func BenchmarkGenerateGo(b *testing.B) {
+	benchmarkSizes(b, Options{Lang: LangGo})
+}
+
+// benchmarkSizes 每个文档一个子基准，名称与 moonbeam bench 的输出相同
+func benchmarkSizes(b *testing.B, opts Options) {
+	// 文档中的警告在每次生成时都会出现，不输出
+	opts.Logger = slog.New(slog.DiscardHandler)
+	for _, size := range SyntheticSizes {
+		spec := SyntheticSpec(size.Operations, size.Schemas)
+		b.Run(size.Name, func(b *testing.B) {
+			BenchmarkSpec(b, New(opts), spec)
+		})
+	}
+}
@@ -0,0 +1,347 @@
+// bundle.go
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// componentNameInvalid components 的名称只能包含字母、数字、.、- 和 _
+var componentNameInvalid = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// schemaContexts 路径中出现这些键时，其中的 $ref 指向 schema
+var schemaContexts = map[string]bool{
+	"schema": true, "schemas": true, "properties": true, "items": true, "additionalProperties": true,
+	"allOf": true, "oneOf": true, "anyOf": true, "not": true,
+}
+
+// bundler 把其他文件中被引用的节点合并到根文档的 components 中
+type bundler struct {
+	*run
+	rootFile   string
+	root       *yaml.Node            // 根文档的顶层映射
+	files      map[string]*yaml.Node // 已读取的文件 -> 顶层节点
+	imported   map[string]string     // 文件#片段 -> 合并后的本地引用
+	inlining   map[string]bool       // 正在内联的文件#片段，用于发现循环引用
+	components *yaml.Node
+}
+
+// bundleSpecFiles 把文档中指向其他本地文件的相对 $ref（例如 paths/teams.yaml、components/schemas/User.yaml#/User）
+// 合并为一个文档：被引用的节点复制到 components 下对应的分类中（名称取自片段的最后一段或文件名），
+// 引用改为本地引用；paths 下的路径项和 components 中的定义直接内联。没有这类引用时原样返回 spec
+func (r *run) bundleSpecFiles(spec []byte, source string) ([]byte, bool, error) {
+	doc, err := r.parseDocument(spec)
+	if err != nil || len(doc.Content) == 0 || !hasFileRefs(doc.Content[0]) {
+		// 语法错误由解析文档时报告
+		return spec, false, nil
+	}
+	// parseDocument 的结果是共享的，合并需要修改节点，重新解析一份
+	var fresh yaml.Node
+	if err := yaml.Unmarshal(spec, &fresh); err != nil {
+		return spec, false, nil
+	}
+	expandMergeKeys(&fresh)
+	rootFile, err := filepath.Abs(source)
+	if err != nil {
+		return nil, false, err
+	}
+	b := &bundler{run: r,
+
+		rootFile: rootFile,
+		root:     fresh.Content[0],
+		files:    map[string]*yaml.Node{rootFile: fresh.Content[0]},
+		imported: make(map[string]string),
+		inlining: make(map[string]bool),
+	}
+	if b.root.Kind != yaml.MappingNode {
+		return spec, false, nil
+	}
+	b.components = r.field(b.root, "components")
+	b.registerComponents()
+	if err := b.walk(b.root, rootFile, nil, make(map[*yaml.Node]bool)); err != nil {
+		return nil, false, err
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&fresh); err != nil {
+		return nil, false, fmt.Errorf("bundle %s: %w", source, err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, false, err
+	}
+	return buf.Bytes(), true, nil
+}
+
+// hasFileRefs 文档中是否有指向其他文件的 $ref，URL 不算在内
+func hasFileRefs(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "$ref" && value.Kind == yaml.ScalarNode {
+				if isFileRef(value.Value) {
+					return true
+				}
+				continue
+			}
+			if hasFileRefs(value) {
+				return true
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if hasFileRefs(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isFileRef ref 是否指向本地文件
+func isFileRef(ref string) bool {
+	if ref == "" || strings.HasPrefix(ref, "#") {
+		return false
+	}
+	return !strings.Contains(ref, "://")
+}
+
+// registerComponents 根文档 components 中直接引用其他文件的定义（User: {$ref: ./schemas/User.yaml}）会被内联，
+// 其他位置引用同一个节点时使用这个定义，而不是再复制一份
+func (b *bundler) registerComponents() {
+	if b.components == nil || b.components.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(b.components.Content); i += 2 {
+		kind, entries := b.components.Content[i].Value, b.components.Content[i+1]
+		if entries.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(entries.Content); j += 2 {
+			ref := b.field(entries.Content[j+1], "$ref")
+			if ref == nil || ref.Kind != yaml.ScalarNode || !isFileRef(ref.Value) {
+				continue
+			}
+			file, fragment := b.split(b.rootFile, ref.Value)
+			b.imported[file+"#"+fragment] = "#/components/" + escapePointer(kind) + "/" + escapePointer(entries.Content[j].Value)
+		}
+	}
+}
+
+// split 把 ref 拆分为绝对文件路径和片段，file 是 ref 所在的文件
+func (b *bundler) split(file, ref string) (string, string) {
+	target, fragment, _ := strings.Cut(ref, "#")
+	if target == "" {
+		return file, fragment
+	}
+	target = filepath.FromSlash(target)
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(file), target)
+	}
+	return filepath.Clean(target), fragment
+}
+
+// walk 改写 node 中的 $ref，file 是 node 所在的文件，tokens 是 node 在合并后的文档中的位置
+func (b *bundler) walk(node *yaml.Node, file string, tokens []string, done map[*yaml.Node]bool) error {
+	if done[node] {
+		return nil
+	}
+	done[node] = true
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "$ref" && value.Kind == yaml.ScalarNode {
+				return b.rewrite(node, value, file, tokens, done)
+			}
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if err := b.walk(node.Content[i+1], file, append(tokens[:len(tokens):len(tokens)], node.Content[i].Value), done); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			if err := b.walk(item, file, append(tokens[:len(tokens):len(tokens)], strconv.Itoa(i)), done); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rewrite 改写 holder 中的 $ref：根文档中的本地引用和 URL 保持不变，其他文件中的节点合并到根文档
+func (b *bundler) rewrite(holder, ref *yaml.Node, file string, tokens []string, done map[*yaml.Node]bool) error {
+	if strings.Contains(ref.Value, "://") || (file == b.rootFile && strings.HasPrefix(ref.Value, "#")) {
+		return nil
+	}
+	targetFile, fragment := b.split(file, ref.Value)
+	if targetFile == b.rootFile {
+		// 其他文件引用回根文档
+		ref.Value = "#" + fragment
+		return nil
+	}
+	key := targetFile + "#" + fragment
+	kind := refKind(tokens)
+	if local, ok := b.imported[key]; ok && (kind != "" || local != "#/"+strings.Join(escapeTokens(tokens), "/")) {
+		ref.Value = local
+		return nil
+	}
+	target, err := b.resolve(targetFile, fragment, ref)
+	if err != nil {
+		return err
+	}
+	copied := copyNode(target, make(map[*yaml.Node]*yaml.Node))
+
+	if kind == "" {
+		// 路径项和 components 中的定义在原位置展开，其中的引用相对于被引用的文件
+		if b.inlining[key] {
+			return fmt.Errorf("bundle $ref %q (line %d): circular reference", ref.Value, ref.Line)
+		}
+		b.inlining[key] = true
+		defer delete(b.inlining, key)
+		*holder = *copied
+		delete(done, holder)
+		return b.walk(holder, targetFile, tokens, done)
+	}
+
+	name := b.componentName(kind, fragment, targetFile)
+	local := "#/components/" + kind + "/" + escapePointer(name)
+	b.imported[key] = local
+	ref.Value = local
+	setField(b.section(kind), name, copied)
+	return b.walk(copied, targetFile, []string{"components", kind, name}, done)
+}
+
+// resolve 读取 file 并查找片段指向的节点
+func (b *bundler) resolve(file, fragment string, ref *yaml.Node) (*yaml.Node, error) {
+	root, ok := b.files[file]
+	if !ok {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("bundle $ref %q (line %d): %w", ref.Value, ref.Line, err)
+		}
+		if data, err = normalizeText(data); err != nil {
+			return nil, fmt.Errorf("bundle $ref %q (line %d): %w", ref.Value, ref.Line, err)
+		}
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("bundle $ref %q (line %d): parse %s: %w", ref.Value, ref.Line, file, err)
+		}
+		if len(doc.Content) == 0 {
+			return nil, fmt.Errorf("bundle $ref %q (line %d): %s is empty", ref.Value, ref.Line, file)
+		}
+		expandMergeKeys(&doc)
+		root = doc.Content[0]
+		b.files[file] = root
+	}
+	target, reason := b.resolveRef(root, "#"+fragment)
+	if target == nil {
+		return nil, fmt.Errorf("bundle $ref %q (line %d): %s", ref.Value, ref.Line, reason)
+	}
+	return target, nil
+}
+
+// refKind 按引用所在的位置返回被引用的节点应放入的 components 分类；路径项和 components 中的定义返回空字符串，表示内联
+func refKind(tokens []string) string {
+	n := len(tokens)
+	if (n == 2 && tokens[0] == "paths") || (n == 3 && tokens[0] == "components") {
+		return ""
+	}
+	for _, token := range tokens {
+		if schemaContexts[token] {
+			return "schemas"
+		}
+	}
+	if n >= 2 {
+		switch tokens[n-2] {
+		case "parameters":
+			return "parameters"
+		case "responses":
+			return "responses"
+		case "headers":
+			return "headers"
+		case "examples":
+			return "examples"
+		case "links":
+			return "links"
+		case "callbacks":
+			return "callbacks"
+		}
+	}
+	if n >= 1 && tokens[n-1] == "requestBody" {
+		return "requestBodies"
+	}
+	return "schemas"
+}
+
+// componentName 被引用节点在 components 中的名称：片段的最后一段，没有片段时使用不含扩展名的文件名；
+// 与已有的名称冲突时加上数字后缀
+func (b *bundler) componentName(kind, fragment, file string) string {
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	if tokens := strings.Split(strings.Trim(fragment, "/"), "/"); tokens[len(tokens)-1] != "" {
+		name = pointerUnescaper.Replace(tokens[len(tokens)-1])
+	}
+	name = componentNameInvalid.ReplaceAllString(name, "_")
+	section := b.section(kind)
+	candidate := name
+	for i := 2; b.field(section, candidate) != nil; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	return candidate
+}
+
+// section 返回根文档中 components 下的分类，不存在时创建
+func (b *bundler) section(kind string) *yaml.Node {
+	if b.components == nil {
+		b.components = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setField(b.root, "components", b.components)
+	}
+	section := b.field(b.components, kind)
+	if section == nil {
+		section = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setField(b.components, kind, section)
+	}
+	return section
+}
+
+// setField 在映射的末尾加上键值
+func setField(node *yaml.Node, key string, value *yaml.Node) {
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// copyNode 深拷贝节点，别名展开为其指向节点的拷贝，同一节点只拷贝一次
+func copyNode(node *yaml.Node, copies map[*yaml.Node]*yaml.Node) *yaml.Node {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		return copyNode(node.Alias, copies)
+	}
+	if copied, ok := copies[node]; ok {
+		return copied
+	}
+	copied := *node
+	copied.Anchor = ""
+	copies[node] = &copied
+	copied.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		copied.Content[i] = copyNode(child, copies)
+	}
+	return &copied
+}
+
+// escapeTokens 转义 JSON pointer 的每一段
+func escapeTokens(tokens []string) []string {
+	escaped := make([]string, len(tokens))
+	for i, token := range tokens {
+		escaped[i] = escapePointer(token)
+	}
+	return escaped
+}
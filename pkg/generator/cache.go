@@ -0,0 +1,184 @@
+// cache.go
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// Cache 保存上一次生成的渲染结果，同一个文档多次生成时（-watch）只重新渲染受变化影响的部分：
+// 内容不变的 schema 复用渲染好的接口定义；模块的接口和它们（传递）引用的 schema 都没有变化时，
+// 复用上次生成的函数和模块目录中的文件（index.ts、hooks、fixtures 等）。
+// 选项或自定义模板变化时全部重新渲染。每个文档使用各自的 Cache；-provenance 和 TypeScript 以外的语言不使用。
+// 多个 goroutine 可以共用一个 Cache，使用它的生成依次进行
+type Cache struct {
+	mu         sync.Mutex
+	key        string                     // 选项和自定义模板的指纹，见 cacheKey
+	schemas    map[string]string          // schema 名称 -> 模型或枚举的哈希
+	names      string                     // 所有模型和枚举名称的哈希，名称增减会改变模块文件中的导入
+	interfaces map[string]cachedInterface // schema 名称 -> 渲染好的接口定义
+	modules    map[string]cachedModule    // 模块 -> 上次生成的文件
+}
+
+// cachedInterface 渲染好的接口定义及模型的哈希
+type cachedInterface struct {
+	hash string
+	code string
+}
+
+// cachedModule 模块上次生成的文件及其依赖
+type cachedModule struct {
+	hash      string          // 模块中接口的哈希
+	deps      map[string]bool // 模块的接口（传递）引用的 schema
+	functions []string        // 渲染好的函数
+	files     Files
+}
+
+// NewCache 创建空的 Cache，通过 Options.Cache 在多次生成之间共用
+func NewCache() *Cache {
+	return &Cache{}
+}
+
+// cacheKey 影响渲染结果的选项和自定义模板的指纹；头部注释、文档来源、日志和进度只在渲染之后使用，并行数量不影响结果，都不计入
+func cacheKey(o Options) string {
+	o.Logger, o.Progress, o.Cache, o.Header, o.Source, o.Jobs = nil, nil, nil, "", "", 0
+	h := sha256.New()
+	fmt.Fprintf(h, "%+v", o)
+	if o.TemplateDir != "" {
+		files, _ := filepath.Glob(filepath.Join(o.TemplateDir, "*.tmpl"))
+		sort.Strings(files)
+		for _, file := range files {
+			data, _ := os.ReadFile(file)
+			fmt.Fprintf(h, "\x00%s\x00%s", filepath.Base(file), data)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// begin 开始一次生成，选项或模板变化时清空缓存，调用方需持有 c.mu
+func (c *Cache) begin(key string) {
+	if c.key != key {
+		c.key, c.schemas, c.names = key, nil, ""
+		c.interfaces, c.modules = make(map[string]cachedInterface), make(map[string]cachedModule)
+	}
+}
+
+// prepare 计算 schema 的哈希，返回变化（新增、修改或删除）的 schema 和名称是否有增减
+func (c *Cache) prepare(api *ir.API) (changed map[string]bool, namesChanged bool) {
+	schemas := make(map[string]string, len(api.Models)+len(api.Enums))
+	names := make([]string, 0, len(api.Models)+len(api.Enums))
+	for _, model := range api.Models {
+		schemas[model.Name] = hashJSON(model)
+		names = append(names, model.Name)
+	}
+	for _, enum := range api.Enums {
+		schemas[enum.Name] = hashJSON(enum)
+		names = append(names, "enum:"+enum.Name)
+	}
+	sort.Strings(names)
+	changed = make(map[string]bool)
+	for name, hash := range schemas {
+		if c.schemas[name] != hash {
+			changed[name] = true
+		}
+	}
+	for name := range c.schemas {
+		if _, ok := schemas[name]; !ok {
+			changed[name] = true
+			delete(c.interfaces, name)
+		}
+	}
+	namesHash := hashJSON(names)
+	namesChanged = c.names != namesHash
+	c.schemas, c.names = schemas, namesHash
+	return changed, namesChanged
+}
+
+// interfaceFor 返回 schema 没有变化时上次渲染的接口定义，可以在 renderParallel 中调用
+func (c *Cache) interfaceFor(name string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	cached, ok := c.interfaces[name]
+	if !ok || cached.hash != c.schemas[name] {
+		return "", false
+	}
+	return cached.code, true
+}
+
+// storeInterface 保存渲染好的接口定义
+func (c *Cache) storeInterface(name, code string) {
+	if c != nil {
+		c.interfaces[name] = cachedInterface{hash: c.schemas[name], code: code}
+	}
+}
+
+// storeModule 保存模块渲染好的函数和生成的文件
+func (c *Cache) storeModule(name string, mod *ModuleData, deps map[string]bool, files Files) {
+	c.modules[name] = cachedModule{hash: hashJSON(mod.Operations), deps: deps, functions: mod.Functions, files: files}
+}
+
+// reusableModules 返回可以复用上次生成结果的模块：模块的接口没有变化、引用的 schema 都没有变化，并且名称没有增减；
+// 同时删除不再存在的模块
+func (c *Cache) reusableModules(modules map[string]*ModuleData, deps map[string]map[string]bool, changed map[string]bool, namesChanged bool) map[string]bool {
+	reuse := make(map[string]bool)
+	for name := range c.modules {
+		if _, ok := modules[name]; !ok {
+			delete(c.modules, name)
+		}
+	}
+	if namesChanged {
+		return reuse
+	}
+	for name, mod := range modules {
+		cached, ok := c.modules[name]
+		if !ok || cached.hash != hashJSON(mod.Operations) {
+			continue
+		}
+		// 引用关系本身也可能变化，上次和这次引用的 schema 都要检查
+		affected := false
+		for _, deps := range []map[string]bool{cached.deps, deps[name]} {
+			for dep := range deps {
+				affected = affected || changed[dep]
+			}
+		}
+		reuse[name] = !affected
+	}
+	return reuse
+}
+
+// hashJSON 数据的 JSON 编码的哈希
+func hashJSON(v interface{}) string {
+	data, _ := json.Marshal(v)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// recordFiles 执行 write 并返回其中 writeFile 写入的文件
+func (r *run) recordFiles(write func()) Files {
+	r.recorded = make(Files)
+	defer func() { r.recorded = nil }()
+	write()
+	return r.recorded
+}
+
+// cachedModuleNames 复用的模块名称，用于日志
+func cachedModuleNames(reuse map[string]bool) string {
+	var names []string
+	for name, ok := range reuse {
+		if ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,53 @@
+// classes.go
+package generator
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// ClassFileData API 类文件模板数据
+type ClassFileData struct {
+	ModuleName string
+	ClassName  string
+	Imports    []ImportData
+	Operations []FunctionData
+	Parsers    []string // 响应校验使用的 parseXxx
+	Runtime    []string // 从 runtime.ts 导入的函数
+}
+
+// renderClass 生成模块的 api.ts，每个模块（tag）一个 API 类
+func (r *run) renderClass(tmpl *template.Template, moduleDir string, mod *ModuleData, imports []ImportData) {
+	data := ClassFileData{
+		ModuleName: mod.Name,
+		ClassName:  toPascal(mod.Name) + "Api",
+		Imports:    imports,
+		Operations: mod.Operations,
+		Parsers:    responseParsers(mod.Operations),
+		Runtime:    runtimeImports(mod.Operations),
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		r.logger.Error("class template execution failed", "module", mod.Name, "err", err)
+		return
+	}
+
+	filename := filepath.Join(moduleDir, "api.ts")
+	r.writeFile(filename, buf.Bytes())
+	r.logger.Debug("generate class file", "file", filename)
+}
+
+// toPascal 将任意分隔的名称转换为 PascalCase，例如 team-role -> TeamRole
+func toPascal(s string) string {
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, p := range parts {
+		parts[i] = strings.ToUpper(p[:1]) + p[1:]
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,193 @@
+// clients_test.go
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// goClientTest 在 members-go 的期望文件旁运行的测试，记录服务端收到的请求
+const goClientTest = `package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequests(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, strings.TrimSpace(r.Method+" "+r.URL.RequestURI()+" "+string(body)))
+		if strings.HasPrefix(r.URL.Path, "/members/") {
+			w.Write([]byte("{}"))
+		} else {
+			w.Write([]byte("[]"))
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL)
+	ctx := context.Background()
+	if _, err := c.Member.List(ctx, &ListRequest{TeamID: 7, Role: "admin"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Member.Replace(ctx, 7, []Member{{ID: "a", Name: "A"}, {ID: "b", Name: "B"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Member.Update(ctx, &Member{ID: "a", Name: "A"}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"GET /teams/7/members?role=admin",
+		` + "`" + `PUT /teams/7/members [{"id":"a","name":"A"},{"id":"b","name":"B"}]` + "`" + `,
+		` + "`" + `PUT /members/a {"id":"a","name":"A"}` + "`" + `,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+`
+
+// TestGoClientRequests 生成的 Go 客户端原样发送请求体：数组仍是数组，请求体声明的路径参数不被去除；
+// 合成的请求类型中的路径参数不出现在查询参数中
+func TestGoClientRequests(t *testing.T) {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip(err)
+	}
+	dir := t.TempDir()
+	copyDir(t, filepath.Join("testdata", "golden", "members-go"), dir)
+	writeTestFile(t, filepath.Join(dir, "go.mod"), "module api\n\ngo 1.21\n")
+	writeTestFile(t, filepath.Join(dir, "client_test.go"), goClientTest)
+	cmd := exec.Command(goBin, "test", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("go test: %v\n%s", err, out)
+	}
+}
+
+// pythonClientTest 导入 members-python 生成的包，用 httpx.MockTransport 记录请求
+const pythonClientTest = `import json
+
+import httpx
+
+from api import Client, ListRequest, Member
+
+got = []
+
+
+def handler(request: httpx.Request) -> httpx.Response:
+    body = json.loads(request.content) if request.content else None
+    got.append([request.method, request.url.raw_path.decode(), body])
+    return httpx.Response(200, json={"id": "a", "name": "A"} if request.url.path.startswith("/members/") else [])
+
+
+client = Client("http://test", http_client=httpx.Client(base_url="http://test", transport=httpx.MockTransport(handler)))
+client.member.list(ListRequest(team_id=7, role="admin"))
+client.member.replace(7, [Member(id="a", name="A"), Member(id="b", name="B")])
+client.member.update(Member(id="a", name="A"))
+want = [
+    ["GET", "/teams/7/members?role=admin", None],
+    ["PUT", "/teams/7/members", [{"id": "a", "name": "A"}, {"id": "b", "name": "B"}]],
+    ["PUT", "/members/a", {"id": "a", "name": "A"}],
+]
+assert got == want, f"requests {got}, want {want}"
+`
+
+// TestPythonClientRequests 与 TestGoClientRequests 相同的请求由生成的 Python 客户端发送；没有 python3 或 httpx、pydantic 时跳过
+func TestPythonClientRequests(t *testing.T) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip(err)
+	}
+	if out, err := exec.Command(python, "-c", "import httpx, pydantic").CombinedOutput(); err != nil {
+		t.Skipf("python3 -c 'import httpx, pydantic': %v\n%s", err, out)
+	}
+	dir := t.TempDir()
+	copyDir(t, filepath.Join("testdata", "golden", "members-python"), filepath.Join(dir, "api"))
+	writeTestFile(t, filepath.Join(dir, "client_test.py"), pythonClientTest)
+	cmd := exec.Command(python, "client_test.py")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("python3 client_test.py: %v\n%s", err, out)
+	}
+}
+
+// dartClientTest 用 members-dart 生成的库发送请求，拦截器记录请求并直接返回响应
+const dartClientTest = `import 'dart:convert';
+import 'dart:io';
+
+import 'package:api/api.dart';
+import 'package:dio/dio.dart';
+
+Future<void> main() async {
+  final got = <String>[];
+  final dio = Dio(BaseOptions(baseUrl: 'http://test'));
+  dio.interceptors.add(InterceptorsWrapper(onRequest: (options, handler) {
+    final uri = options.uri;
+    final body = options.data == null ? '' : jsonEncode(options.data);
+    got.add('${options.method} ${uri.path}${uri.hasQuery ? '?${uri.query}' : ''} $body'.trim());
+    final data = uri.path.startsWith('/members/') ? {'id': 'a', 'name': 'A'} : <dynamic>[];
+    handler.resolve(Response(requestOptions: options, statusCode: 200, data: data));
+  }));
+  final client = ApiClient('http://test', dio: dio);
+  await client.member.list(const ListRequest(teamId: 7, role: 'admin'));
+  await client.member.replace(7, [const Member(id: 'a', name: 'A'), const Member(id: 'b', name: 'B')]);
+  await client.member.update(const Member(id: 'a', name: 'A'));
+  final want = [
+    'GET /teams/7/members?role=admin',
+    'PUT /teams/7/members [{"id":"a","name":"A"},{"id":"b","name":"B"}]',
+    'PUT /members/a {"id":"a","name":"A"}',
+  ];
+  if (got.join('\n') != want.join('\n')) {
+    stderr.writeln('requests:\n${got.join('\n')}\nwant:\n${want.join('\n')}');
+    exit(1);
+  }
+}
+`
+
+// dartPubspec 生成的库需要的依赖，与 README 中 -lang dart 一节一致
+const dartPubspec = `name: api
+environment:
+  sdk: '>=3.0.0 <4.0.0'
+dependencies:
+  dio: ^5.0.0
+  json_annotation: ^4.8.0
+dev_dependencies:
+  build_runner: ^2.4.0
+  json_serializable: ^6.7.0
+`
+
+// TestDartClientRequests 与 TestGoClientRequests 相同的请求由生成的 Dart 客户端发送；没有 dart 或无法获取依赖时跳过
+func TestDartClientRequests(t *testing.T) {
+	dart, err := exec.LookPath("dart")
+	if err != nil {
+		t.Skip(err)
+	}
+	dir := t.TempDir()
+	copyDir(t, filepath.Join("testdata", "golden", "members-dart"), filepath.Join(dir, "lib"))
+	writeTestFile(t, filepath.Join(dir, "pubspec.yaml"), dartPubspec)
+	writeTestFile(t, filepath.Join(dir, "bin", "main.dart"), dartClientTest)
+	run := func(args ...string) ([]byte, error) {
+		cmd := exec.Command(dart, args...)
+		cmd.Dir = dir
+		return cmd.CombinedOutput()
+	}
+	if out, err := run("pub", "get"); err != nil {
+		t.Skipf("dart pub get: %v\n%s", err, out)
+	}
+	if out, err := run("run", "build_runner", "build", "--delete-conflicting-outputs"); err != nil {
+		t.Fatalf("build_runner: %v\n%s", err, out)
+	}
+	if out, err := run("run", "bin/main.dart"); err != nil {
+		t.Errorf("dart run bin/main.dart: %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,537 @@
+// collection.go
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// 请求集合格式
+const (
+	CollectionPostman  = "postman"  // Postman v2.1 集合
+	CollectionInsomnia = "insomnia" // Insomnia v4 导出文件
+	CollectionBruno    = "bruno"    // Bruno 集合目录
+)
+
+// collectionDefaultBaseURL 文档没有 servers 时 baseUrl 变量的默认值
+const collectionDefaultBaseURL = "http://localhost:8080"
+
+// collectionPathParam 路径中的 {name} 变量
+var collectionPathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// collectionRequest 一个接口对应的请求，三种格式都从它生成
+type collectionRequest struct {
+	Module      string
+	File        string // 模块内唯一的名称，Bruno 用作文件名
+	Name        string // 显示名称，接口 summary，没有时使用函数名称
+	Method      string
+	Path        string // /users/:id 形式的路径
+	PathParams  []collectionParam
+	Query       []collectionParam
+	Body        string // 格式化的 JSON 请求体示例，GET 和 DELETE 为空
+	Description string
+}
+
+type collectionParam struct {
+	Key   string
+	Value string
+}
+
+// collectionAuth 文档声明的认证方式，请求中使用变量占位
+type collectionAuth struct {
+	Type string // bearer、basic、apikey，为空表示没有声明认证
+	Key  string // apikey 的参数名称
+	In   string // apikey 的位置：header 或 query
+}
+
+// collectionSpec 生成集合需要的文档信息，Generate 使用的 OpenAPI 类型不包含这些字段
+type collectionSpec struct {
+	Info struct {
+		Title string `yaml:"title"`
+	} `yaml:"info"`
+	Servers []struct {
+		URL string `yaml:"url"`
+	} `yaml:"servers"`
+	Components struct {
+		SecuritySchemes map[string]struct {
+			Type   string `yaml:"type"`
+			Scheme string `yaml:"scheme"`
+			In     string `yaml:"in"`
+			Name   string `yaml:"name"`
+		} `yaml:"securitySchemes"`
+	} `yaml:"components"`
+}
+
+// collection 集合的全部内容
+type collection struct {
+	*run
+	Name     string
+	BaseURL  string
+	Auth     collectionAuth
+	Requests []collectionRequest // 按模块和名称排序
+}
+
+// variables 集合变量：baseUrl 和认证使用的占位变量
+func (c *collection) variables() []collectionParam {
+	vars := []collectionParam{{"baseUrl", c.BaseURL}}
+	switch c.Auth.Type {
+	case "bearer":
+		vars = append(vars, collectionParam{"token", ""})
+	case "basic":
+		vars = append(vars, collectionParam{"username", ""}, collectionParam{"password", ""})
+	case "apikey":
+		vars = append(vars, collectionParam{"apiKey", ""})
+	}
+	return vars
+}
+
+// Collection 把文档转换为 Postman、Insomnia 或 Bruno 的请求集合，每个接口一个请求，按模块分组；
+// 请求体和参数使用按类型生成的示例值，认证使用变量占位，返回文件名到内容
+func (g *Generator) Collection(spec []byte, format string) (Files, error) {
+	r, err := g.newRun()
+	if err != nil {
+		return nil, err
+	}
+	spec, err = r.openAPI(spec)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := r.parseSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	api := r.buildIR(parsed, newSchemaResolver(parsed.Components.Schemas, r.logger))
+	var info collectionSpec
+	if err := yaml.Unmarshal(spec, &info); err != nil {
+		return nil, fmt.Errorf("parse spec: %w", err)
+	}
+	c := r.newCollection(api, info)
+
+	switch format {
+	case CollectionPostman:
+		data, err := c.postman()
+		return Files{fileSafeName(c.Name) + ".postman_collection.json": data}, err
+	case CollectionInsomnia:
+		data, err := c.insomnia()
+		return Files{fileSafeName(c.Name) + ".insomnia.json": data}, err
+	case CollectionBruno:
+		return c.bruno()
+	}
+	return nil, fmt.Errorf("unsupported collection format %q, use postman, insomnia or bruno", format)
+}
+
+// newCollection 从中间表示生成请求
+func (r *run) newCollection(api *ir.API, info collectionSpec) *collection {
+	c := &collection{run: r, Name: info.Info.Title, BaseURL: collectionDefaultBaseURL}
+	if c.Name == "" {
+		c.Name = "API"
+	}
+	if len(info.Servers) > 0 && info.Servers[0].URL != "" {
+		c.BaseURL = strings.TrimRight(info.Servers[0].URL, "/")
+	}
+	// 声明了多种认证方式时使用名称排序后的第一种
+	for _, name := range sortedKeys(info.Components.SecuritySchemes) {
+		scheme := info.Components.SecuritySchemes[name]
+		switch {
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+			c.Auth = collectionAuth{Type: "basic"}
+		case scheme.Type == "apiKey" && (scheme.In == "header" || scheme.In == "query"):
+			c.Auth = collectionAuth{Type: "apikey", Key: scheme.Name, In: scheme.In}
+		case scheme.Type == "http", scheme.Type == "oauth2", scheme.Type == "openIdConnect":
+			c.Auth = collectionAuth{Type: "bearer"}
+		default:
+			continue
+		}
+		break
+	}
+
+	examples := &exampleBuilder{api: api}
+	for _, op := range api.Operations {
+		request := collectionRequest{
+			Module:      op.Module,
+			File:        op.Name,
+			Name:        op.Summary,
+			Method:      op.Method,
+			Path:        collectionPathParam.ReplaceAllString(op.Path, ":$1"),
+			Description: op.Method + " " + op.Path,
+		}
+		if request.Name == "" {
+			request.Name = op.Name
+		}
+		var params interface{}
+		if op.Request != nil {
+			params = examples.value(*op.Request, 0)
+		}
+		fields, _ := params.(map[string]interface{})
+		for _, m := range collectionPathParam.FindAllStringSubmatch(op.Path, -1) {
+			value := ""
+			if v, ok := fields[m[1]]; ok {
+				value = queryValue(v)
+				delete(fields, m[1])
+			}
+			request.PathParams = append(request.PathParams, collectionParam{m[1], value})
+		}
+		switch {
+		case op.Method == "GET" || op.Method == "DELETE":
+			for _, key := range sortedKeys(fields) {
+				values, ok := fields[key].([]interface{})
+				if !ok {
+					values = []interface{}{fields[key]}
+				}
+				for _, v := range values {
+					request.Query = append(request.Query, collectionParam{key, queryValue(v)})
+				}
+			}
+		case params != nil:
+			body, _ := json.MarshalIndent(params, "", "  ")
+			request.Body = string(body)
+		}
+		c.Requests = append(c.Requests, request)
+	}
+	sort.SliceStable(c.Requests, func(i, j int) bool {
+		if c.Requests[i].Module != c.Requests[j].Module {
+			return c.Requests[i].Module < c.Requests[j].Module
+		}
+		return c.Requests[i].File < c.Requests[j].File
+	})
+	return c
+}
+
+// queryValue 查询参数和路径变量的字符串形式，对象序列化为 JSON
+func queryValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+	return fmt.Sprint(v)
+}
+
+// exampleMaxDepth 示例中嵌套模型的最大深度，避免递归类型无限展开
+const exampleMaxDepth = 4
+
+// exampleBuilder 按类型生成固定的示例值，同一份文档每次生成的结果相同
+type exampleBuilder struct {
+	api *ir.API
+}
+
+// value 类型 t 的示例值；字符串按 format 给出典型值，枚举取第一个值，数组包含一个元素
+func (b *exampleBuilder) value(t ir.Type, depth int) interface{} {
+	if depth > exampleMaxDepth {
+		return nil
+	}
+	switch t.Kind {
+	case ir.String:
+		switch t.Format {
+		case "date-time":
+			return "2024-01-01T00:00:00Z"
+		case "date":
+			return "2024-01-01"
+		case "email":
+			return "user@example.com"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		case "uri", "url":
+			return "https://example.com"
+		}
+		return "string"
+	case ir.Integer, ir.Number:
+		if t.Constraints != nil && t.Constraints.Minimum != nil {
+			return *t.Constraints.Minimum
+		}
+		return 0
+	case ir.Boolean:
+		return false
+	case ir.Object:
+		return map[string]interface{}{}
+	case ir.EnumRef:
+		if len(t.Values) > 0 {
+			return t.Values[0]
+		}
+		for _, enum := range b.api.Enums {
+			if enum.Name == t.Ref && len(enum.Values) > 0 {
+				return enum.Values[0]
+			}
+		}
+		return "string"
+	case ir.Ref:
+		model, ok := b.api.Model(t.Ref)
+		if !ok {
+			return nil
+		}
+		if model.Alias != nil {
+			return b.value(*model.Alias, depth+1)
+		}
+		result := make(map[string]interface{})
+		for _, base := range model.Extends {
+			if fields, ok := b.value(ir.Type{Kind: ir.Ref, Ref: base}, depth+1).(map[string]interface{}); ok {
+				for key, value := range fields {
+					result[key] = value
+				}
+			}
+		}
+		for _, field := range model.Fields {
+			if value := b.value(field.Type, depth+1); value != nil {
+				result[field.Name] = value
+			}
+		}
+		return result
+	case ir.Array:
+		if t.Items == nil {
+			return []interface{}{}
+		}
+		if item := b.value(*t.Items, depth+1); item != nil {
+			return []interface{}{item}
+		}
+		return []interface{}{}
+	case ir.Map:
+		if t.Items == nil {
+			return map[string]interface{}{}
+		}
+		return map[string]interface{}{"key": b.value(*t.Items, depth+1)}
+	case ir.Tuple:
+		var values []interface{}
+		for _, element := range t.Elements {
+			values = append(values, b.value(element, depth+1))
+		}
+		return values
+	}
+	return nil
+}
+
+// rawURL {{baseUrl}} 开头、包含查询参数的完整 URL
+func (r collectionRequest) rawURL(baseURL string) string {
+	url := baseURL + r.Path
+	for i, q := range r.Query {
+		sep := "&"
+		if i == 0 {
+			sep = "?"
+		}
+		url += sep + q.Key + "=" + q.Value
+	}
+	return url
+}
+
+// postman 生成 Postman v2.1 集合，每个模块一个文件夹
+func (c *collection) postman() ([]byte, error) {
+	var folders []interface{}
+	var items []interface{}
+	for i, r := range c.Requests {
+		segments := strings.Split(strings.TrimPrefix(r.Path, "/"), "/")
+		url := map[string]interface{}{
+			"raw":  r.rawURL("{{baseUrl}}"),
+			"host": []string{"{{baseUrl}}"},
+			"path": segments,
+		}
+		if r.Query != nil {
+			var query []interface{}
+			for _, q := range r.Query {
+				query = append(query, map[string]string{"key": q.Key, "value": q.Value})
+			}
+			url["query"] = query
+		}
+		if r.PathParams != nil {
+			var variables []interface{}
+			for _, p := range r.PathParams {
+				variables = append(variables, map[string]string{"key": p.Key, "value": p.Value})
+			}
+			url["variable"] = variables
+		}
+		request := map[string]interface{}{
+			"method":      r.Method,
+			"url":         url,
+			"description": r.Description,
+			"header":      []interface{}{},
+		}
+		if r.Body != "" {
+			request["header"] = []interface{}{map[string]string{"key": "Content-Type", "value": "application/json"}}
+			request["body"] = map[string]interface{}{
+				"mode":    "raw",
+				"raw":     r.Body,
+				"options": map[string]interface{}{"raw": map[string]string{"language": "json"}},
+			}
+		}
+		items = append(items, map[string]interface{}{"name": r.Name, "request": request, "response": []interface{}{}})
+		if i == len(c.Requests)-1 || c.Requests[i+1].Module != r.Module {
+			folders = append(folders, map[string]interface{}{"name": r.Module, "item": items})
+			items = nil
+		}
+	}
+
+	var variables []interface{}
+	for _, v := range c.variables() {
+		variables = append(variables, map[string]string{"key": v.Key, "value": v.Value})
+	}
+	result := map[string]interface{}{
+		"info": map[string]interface{}{
+			"name":   c.Name,
+			"schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json",
+		},
+		"item":     folders,
+		"variable": variables,
+	}
+	switch c.Auth.Type {
+	case "bearer":
+		result["auth"] = map[string]interface{}{"type": "bearer", "bearer": []interface{}{
+			map[string]string{"key": "token", "value": "{{token}}", "type": "string"},
+		}}
+	case "basic":
+		result["auth"] = map[string]interface{}{"type": "basic", "basic": []interface{}{
+			map[string]string{"key": "username", "value": "{{username}}", "type": "string"},
+			map[string]string{"key": "password", "value": "{{password}}", "type": "string"},
+		}}
+	case "apikey":
+		result["auth"] = map[string]interface{}{"type": "apikey", "apikey": []interface{}{
+			map[string]string{"key": "key", "value": c.Auth.Key, "type": "string"},
+			map[string]string{"key": "value", "value": "{{apiKey}}", "type": "string"},
+			map[string]string{"key": "in", "value": c.Auth.In, "type": "string"},
+		}}
+	}
+	return marshalCollection(result)
+}
+
+// insomnia 生成 Insomnia v4 导出文件，每个模块一个请求组，变量在基础环境中
+func (c *collection) insomnia() ([]byte, error) {
+	const workspace = "wrk_moonbeam"
+	data := make(map[string]string)
+	for _, v := range c.variables() {
+		data[v.Key] = v.Value
+	}
+	resources := []interface{}{
+		map[string]interface{}{"_id": workspace, "_type": "workspace", "parentId": nil, "name": c.Name, "scope": "collection"},
+		map[string]interface{}{"_id": "env_moonbeam", "_type": "environment", "parentId": workspace, "name": "Base Environment", "data": data},
+	}
+	var authentication map[string]interface{}
+	switch c.Auth.Type {
+	case "bearer":
+		authentication = map[string]interface{}{"type": "bearer", "token": "{{ _.token }}"}
+	case "basic":
+		authentication = map[string]interface{}{"type": "basic", "username": "{{ _.username }}", "password": "{{ _.password }}"}
+	case "apikey":
+		addTo := "header"
+		if c.Auth.In == "query" {
+			addTo = "queryParams"
+		}
+		authentication = map[string]interface{}{"type": "apikey", "key": c.Auth.Key, "value": "{{ _.apiKey }}", "addTo": addTo}
+	}
+
+	for i, r := range c.Requests {
+		folder := "fld_" + fileSafeName(r.Module)
+		if i == 0 || c.Requests[i-1].Module != r.Module {
+			resources = append(resources, map[string]interface{}{"_id": folder, "_type": "request_group", "parentId": workspace, "name": r.Module})
+		}
+		request := map[string]interface{}{
+			"_id":         "req_" + fileSafeName(r.Module) + "_" + fileSafeName(r.File),
+			"_type":       "request",
+			"parentId":    folder,
+			"name":        r.Name,
+			"description": r.Description,
+			"method":      r.Method,
+			"url":         "{{ _.baseUrl }}" + r.Path,
+			"headers":     []interface{}{},
+			"parameters":  []interface{}{},
+			"body":        map[string]interface{}{},
+		}
+		if authentication != nil {
+			request["authentication"] = authentication
+		}
+		var parameters []interface{}
+		for _, q := range r.Query {
+			parameters = append(parameters, map[string]string{"name": q.Key, "value": q.Value})
+		}
+		if parameters != nil {
+			request["parameters"] = parameters
+		}
+		var pathParameters []interface{}
+		for _, p := range r.PathParams {
+			pathParameters = append(pathParameters, map[string]string{"name": p.Key, "value": p.Value})
+		}
+		if pathParameters != nil {
+			request["pathParameters"] = pathParameters
+		}
+		if r.Body != "" {
+			request["headers"] = []interface{}{map[string]string{"name": "Content-Type", "value": "application/json"}}
+			request["body"] = map[string]interface{}{"mimeType": "application/json", "text": r.Body}
+		}
+		resources = append(resources, request)
+	}
+	return marshalCollection(map[string]interface{}{
+		"_type":           "export",
+		"__export_format": 4,
+		"__export_source": "moonbeam:" + Version,
+		"resources":       resources,
+	})
+}
+
+// bruno 生成 Bruno 集合目录：bruno.json、默认环境和每个模块一个文件夹
+func (c *collection) bruno() (Files, error) {
+	tmpl, err := c.parseTemplate("templates/bruno-request.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parse bruno-request template: %w", err)
+	}
+	meta, err := marshalCollection(map[string]interface{}{"version": "1", "name": c.Name, "type": "collection"})
+	if err != nil {
+		return nil, err
+	}
+	var env strings.Builder
+	env.WriteString("vars {\n")
+	for _, v := range c.variables() {
+		fmt.Fprintf(&env, "  %s: %s\n", v.Key, v.Value)
+	}
+	env.WriteString("}\n")
+	files := Files{"bruno.json": meta, "environments/Default.bru": []byte(env.String())}
+
+	seq := 0
+	for i, r := range c.Requests {
+		if i == 0 || c.Requests[i-1].Module != r.Module {
+			files[fileSafeName(r.Module)+"/folder.bru"] = []byte(fmt.Sprintf("meta {\n  name: %s\n}\n", r.Module))
+			seq = 0
+		}
+		seq++
+		var body bytes.Buffer
+		err := tmpl.Execute(&body, map[string]interface{}{
+			"Request": r,
+			"Seq":     seq,
+			"URL":     r.rawURL("{{baseUrl}}"),
+			"Auth":    c.Auth,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("render %s: %w", r.File, err)
+		}
+		files[fileSafeName(r.Module)+"/"+fileSafeName(r.File)+".bru"] = body.Bytes()
+	}
+	return files, nil
+}
+
+// marshalCollection 格式化的 JSON，以换行结尾
+func marshalCollection(v interface{}) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// unsafeFileChars 文件名中替换为 - 的字符
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// fileSafeName 只包含字母、数字、点、下划线和连字符的文件名
+func fileSafeName(name string) string {
+	name = strings.Trim(unsafeFileChars.ReplaceAllString(name, "-"), "-.")
+	if name == "" {
+		return "api"
+	}
+	return name
+}
@@ -0,0 +1,57 @@
+// contract.go
+package generator
+
+import (
+	"path/filepath"
+	"sort"
+	"text/template"
+)
+
+// contractFrameworks 各测试框架 describe/it/expect 的导入来源
+var contractFrameworks = map[string]string{
+	"vitest": "vitest",
+	"jest":   "@jest/globals",
+}
+
+// ContractFileData 契约测试文件模板数据
+type ContractFileData struct {
+	ModuleName string
+	Framework  string   // 测试框架导入来源
+	Parsers    []string // 需要从 types/schemas.ts 导入的 parseXxx
+	Operations []FunctionData
+}
+
+// renderContractTests 在 contract 目录下生成 setup.ts 和每个模块的契约测试
+// 测试调用真实接口，并用生成的校验器断言响应结构与类型一致
+func (r *run) renderContractTests(framework string, setupTmpl, testTmpl *template.Template, modules map[string]*ModuleData) {
+	contractDir := "contract"
+	r.writeModuleFile(setupTmpl, filepath.Join(contractDir, "setup.ts"), "contract setup", "contract", nil)
+
+	var names []string
+	for name := range modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		mod := modules[name]
+		if len(mod.Operations) == 0 {
+			continue
+		}
+		data := ContractFileData{
+			ModuleName: mod.Name,
+			Framework:  contractFrameworks[framework],
+			Operations: mod.Operations,
+		}
+		usedParsers := make(map[string]bool)
+		for _, op := range mod.Operations {
+			if !op.EmptyResponse && !usedParsers["parse"+op.ResponseModel] {
+				usedParsers["parse"+op.ResponseModel] = true
+				data.Parsers = append(data.Parsers, "parse"+op.ResponseModel)
+			}
+		}
+		sort.Strings(data.Parsers)
+		filename := filepath.Join(contractDir, mod.Name+".contract.test.ts")
+		r.writeModuleFile(testTmpl, filename, "contract test", mod.Name, data)
+	}
+}
@@ -0,0 +1,88 @@
+// cycles.go
+package generator
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// valueImports 返回生成的 TypeScript 文件之间的值导入关系；import type 和 export type 在编译后会被删除，不会形成运行时的循环
+func valueImports(files Files) map[string][]string {
+	graph := make(map[string][]string)
+	for _, name := range sortedKeys(files) {
+		if !strings.HasSuffix(name, ".ts") && !strings.HasSuffix(name, ".tsx") {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, imp := range scanImports(files[name]) {
+			if imp.Statement < 0 || imp.TypeOnly || !isRelativeSpecifier(imp.Specifier) {
+				continue
+			}
+			target := path.Join(path.Dir(name), imp.Specifier)
+			if _, ok := files[target]; ok && !seen[target] {
+				seen[target] = true
+				graph[name] = append(graph[name], target)
+			}
+		}
+	}
+	return graph
+}
+
+// importCycles 返回生成文件之间的值导入循环，每个循环从其中名称最小的文件开始，首尾相同，例如 [a.ts b.ts a.ts]
+func importCycles(files Files) [][]string {
+	graph := valueImports(files)
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int)
+	var stack []string
+	found := make(map[string][]string)
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, next := range graph[name] {
+			switch state[next] {
+			case 0:
+				visit(next)
+			case visiting:
+				// 栈中从 next 开始的部分构成一个循环，旋转到名称最小的文件开头以便去重
+				var cycle []string
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i] == next {
+						cycle = append([]string{}, stack[i:]...)
+						break
+					}
+				}
+				start := 0
+				for i, file := range cycle {
+					if file < cycle[start] {
+						start = i
+					}
+				}
+				cycle = append(cycle[start:], cycle[:start]...)
+				found[strings.Join(cycle, " ")] = append(cycle, cycle[0])
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = done
+	}
+	var names []string
+	for name := range graph {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if state[name] == 0 {
+			visit(name)
+		}
+	}
+
+	var cycles [][]string
+	for _, key := range sortedKeys(found) {
+		cycles = append(cycles, found[key])
+	}
+	return cycles
+}
@@ -0,0 +1,431 @@
+// dart.go
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// dartKeywords Dart 保留字和 Object、json_serializable 占用的成员，字段、方法和枚举成员遇到时加下划线后缀；
+// 内置标识符（get、set、required 等）可以作为成员名称
+var dartKeywords = map[string]bool{
+	"assert": true, "await": true, "break": true, "case": true, "catch": true, "class": true, "const": true,
+	"continue": true, "default": true, "do": true, "else": true, "enum": true, "extends": true, "false": true,
+	"final": true, "finally": true, "for": true, "if": true, "in": true, "is": true, "new": true, "null": true,
+	"rethrow": true, "return": true, "super": true, "switch": true, "this": true, "throw": true, "true": true,
+	"try": true, "var": true, "void": true, "while": true, "with": true, "yield": true,
+	"hashCode": true, "runtimeType": true, "toString": true, "noSuchMethod": true, "toJson": true, "fromJson": true,
+}
+
+// dartEnumMembers 枚举自带的成员，枚举成员遇到时加下划线后缀
+var dartEnumMembers = map[string]bool{"index": true, "name": true, "values": true, "value": true}
+
+// dartReserved 包中占用和 dart:core 中常用的类型名称，模型和枚举遇到时加上 Model 后缀
+var dartReserved = map[string]bool{
+	"ApiClient": true, "BigInt": true, "DateTime": true, "Duration": true, "Enum": true, "Error": true,
+	"Exception": true, "Function": true, "Future": true, "Iterable": true, "List": true, "Map": true,
+	"MapEntry": true, "Null": true, "Object": true, "Record": true, "Set": true, "Stream": true,
+	"String": true, "Symbol": true, "Type": true, "Uri": true,
+	"Dio": true, "Options": true, "Response": true, "JsonKey": true, "JsonSerializable": true, "JsonEnum": true,
+}
+
+// dartName 转换为 Dart 类型名称，例如 user_id -> UserId，2fa -> X2fa
+func dartName(s string) string {
+	name := pascalCase(s)
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "X" + name
+	}
+	return name
+}
+
+// dartMember 转换为 Dart 字段或方法名称，例如 user_id -> userId，关键字加下划线后缀
+func dartMember(s string) string {
+	name := dartName(s)
+	name = strings.ToLower(name[:1]) + name[1:]
+	if dartKeywords[name] {
+		name += "_"
+	}
+	return name
+}
+
+// dartEscaper 转义 Dart 单引号字符串中的特殊字符
+var dartEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, `$`, `\$`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// dartQuote Dart 单引号字符串字面量
+func dartQuote(s string) string {
+	return "'" + dartEscaper.Replace(s) + "'"
+}
+
+// dartModel json_serializable 类；Type 非空时生成 typedef Name = Type
+type dartModel struct {
+	Name   string
+	Doc    []string
+	Type   string
+	Fields []dartField
+}
+
+type dartField struct {
+	Name     string
+	Type     string
+	JSONName string // 与字段名称不同时生成 @JsonKey(name: ...)
+	Required bool
+	Doc      []string
+}
+
+// dartEnum 带 value 字段的增强枚举，序列化为原始取值
+type dartEnum struct {
+	Name    string
+	Doc     []string
+	Members []dartConst
+}
+
+type dartConst struct {
+	Name  string
+	Value string
+}
+
+// dartService 一个模块的接口类，作为 ApiClient 的字段，例如 client.user.get(params)
+type dartService struct {
+	Module  string
+	File    string // 文件名称，不含 .dart
+	Field   string
+	Class   string
+	Methods []dartMethod
+}
+
+// dartMethod 服务类上的接口方法
+type dartMethod struct {
+	Name   string
+	Doc    []string
+	Method string
+	Path   string
+	Args   []dartArg // 请求体之外的路径参数，位于 params 之前
+	Values string    // 传给 request 的 pathParams，例如 {'id': id}
+	Params string    // 参数类型，为空表示没有参数
+	Encode string    // 请求参数的 JSON 表达式
+	Body   bool      // params 是请求体，GET/DELETE 之外原样发送
+	Result string    // 返回值类型，为空表示没有响应体
+	Decode string    // 从响应数据 data 构造返回值的表达式
+}
+
+// dartArg 方法参数
+type dartArg struct {
+	Name string
+	Type string
+}
+
+// dartRenderer 记录 IR 中模型和枚举的 Dart 类型名称
+type dartRenderer struct {
+	api     *ir.API
+	types   map[string]string // 原始名称 -> Dart 类型名称
+	models  map[string]ir.Model
+	classes map[string]bool // 生成为类的模型，其余生成 typedef
+	enums   map[string]bool
+}
+
+// generateDart 根据中间表示生成 Dart 库：json_serializable 模型（models.dart，需要 build_runner 生成 models.g.dart）、
+// 基于 Dio 的运行时（client.dart）、每个模块一个 <module>_service.dart，api.dart 导出全部内容
+func (r *run) generateDart(data []byte) (*ir.API, error) {
+	spec, err := r.parseSpec(data)
+	if err != nil {
+		return nil, err
+	}
+	api := r.buildIR(spec, newSchemaResolver(spec.Components.Schemas, r.logger))
+	renderer := &dartRenderer{api: api, types: make(map[string]string), models: make(map[string]ir.Model), classes: make(map[string]bool), enums: make(map[string]bool)}
+
+	names := make(uniqueNames)
+	for name := range dartReserved {
+		names[name] = true
+	}
+	modules := make(map[string][]ir.Operation)
+	for _, op := range api.Operations {
+		modules[op.Module] = append(modules[op.Module], op)
+	}
+	fields := uniqueNames{"dio": true, "request": true}
+	var services []dartService
+	for _, module := range sortedKeys(modules) {
+		field := fields.unique(dartMember(module))
+		services = append(services, dartService{Module: module, File: snakeCase(field) + "_service", Field: field, Class: names.unique(dartName(module) + "Service")})
+	}
+	typeName := func(name string) string {
+		if dartReserved[name] {
+			name += "Model"
+		}
+		return names.unique(name)
+	}
+	for _, enum := range api.Enums {
+		renderer.types[enum.Name] = typeName(dartName(enum.TypeName))
+		renderer.enums[enum.Name] = len(enum.Members) > 0
+	}
+	for _, model := range api.Models {
+		renderer.types[model.Name] = typeName(dartName(model.TypeName))
+		renderer.models[model.Name] = model
+		renderer.classes[model.Name] = model.Alias == nil && len(renderer.fields(model, make(map[string]bool))) > 0
+	}
+
+	var enums []dartEnum
+	for _, enum := range api.Enums {
+		enums = append(enums, renderer.enum(enum))
+	}
+	var classes, aliases []dartModel
+	for _, model := range api.Models {
+		if result := renderer.model(model); result.Type != "" {
+			aliases = append(aliases, result)
+		} else {
+			classes = append(classes, result)
+		}
+	}
+	tracker := r.startProgress(StageOperations, len(api.Operations))
+	for i := range services {
+		methodNames := make(uniqueNames)
+		for _, op := range modules[services[i].Module] {
+			services[i].Methods = append(services[i].Methods, renderer.method(op, methodNames.unique(dartMember(op.Name))))
+			tracker.step()
+		}
+		sort.Slice(services[i].Methods, func(a, b int) bool { return services[i].Methods[a].Name < services[i].Methods[b].Name })
+	}
+
+	files := map[string]struct {
+		template string
+		data     interface{}
+	}{
+		"api.dart":    {"templates/dart-library.tmpl", map[string]interface{}{"Services": services}},
+		"client.dart": {"templates/dart-client.tmpl", map[string]interface{}{"Services": services}},
+		"models.dart": {"templates/dart-models.tmpl", map[string]interface{}{"Enums": enums, "Classes": classes, "Aliases": aliases}},
+	}
+	for _, filename := range sortedKeys(files) {
+		tmpl, err := r.parseTemplate(files[filename].template)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s template: %w", strings.TrimSuffix(strings.TrimPrefix(files[filename].template, "templates/"), ".tmpl"), err)
+		}
+		if err := r.renderSourceFile(tmpl, filename, files[filename].data); err != nil {
+			return nil, err
+		}
+	}
+	serviceTmpl, err := r.parseTemplate("templates/dart-service.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parse dart-service template: %w", err)
+	}
+	for _, service := range services {
+		if err := r.renderSourceFile(serviceTmpl, service.File+".dart", service); err != nil {
+			return nil, err
+		}
+	}
+	return api, nil
+}
+
+// enum 枚举成员名称为 lowerCamelCase 的取值，例如 in-progress -> inProgress
+func (r *dartRenderer) enum(enum ir.Enum) dartEnum {
+	result := dartEnum{Name: r.types[enum.Name], Doc: docLines(enum.Description)}
+	names := make(uniqueNames)
+	for _, member := range enum.Members {
+		name := "empty"
+		if member.Value != "" {
+			name = dartMember(member.Value)
+		}
+		if dartEnumMembers[name] {
+			name += "_"
+		}
+		result.Members = append(result.Members, dartConst{Name: names.unique(name), Value: dartQuote(member.Value)})
+	}
+	return result
+}
+
+// fields 模型的全部字段，allOf 基类的字段在前；Dart 类只能单继承，基类字段直接展开到子类
+func (r *dartRenderer) fields(model ir.Model, visiting map[string]bool) []ir.Field {
+	if visiting[model.Name] {
+		return nil
+	}
+	visiting[model.Name] = true
+	defer delete(visiting, model.Name)
+
+	var result []ir.Field
+	seen := make(map[string]int)
+	add := func(field ir.Field) {
+		if i, ok := seen[field.Name]; ok {
+			result[i] = field
+			return
+		}
+		seen[field.Name] = len(result)
+		result = append(result, field)
+	}
+	for _, base := range model.Extends {
+		if base, ok := r.models[base]; ok && base.Alias == nil {
+			for _, field := range r.fields(base, visiting) {
+				add(field)
+			}
+		}
+	}
+	for _, field := range model.Fields {
+		add(field)
+	}
+	return result
+}
+
+// model 有字段的模型生成 @JsonSerializable 类，可选字段可空；其余生成 typedef
+func (r *dartRenderer) model(model ir.Model) dartModel {
+	result := dartModel{Name: r.types[model.Name], Doc: docLines(model.Description)}
+	switch {
+	case model.Alias != nil:
+		result.Type = r.typeName(*model.Alias)
+		return result
+	case !r.classes[model.Name]:
+		result.Type = "Map<String, dynamic>"
+		return result
+	}
+	names := make(uniqueNames)
+	for _, field := range r.fields(model, make(map[string]bool)) {
+		name := names.unique(dartMember(field.Name))
+		f := dartField{
+			Name:     name,
+			Type:     r.typeName(field.Type),
+			Required: field.Required,
+			Doc:      docLines(field.Description),
+		}
+		if name != field.Name {
+			f.JSONName = dartQuote(field.Name)
+		}
+		if !f.Required && f.Type != "dynamic" {
+			f.Type += "?"
+		}
+		result.Fields = append(result.Fields, f)
+	}
+	return result
+}
+
+// method 生成接口方法
+func (r *dartRenderer) method(op ir.Operation, name string) dartMethod {
+	summary := op.Summary
+	if summary == "" {
+		summary = "调用 " + op.Method + " " + op.Path
+	}
+	method := dartMethod{
+		Name:   name,
+		Doc:    append(docLines(summary), "", op.Method+" "+op.Path),
+		Method: dartQuote(op.Method),
+		Path:   dartQuote(op.Path),
+		Encode: "null",
+		Body:   op.Body,
+	}
+	if op.Request != nil {
+		method.Params = r.typeName(*op.Request)
+		method.Encode = "params"
+		if op.Request.Kind == ir.Ref && r.classes[op.Request.Ref] {
+			method.Encode = "params.toJson()"
+		}
+	}
+	if op.Response != nil {
+		method.Result = r.typeName(*op.Response)
+		method.Decode = r.decode(*op.Response, "data", make(map[string]bool))
+	}
+	// 请求体之外的路径参数作为方法参数，合成的请求类型中的路径参数是必填字段；枚举取 value
+	names := uniqueNames{"params": true, "data": true}
+	var values []string
+	for _, field := range op.PathParams {
+		arg := dartArg{Name: names.unique(dartMember(field.Name)), Type: r.typeName(field.Type)}
+		method.Args = append(method.Args, arg)
+		value := arg.Name
+		if _, ok := r.types[field.Type.Ref]; ok && field.Type.Kind == ir.EnumRef {
+			value += ".value"
+		}
+		values = append(values, dartQuote(field.Name)+": "+value)
+	}
+	if len(values) > 0 {
+		method.Values = "{" + strings.Join(values, ", ") + "}"
+	}
+	return method
+}
+
+// typeName 类型表达式对应的 Dart 类型
+func (r *dartRenderer) typeName(t ir.Type) string {
+	switch t.Kind {
+	case ir.String:
+		if t.Format == "date-time" {
+			return "DateTime"
+		}
+		return "String"
+	case ir.Integer:
+		return "int"
+	case ir.Number:
+		return "double"
+	case ir.Boolean:
+		return "bool"
+	case ir.Object:
+		return "Map<String, dynamic>"
+	case ir.Ref:
+		if name, ok := r.types[t.Ref]; ok {
+			return name
+		}
+	case ir.EnumRef:
+		if name, ok := r.types[t.Ref]; ok && r.enums[t.Ref] {
+			return name
+		}
+		return "String"
+	case ir.Array:
+		if t.Items == nil {
+			return "List<dynamic>"
+		}
+		return "List<" + r.typeName(*t.Items) + ">"
+	case ir.Map:
+		if t.Items == nil {
+			return "Map<String, dynamic>"
+		}
+		return "Map<String, " + r.typeName(*t.Items) + ">"
+	case ir.Tuple:
+		return "List<dynamic>"
+	}
+	return "dynamic"
+}
+
+// decode 从 JSON 值 v 构造类型 t 的表达式；模型类使用 fromJson，typedef 按其定义展开
+func (r *dartRenderer) decode(t ir.Type, v string, aliases map[string]bool) string {
+	switch t.Kind {
+	case ir.String:
+		if t.Format == "date-time" {
+			return "DateTime.parse(" + v + " as String)"
+		}
+		return v + " as String"
+	case ir.Integer:
+		return "(" + v + " as num).toInt()"
+	case ir.Number:
+		return "(" + v + " as num).toDouble()"
+	case ir.Boolean:
+		return v + " as bool"
+	case ir.Object:
+		return v + " as Map<String, dynamic>"
+	case ir.Ref:
+		model, ok := r.models[t.Ref]
+		switch {
+		case !ok:
+		case r.classes[t.Ref]:
+			return r.types[t.Ref] + ".fromJson(" + v + " as Map<String, dynamic>)"
+		case model.Alias != nil && !aliases[t.Ref]:
+			aliases[t.Ref] = true
+			defer delete(aliases, t.Ref)
+			return r.decode(*model.Alias, v, aliases)
+		case model.Alias == nil:
+			return v + " as Map<String, dynamic>"
+		}
+	case ir.EnumRef:
+		if name, ok := r.types[t.Ref]; ok && r.enums[t.Ref] {
+			return name + ".fromJson(" + v + " as String)"
+		}
+		return v + " as String"
+	case ir.Array:
+		if t.Items == nil {
+			return v + " as List<dynamic>"
+		}
+		return "(" + v + " as List<dynamic>).map((e) => " + r.decode(*t.Items, "e", aliases) + ").toList()"
+	case ir.Map:
+		if t.Items == nil {
+			return v + " as Map<String, dynamic>"
+		}
+		return "(" + v + " as Map<String, dynamic>).map((k, e) => MapEntry(k, " + r.decode(*t.Items, "e", aliases) + "))"
+	case ir.Tuple:
+		return v + " as List<dynamic>"
+	}
+	return v
+}
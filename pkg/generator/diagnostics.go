@@ -0,0 +1,68 @@
+// diagnostics.go
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 诊断的级别：ignore 只记录调试日志，warn 记录警告，error 记录错误并使生成失败
+const (
+	levelIgnore = "ignore"
+	levelWarn   = "warn"
+	levelError  = "error"
+)
+
+// diagnosticRules 可以用 -diagnostic 调整级别的规则及其默认级别
+var diagnosticRules = map[string]string{
+	"missing-operation-id": levelIgnore, // 接口没有 operationId（也没有 x-moonbeam-name），函数名由请求方法和路径生成
+	"unresolved-ref":       levelWarn,   // 无法解析的 $ref，-strict 时默认为 error
+	"any-type":             levelWarn,   // 生成为 any、object 或没有类型的属性、参数和响应
+	"duplicate-name":       levelWarn,   // 重复的 operationId，以及推导出相同函数名而按路径改名的接口
+	"unsupported":          levelWarn,   // 其他不支持的特性，例如 oneOf、未知的 format
+}
+
+// parseDiagnostics 解析 -diagnostic：<规则>=<级别>，同一条规则出现多次时后面的优先，与命令行参数覆盖配置文件一致
+func parseDiagnostics(values []string) (map[string]string, error) {
+	levels := make(map[string]string)
+	for _, value := range values {
+		rule, level, found := strings.Cut(value, "=")
+		rule, level = strings.TrimSpace(rule), strings.TrimSpace(level)
+		if _, ok := diagnosticRules[rule]; !found || !ok {
+			return nil, fmt.Errorf("invalid -diagnostic %q, expected '<rule>=<level>' with a rule of %s", value, strings.Join(sortedKeys(diagnosticRules), ", "))
+		}
+		if level != levelIgnore && level != levelWarn && level != levelError {
+			return nil, fmt.Errorf("invalid -diagnostic %q, expected a level of ignore, warn or error", value)
+		}
+		levels[rule] = level
+	}
+	return levels, nil
+}
+
+// diagnosticLevel 规则的级别
+func (r *run) diagnosticLevel(rule string) string {
+	if level, ok := r.diagnosticLevels[rule]; ok {
+		return level
+	}
+	return diagnosticRules[rule]
+}
+
+// diagnose 按规则的级别记录诊断，日志中带上规则名称，便于用 -diagnostic 调整；
+// error 级别同时计入 problems，Generate 结束时返回错误，见 checkProblems
+func (r *run) diagnose(rule, msg string, args ...interface{}) {
+	r.logDiagnostic(r.diagnosticLevel(rule), rule, msg, args...)
+}
+
+// logDiagnostic 按 level 记录诊断
+func (r *run) logDiagnostic(level, rule, msg string, args ...interface{}) {
+	args = append(args, "rule", rule)
+	switch level {
+	case levelIgnore:
+		r.logger.Debug(msg, args...)
+	case levelError:
+		r.problems.diagnostics.Add(1)
+		r.logger.Error(msg, args...)
+	default:
+		r.logger.Warn(msg, args...)
+	}
+}
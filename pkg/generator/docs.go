@@ -0,0 +1,147 @@
+// docs.go
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// docsInfo 文档的 info，用于参考文档的标题
+type docsInfo struct {
+	Title       string `yaml:"title"`
+	Version     string `yaml:"version"`
+	Description string `yaml:"description"`
+}
+
+// Docs 生成 Markdown 格式的接口参考文档：按模块列出接口及其请求和响应类型，再列出所有类型和枚举；
+// 过滤、分组和命名规则与 Generate 相同，类型使用生成的 TypeScript 类型名称
+func (g *Generator) Docs(spec []byte) ([]byte, error) {
+	r, err := g.newRun()
+	if err != nil {
+		return nil, err
+	}
+	spec, err = r.openAPI(spec)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := r.parseSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	api := r.buildIR(parsed, newSchemaResolver(parsed.Components.Schemas, r.logger))
+	var doc struct {
+		Info docsInfo `yaml:"info"`
+	}
+	if err := r.decodeDocument(spec, &doc); err != nil {
+		return nil, err
+	}
+	return renderDocs(api, doc.Info), nil
+}
+
+// renderDocs 渲染参考文档
+func renderDocs(api *ir.API, info docsInfo) []byte {
+	var b strings.Builder
+	title := info.Title
+	if title == "" {
+		title = "API"
+	}
+	fmt.Fprintf(&b, "# %s\n", title)
+	if info.Version != "" {
+		fmt.Fprintf(&b, "\nVersion %s\n", info.Version)
+	}
+	if description := strings.TrimSpace(info.Description); description != "" {
+		fmt.Fprintf(&b, "\n%s\n", description)
+	}
+
+	// 模块按名称排序，模块内的接口保持 IR 的顺序（按路径）
+	modules := make(map[string][]ir.Operation)
+	var names []string
+	for _, op := range api.Operations {
+		if _, ok := modules[op.Module]; !ok {
+			names = append(names, op.Module)
+		}
+		modules[op.Module] = append(modules[op.Module], op)
+	}
+	sort.Strings(names)
+	if len(names) > 0 {
+		b.WriteString("\n## Operations\n")
+	}
+	for _, name := range names {
+		fmt.Fprintf(&b, "\n### %s\n\n", name)
+		b.WriteString("| Operation | Function | Request | Response | Summary |\n")
+		b.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, op := range modules[name] {
+			request, response := "-", "-"
+			if op.Request != nil {
+				request = docsCode(tsType(*op.Request))
+			}
+			if op.Response != nil {
+				response = docsCode(tsType(*op.Response))
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+				docsCode(op.Method+" "+op.Path), docsCode(sanitizeIdentifier(op.Name)), request, response, docsText(op.Summary))
+		}
+	}
+
+	if len(api.Models) > 0 {
+		b.WriteString("\n## Types\n")
+	}
+	for _, model := range api.Models {
+		fmt.Fprintf(&b, "\n### %s\n", model.TypeName)
+		if model.Description != "" {
+			fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(model.Description))
+		}
+		if len(model.Extends) > 0 {
+			var bases []string
+			for _, base := range model.Extends {
+				bases = append(bases, docsCode(interfaceName(base)))
+			}
+			fmt.Fprintf(&b, "\nExtends %s.\n", strings.Join(bases, ", "))
+		}
+		if model.Alias != nil {
+			fmt.Fprintf(&b, "\nAlias of %s.\n", docsCode(tsType(*model.Alias)))
+			continue
+		}
+		if len(model.Fields) == 0 {
+			continue
+		}
+		b.WriteString("\n| Field | Type | Required | Description |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, field := range model.Fields {
+			required := ""
+			if field.Required {
+				required = "yes"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", docsCode(field.Name), docsCode(tsType(field.Type)), required, docsText(field.Description))
+		}
+	}
+
+	if len(api.Enums) > 0 {
+		b.WriteString("\n## Enums\n")
+	}
+	for _, enum := range api.Enums {
+		fmt.Fprintf(&b, "\n### %s\n", enum.TypeName)
+		if enum.Description != "" {
+			fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(enum.Description))
+		}
+		var values []string
+		for _, value := range enum.Values {
+			values = append(values, docsCode(value))
+		}
+		fmt.Fprintf(&b, "\nValues: %s.\n", strings.Join(values, ", "))
+	}
+	return []byte(b.String())
+}
+
+// docsCode 表格中的代码，| 需要转义，否则会被当作列的分隔
+func docsCode(s string) string {
+	return "`" + strings.ReplaceAll(s, "|", `\|`) + "`"
+}
+
+// docsText 表格中的文本，换行合并为空格
+func docsText(s string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(s), " "), "|", `\|`)
+}
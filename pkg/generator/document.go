@@ -0,0 +1,132 @@
+// document.go
+package generator
+
+import (
+	"bytes"
+
+	"gopkg.in/yaml.v3"
+)
+
+// indexedMappingSize 超过这个数量的键的映射节点（例如有数千个 schema 的 components/schemas）按索引查找，
+// 逐个比较会让解析每个 $ref 的开销随 schema 数量增长
+const indexedMappingSize = 64
+
+// parseDocument 将文档解析为 YAML 节点树，同一份内容只解析一次；返回的节点树是共享的，调用方不能修改
+func (r *run) parseDocument(data []byte) (*yaml.Node, error) {
+	if r.specDocument.doc != nil && bytes.Equal(r.specDocument.data, data) {
+		return r.specDocument.doc, nil
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	r.rememberDocument(data, &doc)
+	return &doc, nil
+}
+
+// rememberDocument 记录已经解析好的文档，例如 checkSingleDocument 解码出的第一个文档；合并键在这里展开
+func (r *run) rememberDocument(data []byte, doc *yaml.Node) {
+	expandMergeKeys(doc)
+	r.specDocument.data, r.specDocument.doc = data, doc
+}
+
+// expandMergeKeys 在节点树上展开 YAML 合并键（<<: *anchor 或 <<: [*a, *b]），校验、$ref 检查和解码都只看到普通的键：
+// 被合并映射的键值放在 << 所在的位置，映射中显式写出的键优先，列表中靠前的映射优先，与 YAML 1.1 的规则相同。
+// 别名指向的节点只展开一次，别名本身保持不变（见 resolveAlias），大量别名的文档不会因此成倍增长
+func expandMergeKeys(root *yaml.Node) {
+	done := make(map[*yaml.Node]bool)
+	var expand func(node *yaml.Node)
+	expand = func(node *yaml.Node) {
+		if node == nil || done[node] {
+			return
+		}
+		done[node] = true
+		if node.Kind == yaml.AliasNode {
+			expand(node.Alias)
+			return
+		}
+		for _, child := range node.Content {
+			expand(child)
+		}
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		explicit := make(map[string]bool)
+		merges := false
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if isMergeKey(node.Content[i]) {
+				merges = true
+			} else {
+				explicit[node.Content[i].Value] = true
+			}
+		}
+		if !merges {
+			return
+		}
+		var content []*yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if !isMergeKey(key) {
+				content = append(content, key, value)
+				continue
+			}
+			sources := []*yaml.Node{value}
+			if value = resolveAlias(value); value != nil && value.Kind == yaml.SequenceNode {
+				sources = value.Content
+			}
+			for _, source := range sources {
+				source = resolveAlias(source)
+				if source == nil || source.Kind != yaml.MappingNode {
+					continue
+				}
+				for j := 0; j+1 < len(source.Content); j += 2 {
+					if name := source.Content[j].Value; !explicit[name] {
+						explicit[name] = true
+						content = append(content, source.Content[j], source.Content[j+1])
+					}
+				}
+			}
+		}
+		node.Content = content
+	}
+	expand(root)
+}
+
+// isMergeKey 判断映射的键是否为合并键，带引号的 "<<" 是普通的键
+func isMergeKey(key *yaml.Node) bool {
+	return key.Kind == yaml.ScalarNode && key.Tag == "!!merge"
+}
+
+// mappingIndex 返回映射节点的键到值的索引，重复的键与逐个查找相同取第一个；节点树不会被修改，索引在生成结束时释放
+func (r *run) mappingIndex(node *yaml.Node) map[string]*yaml.Node {
+	if index, ok := r.specDocument.index[node]; ok {
+		return index
+	}
+	index := make(map[string]*yaml.Node, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if _, ok := index[node.Content[i].Value]; !ok {
+			index[node.Content[i].Value] = node.Content[i+1]
+		}
+	}
+	if r.specDocument.index == nil {
+		r.specDocument.index = make(map[*yaml.Node]map[string]*yaml.Node)
+	}
+	r.specDocument.index[node] = index
+	return index
+}
+
+// decodeDocument 从已解析的节点树解码到 out，与 yaml.Unmarshal 相同但不再解析文本
+func (r *run) decodeDocument(data []byte, out interface{}) error {
+	doc, err := r.parseDocument(data)
+	if err != nil || len(doc.Content) == 0 {
+		return err
+	}
+	return doc.Decode(out)
+}
+
+// decodeOpenAPI 与 ParseOpenAPI 相同，但复用已解析的节点树
+func (r *run) decodeOpenAPI(data []byte) (*OpenAPI, error) {
+	var api OpenAPI
+	err := r.decodeDocument(data, &api)
+	return &api, err
+}
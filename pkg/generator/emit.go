@@ -0,0 +1,202 @@
+// emit.go
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// outputExts 支持的扩展名，.d.ts 只生成类型声明
+var outputExts = []string{".ts", ".mts", ".cts", ".d.ts"}
+
+// compiledExts 源文件扩展名对应的编译产物扩展名，导入路径按它改写
+var compiledExts = map[string]string{".ts": ".js", ".mts": ".mjs", ".cts": ".cjs"}
+
+// validateOutputExt 校验 -ext 和 -emit-js 的组合
+func validateOutputExt(ext string, js bool) error {
+	if !contains(outputExts, ext) {
+		return fmt.Errorf("unsupported -ext %q, expected one of %s", ext, strings.Join(outputExts, ", "))
+	}
+	if ext == ".d.ts" && js {
+		return fmt.Errorf("-emit-js already emits .d.ts files, use -ext .ts, .mts or .cts to choose the module format")
+	}
+	return nil
+}
+
+// convertFiles 按 -ext、-emit-js 和 -import-style 转换生成的 .ts / .tsx 文件，其他文件（例如 JSON Schema）保持不变
+func (r *run) convertFiles(files Files) (Files, error) {
+	sourceExt := r.outputExt
+	if sourceExt == ".d.ts" {
+		sourceExt = ".ts"
+	}
+	compile := r.emitJS || r.outputExt == ".d.ts"
+	importExt := r.importExtFor(sourceExt, compile)
+
+	// 编译时和 -import-style 不是 source 时外部文件同样改写，只改扩展名时只改写生成的文件
+	var generated map[string]bool
+	if !compile && r.importStyle == "source" {
+		generated = make(map[string]bool)
+		for name := range files {
+			generated[name] = true
+		}
+	}
+
+	converted := make(Files)
+	sources := make(Files)
+	for name, data := range files {
+		original := name
+		ext := filepath.Ext(name)
+		if ext != ".ts" && ext != ".tsx" {
+			converted[name] = data
+			continue
+		}
+		if ext == ".ts" {
+			name = strings.TrimSuffix(name, ".ts") + sourceExt
+		}
+		data = rewriteImportExt(original, data, importExt, generated)
+		if compile {
+			sources[name] = data
+		} else {
+			converted[name] = data
+		}
+	}
+	if !compile || len(sources) == 0 {
+		return converted, nil
+	}
+
+	compiled, err := r.compileTypeScript(sources, r.outputExt == ".d.ts")
+	if err != nil {
+		return nil, err
+	}
+	for name, data := range compiled {
+		converted[name] = data
+	}
+	return converted, nil
+}
+
+// rewriteImportExt 将 filename 中相对导入路径的 .ts 扩展名改为 ext；
+// generated 不为 nil 时只改写指向其中文件的导入，外部提供的文件（例如 ../request.ts）保持不变
+func rewriteImportExt(filename string, data []byte, ext string, generated map[string]bool) []byte {
+	if ext == ".ts" {
+		return data
+	}
+	return rewriteImports(data, func(imp moduleImport) string {
+		specifier := imp.Specifier
+		if !isRelativeSpecifier(specifier) || !strings.HasSuffix(specifier, ".ts") {
+			return specifier
+		}
+		if generated != nil && !generated[path.Join(path.Dir(filename), specifier)] {
+			return specifier
+		}
+		return strings.TrimSuffix(specifier, ".ts") + ext
+	})
+}
+
+// compileTypeScript 在临时目录中用 tsc 编译 sources，返回对应的 .js 和 .d.ts 文件；
+// declarationOnly 时只生成 .d.ts。类型错误（例如未安装的第三方依赖）不影响输出，只记录警告
+func (r *run) compileTypeScript(sources Files, declarationOnly bool) (Files, error) {
+	tsc, err := r.findTSC()
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := os.MkdirTemp("", "moonbeam-tsc-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	srcDir, outDir := filepath.Join(tmp, "src"), filepath.Join(tmp, "out")
+
+	var inputs []string
+	nodeNext := false
+	for name, data := range sources {
+		filename := filepath.Join(srcDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(filename, data, 0644); err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, filename)
+		if ext := filepath.Ext(name); ext == ".mts" || ext == ".cts" {
+			nodeNext = true
+		}
+	}
+	sort.Strings(inputs)
+
+	// .mts / .cts 的模块格式由扩展名决定，需要 NodeNext；其他情况输出 ES 模块
+	module, resolution := "ESNext", "Bundler"
+	if nodeNext {
+		module, resolution = "NodeNext", "NodeNext"
+	}
+	args := []string{
+		"--declaration", "--outDir", outDir, "--rootDir", srcDir,
+		"--target", "ES2020", "--module", module, "--moduleResolution", resolution,
+		"--jsx", "react-jsx", "--skipLibCheck", "--pretty", "false",
+	}
+	if declarationOnly {
+		args = append(args, "--emitDeclarationOnly")
+	}
+	cmd := exec.Command(tsc, append(args, inputs...)...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	runErr := cmd.Run()
+	if _, ok := runErr.(*exec.ExitError); runErr != nil && !ok {
+		return nil, fmt.Errorf("run %s: %w", tsc, runErr)
+	}
+	if diagnostics := strings.TrimSpace(out.String()); diagnostics != "" {
+		r.logger.Warn("tsc reported diagnostics, output was still emitted", "count", strings.Count(diagnostics, "error TS"))
+		r.logger.Debug("tsc output", "output", diagnostics)
+	}
+
+	compiled := make(Files)
+	err = filepath.WalkDir(outDir, func(filename string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(outDir, filename)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		compiled[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil || len(compiled) == 0 {
+		return nil, fmt.Errorf("tsc emitted no files: %s", strings.TrimSpace(out.String()))
+	}
+	return compiled, nil
+}
+
+// findTSC 返回 -tsc 指定的编译器，否则从当前目录向上查找 node_modules/.bin/tsc，最后查找 PATH
+func (r *run) findTSC() (string, error) {
+	if r.tscPath != "" {
+		return r.tscPath, nil
+	}
+	if dir, err := os.Getwd(); err == nil {
+		for {
+			candidate := filepath.Join(dir, "node_modules", ".bin", "tsc")
+			if _, err := os.Stat(candidate); err == nil {
+				return candidate, nil
+			}
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				break
+			}
+			dir = parent
+		}
+	}
+	if path, err := exec.LookPath("tsc"); err == nil {
+		return path, nil
+	}
+	return "", fmt.Errorf("TypeScript compiler not found; install it with 'npm i -D typescript' or pass -tsc")
+}
@@ -0,0 +1,112 @@
+// extensions.go
+package generator
+
+import (
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// moonbeamPrefix 覆盖生成结果的扩展字段前缀
+const moonbeamPrefix = "x-moonbeam-"
+
+// 各位置支持的 x-moonbeam-* 扩展及其取值类型：str 为非空的单行字符串，bool 为布尔值
+var (
+	operationExtensions = map[string]string{"x-moonbeam-name": "str", "x-moonbeam-module": "str", "x-moonbeam-skip": "bool"}
+	schemaExtensions    = map[string]string{"x-moonbeam-type": "str", "x-moonbeam-skip": "bool"}
+)
+
+// extensions 检查 node 中的 x-moonbeam-* 扩展：取值类型错误时生成失败，拼错的名称记录警告，其他 x- 扩展不检查
+func (v *specValidator) extensions(node *yaml.Node, pointer string, allowed map[string]string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if !strings.HasPrefix(key, moonbeamPrefix) {
+			continue
+		}
+		value := resolveAlias(node.Content[i+1])
+		keyPointer := pointer + "/" + escapePointer(key)
+		switch allowed[key] {
+		case "str":
+			if value.Kind != yaml.ScalarNode || value.Tag != "!!str" || strings.TrimSpace(value.Value) == "" || strings.ContainsAny(value.Value, "\r\n") {
+				v.add(issueError, value, keyPointer, "%s must be a non-empty single-line string", key)
+			}
+		case "bool":
+			if value.Kind != yaml.ScalarNode || value.Tag != "!!bool" {
+				v.add(issueError, value, keyPointer, "%s must be true or false", key)
+			}
+		default:
+			var names []string
+			for name := range allowed {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			v.add(issueWarning, node.Content[i], keyPointer, "unknown extension %s is ignored here, expected one of %s", key, strings.Join(names, ", "))
+		}
+	}
+}
+
+// applySkips 删除 x-moonbeam-skip 的接口和字段；只被跳过的接口引用的 schema 随之删除，其他 schema 不受影响
+func (r *run) applySkips(api *OpenAPI) {
+	for name, schema := range api.Components.Schemas {
+		for key, prop := range schema.Properties {
+			if prop.MoonbeamSkip {
+				delete(schema.Properties, key)
+				r.logger.Debug("property skipped by x-moonbeam-skip", "schema", name, "property", key)
+			}
+		}
+	}
+
+	var skippedRoots, keptRoots []string
+	skipped := 0
+	for path, item := range api.Paths {
+		for _, entry := range []struct {
+			op     **Operation
+			method string
+		}{
+			{&item.Post, "POST"},
+			{&item.Get, "GET"},
+			{&item.Put, "PUT"},
+			{&item.Delete, "DELETE"},
+		} {
+			op := *entry.op
+			if op == nil {
+				continue
+			}
+			if !op.MoonbeamSkip {
+				keptRoots = append(keptRoots, operationRefs(op)...)
+				continue
+			}
+			skippedRoots = append(skippedRoots, operationRefs(op)...)
+			*entry.op = nil
+			skipped++
+			r.logger.Debug("operation skipped by x-moonbeam-skip", "operation", entry.method+" "+path)
+		}
+		if item.Get == nil && item.Post == nil && item.Put == nil && item.Delete == nil {
+			delete(api.Paths, path)
+		} else {
+			api.Paths[path] = item
+		}
+	}
+	if skipped == 0 {
+		return
+	}
+
+	// 跳过的接口引用的 schema 中，仍被其余接口或其他 schema 引用的保留；没有接口引用的 schema 原本就会生成
+	resolver := newSchemaResolver(api.Components.Schemas, r.logger)
+	fromSkipped := resolver.Closure(skippedRoots)
+	for name := range api.Components.Schemas {
+		if !fromSkipped[name] {
+			keptRoots = append(keptRoots, name)
+		}
+	}
+	kept := resolver.Closure(keptRoots)
+	removed := 0
+	for name := range fromSkipped {
+		if !kept[name] {
+			delete(api.Components.Schemas, name)
+			removed++
+		}
+	}
+	r.logger.Info("operations skipped by x-moonbeam-skip", "operations", skipped, "schemas", removed)
+}
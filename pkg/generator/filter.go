@@ -0,0 +1,173 @@
+// filter.go
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// OperationFilter 按 tag、路径和 operationId 筛选要生成的接口
+// 模式默认为 glob（* 不跨越 /，** 匹配任意字符），以 re: 开头时为正则表达式，均为完整匹配
+type OperationFilter struct {
+	includeTags, excludeTags             []*regexp.Regexp
+	includePaths, excludePaths           []*regexp.Regexp
+	includeOperations, excludeOperations []*regexp.Regexp
+}
+
+// NewOperationFilter 编译筛选模式，每个参数可以是逗号分隔的多个模式
+func NewOperationFilter(includeTags, excludeTags, includePaths, excludePaths, includeOperations, excludeOperations []string) (*OperationFilter, error) {
+	f := &OperationFilter{}
+	lists := []struct {
+		target   *[]*regexp.Regexp
+		patterns []string
+	}{
+		{&f.includeTags, includeTags},
+		{&f.excludeTags, excludeTags},
+		{&f.includePaths, includePaths},
+		{&f.excludePaths, excludePaths},
+		{&f.includeOperations, includeOperations},
+		{&f.excludeOperations, excludeOperations},
+	}
+	for _, list := range lists {
+		for _, value := range list.patterns {
+			for _, pattern := range strings.Split(value, ",") {
+				pattern = strings.TrimSpace(pattern)
+				if pattern == "" {
+					continue
+				}
+				re, err := compileFilterPattern(pattern)
+				if err != nil {
+					return nil, fmt.Errorf("invalid filter pattern %q: %w", pattern, err)
+				}
+				*list.target = append(*list.target, re)
+			}
+		}
+	}
+	return f, nil
+}
+
+// compileFilterPattern 将 glob 或 re: 正则编译为完整匹配的正则表达式
+func compileFilterPattern(pattern string) (*regexp.Regexp, error) {
+	if expr, ok := strings.CutPrefix(pattern, "re:"); ok {
+		return regexp.Compile("^(?:" + expr + ")$")
+	}
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// Active 是否配置了任何筛选条件
+func (f *OperationFilter) Active() bool {
+	return len(f.includeTags)+len(f.excludeTags)+len(f.includePaths)+len(f.excludePaths)+
+		len(f.includeOperations)+len(f.excludeOperations) > 0
+}
+
+// Match 判断接口是否保留：满足所有 include 条件（未配置视为满足）且不命中任何 exclude 条件
+func (f *OperationFilter) Match(path string, op *Operation) bool {
+	if len(f.includeTags) > 0 && !matchAny(f.includeTags, op.Tags...) {
+		return false
+	}
+	if len(f.includePaths) > 0 && !matchAny(f.includePaths, path) {
+		return false
+	}
+	if len(f.includeOperations) > 0 && !matchAny(f.includeOperations, op.OperationID) {
+		return false
+	}
+	return !matchAny(f.excludeTags, op.Tags...) &&
+		!matchAny(f.excludePaths, path) &&
+		!matchAny(f.excludeOperations, op.OperationID)
+}
+
+// matchAny 任一值匹配任一模式
+func matchAny(patterns []*regexp.Regexp, values ...string) bool {
+	for _, re := range patterns {
+		for _, value := range values {
+			if re.MatchString(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterSpec 删除未通过筛选的接口，并只保留剩余接口直接或间接引用的 schema
+func (r *run) filterSpec(api *OpenAPI, f *OperationFilter) {
+	if !f.Active() {
+		return
+	}
+	var roots []string
+	kept, total := 0, 0
+	for path, item := range api.Paths {
+		for _, op := range []**Operation{&item.Get, &item.Post, &item.Put, &item.Delete} {
+			if *op == nil {
+				continue
+			}
+			total++
+			if !f.Match(path, *op) {
+				*op = nil
+				continue
+			}
+			kept++
+			roots = append(roots, operationRefs(*op)...)
+		}
+		if item.Get == nil && item.Post == nil && item.Put == nil && item.Delete == nil {
+			delete(api.Paths, path)
+		} else {
+			api.Paths[path] = item
+		}
+	}
+
+	closure := newSchemaResolver(api.Components.Schemas, r.logger).Closure(roots)
+	for name := range api.Components.Schemas {
+		if !closure[name] {
+			delete(api.Components.Schemas, name)
+		}
+	}
+	if kept == 0 {
+		r.logger.Warn("filters matched no operations", "operations", total)
+		return
+	}
+	r.logger.Info("filters applied", "kept", kept, "operations", total, "schemas", len(api.Components.Schemas))
+}
+
+// operationRefs 返回接口请求体、响应和参数直接引用的 schema 名称
+func operationRefs(op *Operation) []string {
+	var refs []string
+	if op.RequestBody != nil {
+		for _, contentType := range sortedKeys(op.RequestBody.Content) {
+			refs = append(refs, op.RequestBody.Content[contentType].Schema.refs()...)
+		}
+	}
+	for _, code := range sortedKeys(op.Responses) {
+		resp := op.Responses[code]
+		for _, contentType := range sortedKeys(resp.Content) {
+			refs = append(refs, resp.Content[contentType].Schema.refs()...)
+		}
+	}
+	for _, param := range op.Parameters {
+		if param.Schema.Ref != "" {
+			refs = append(refs, cleanRef(param.Schema.Ref))
+		}
+		if items := param.Schema.Items; items != nil && items.RefValue != "" {
+			refs = append(refs, cleanRef(items.RefValue))
+		}
+	}
+	return refs
+}
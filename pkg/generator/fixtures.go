@@ -0,0 +1,150 @@
+// fixtures.go
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"text/template"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// ExampleData 接口响应示例，Value 为格式化后的 JSON
+type ExampleData struct {
+	Name  string // 示例名称
+	Key   string // 对象字面量中的键
+	Value string
+}
+
+// FixturesFileData 模块 fixtures.ts / mocks.ts 模板数据
+type FixturesFileData struct {
+	ModuleName string
+	Imports    []ImportData
+	Mocks      []string // 需要从 types/mocks.ts 导入的工厂函数
+	Fixtures   []string // 需要从 fixtures.ts 导入的示例常量
+	Operations []FunctionData
+}
+
+// responseExamples 将接口的 200 响应示例格式化为 JSON
+func (r *run) responseExamples(op ir.Operation) []ExampleData {
+	var examples []ExampleData
+	for _, example := range op.Examples {
+		encoded, err := json.MarshalIndent(example.Value, "  ", "  ")
+		if err != nil {
+			r.logger.Warn("skip example", "example", example.Name, "operation", op.ID, "err", err)
+			continue
+		}
+		examples = append(examples, ExampleData{Name: example.Name, Key: objectKey(example.Name), Value: string(encoded)})
+	}
+	return examples
+}
+
+// responseExampleValues 返回 200 响应的示例值，单个 example 命名为 default
+func responseExampleValues(op *Operation) map[string]interface{} {
+	resp, ok := op.Responses["200"]
+	if !ok {
+		return nil
+	}
+	var contentTypes []string
+	for contentType := range resp.Content {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+
+	values := make(map[string]interface{})
+	for _, contentType := range contentTypes {
+		content := resp.Content[contentType]
+		if content.Example != nil {
+			values["default"] = content.Example
+		}
+		for name, example := range content.Examples {
+			if example.Value != nil {
+				values[name] = example.Value
+			}
+		}
+		if len(values) > 0 {
+			break
+		}
+	}
+	return values
+}
+
+// renderFixtures 生成模块的 fixtures.ts，每个带响应示例的接口对应一个 xxxFixtures 常量
+func (r *run) renderFixtures(tmpl *template.Template, moduleDir string, mod *ModuleData, imports []ImportData) {
+	data := FixturesFileData{ModuleName: mod.Name}
+	usedTypes := make(map[string]bool)
+	for _, op := range mod.Operations {
+		if len(op.Examples) == 0 {
+			continue
+		}
+		for _, name := range op.ResponseRefs {
+			usedTypes[name] = true
+		}
+		data.Operations = append(data.Operations, op)
+	}
+	if len(data.Operations) == 0 {
+		return
+	}
+	data.Imports = filterImports(imports, usedTypes)
+	r.writeModuleFile(tmpl, filepath.Join(moduleDir, "fixtures.ts"), "fixtures", mod.Name, data)
+}
+
+// renderMockHandlers 生成模块的 mocks.ts，优先返回接口示例，没有示例时使用 types/mocks.ts 的工厂函数
+func (r *run) renderMockHandlers(tmpl *template.Template, moduleDir string, mod *ModuleData, imports []ImportData) {
+	data := FixturesFileData{ModuleName: mod.Name}
+	usedTypes := make(map[string]bool)
+	usedMocks := make(map[string]bool)
+	for _, op := range mod.Operations {
+		if op.EmptyResponse {
+			continue
+		}
+		for _, name := range op.ResponseRefs {
+			usedTypes[name] = true
+		}
+		if len(op.Examples) == 0 {
+			usedMocks["mock"+op.ResponseModel] = true
+		} else {
+			data.Fixtures = append(data.Fixtures, r.naming.Function(op.Name+"Fixtures"))
+		}
+		data.Operations = append(data.Operations, op)
+	}
+	if len(data.Operations) == 0 {
+		return
+	}
+	for name := range usedMocks {
+		data.Mocks = append(data.Mocks, name)
+	}
+	sort.Strings(data.Mocks)
+	data.Imports = filterImports(imports, usedTypes)
+	r.writeModuleFile(tmpl, filepath.Join(moduleDir, "mocks.ts"), "mock handlers", mod.Name, data)
+}
+
+// filterImports 只保留实际使用的类型导入
+func filterImports(imports []ImportData, usedTypes map[string]bool) []ImportData {
+	var result []ImportData
+	for _, imp := range imports {
+		var interfaces []string
+		for _, name := range imp.Interfaces {
+			if usedTypes[name] {
+				interfaces = append(interfaces, name)
+			}
+		}
+		if len(interfaces) > 0 {
+			result = append(result, ImportData{Module: imp.Module, Interfaces: interfaces})
+		}
+	}
+	return result
+}
+
+// writeModuleFile 渲染模板并写入模块目录下的文件
+func (r *run) writeModuleFile(tmpl *template.Template, filename, kind, moduleName string, data interface{}) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		r.logger.Error("template execution failed", "kind", kind, "module", moduleName, "err", err)
+		return
+	}
+	r.writeFile(filename, buf.Bytes())
+	r.logger.Debug("generate "+kind+" file", "file", filename)
+}
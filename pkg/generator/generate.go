@@ -0,0 +1,994 @@
+// generate.go
+package generator
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// clientTemplates 各客户端模式对应的运行时模板，默认模式使用外部提供的 request.ts
+var clientTemplates = map[string]string{
+	"":      "",
+	"axios": "templates/http-axios.tmpl",
+	"fetch": "templates/http-fetch.tmpl",
+}
+
+// generate 根据 OpenAPI 文档生成代码，文件通过 writeFile 写入 output，返回生成使用的中间表示；文档解析失败时返回错误
+func (r *run) generate(data []byte) (*ir.API, error) {
+	spec, err := r.parseSpec(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var paginationMatcher *PaginationMatcher
+	if r.pagination != "" {
+		paginationMatcher, err = ParsePaginationPattern(r.pagination)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 解析 schema 之间的引用关系，打破循环引用
+	resolver := newSchemaResolver(spec.Components.Schemas, r.logger)
+	api := r.buildIR(spec, resolver)
+	if r.provenance {
+		// 在并行渲染之前读取，渲染时只读
+		if r.specLines, err = r.collectSourceLines(data); err != nil {
+			return nil, err
+		}
+	}
+
+	// 加载模板
+	interfaceDefTmpl, err := r.parseTemplate("templates/interface-definition.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parse interface-definition template: %w", err)
+	}
+
+	interfaceTmpl, err := r.parseTemplate("templates/interface.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parse interface template: %w", err)
+	}
+
+	functionTmpl, err := r.parseTemplate("templates/function.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parse function template: %w", err)
+	}
+
+	fileTmpl, err := r.parseTemplate("templates/file.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parse file template: %w", err)
+	}
+
+	indexTmpl, err := r.parseTemplate("templates/index.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parse index template: %w", err)
+	}
+
+	var classTmpl *template.Template
+	if r.classes {
+		classTmpl, err = r.parseTemplate("templates/class.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("parse class template: %w", err)
+		}
+	}
+
+	var hooksTmpl *template.Template
+	if tmplName := hooksTemplates[r.hooks]; tmplName != "" {
+		hooksTmpl, err = r.parseTemplate(tmplName)
+		if err != nil {
+			return nil, fmt.Errorf("parse hooks template: %w", err)
+		}
+	}
+
+	fixturesTmpl, err := r.parseTemplate("templates/fixtures.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parse fixtures template: %w", err)
+	}
+
+	var mockHandlersTmpl *template.Template
+	if r.mocks {
+		mockHandlersTmpl, err = r.parseTemplate("templates/mock-handlers.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("parse mock handlers template: %w", err)
+		}
+	}
+
+	var contractSetupTmpl, contractTestTmpl *template.Template
+	if r.contract != "" {
+		contractSetupTmpl, err = r.parseTemplate("templates/contract-setup.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("parse contract setup template: %w", err)
+		}
+		contractTestTmpl, err = r.parseTemplate("templates/contract-test.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("parse contract test template: %w", err)
+		}
+	}
+
+	var unitTestTmpl *template.Template
+	if r.unitTests != "" {
+		unitTestTmpl, err = r.parseTemplate("templates/unit-test.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("parse unit test template: %w", err)
+		}
+	}
+
+	// 按模块组织数据
+	modules := make(map[string]*ModuleData)
+	interfaces := make(map[string]string)   // schema 名称 -> 接口代码
+	typeRefs := make(map[string][]string)   // schema 名称 -> 类型定义引用的模型和枚举
+	interfaceNames := make(map[string]bool) // 去除命名空间后的接口名称
+	enumTypes := make(map[string]bool)
+	for _, enum := range api.Enums {
+		enumTypes[enum.Name] = true
+	}
+
+	// -watch 时复用内容没有变化的接口定义和模块，见 Cache
+	var changedSchemas, reuse map[string]bool
+	var moduleDeps map[string]map[string]bool // 模块 -> （传递）引用的 schema
+	var namesChanged bool
+	if r.renderCache != nil {
+		changedSchemas, namesChanged = r.renderCache.prepare(api)
+	}
+
+	// 并行渲染所有接口定义，查询参数合成的请求类型单独渲染
+	rendered := renderParallel(r, StageModels, api.Models, func(model ir.Model) string {
+		if code, ok := r.renderCache.interfaceFor(model.Name); ok {
+			return code
+		}
+		if model.Synthetic {
+			return renderRequestInterface(model)
+		}
+		return r.renderInterface(model, interfaceDefTmpl)
+	})
+	synthetic := make(map[string]bool)
+	typeImports := make(map[string]map[string]map[string]bool) // schema 名称 -> -type-mapping 需要导入的模块和名称
+	for i, model := range api.Models {
+		if model.Synthetic {
+			synthetic[model.Name] = true
+		}
+		interfaces[model.Name] = rendered[i]
+		r.renderCache.storeInterface(model.Name, rendered[i])
+		typeRefs[model.Name] = modelRefs(model)
+		if imports := modelTypeImports(model); imports != nil {
+			typeImports[model.Name] = imports
+		}
+	}
+
+	// 识别分页响应，生成 Paginated<T> 泛型类型
+	if paginationMatcher != nil {
+		applyPagination(paginationMatcher, api.Models, interfaces, typeRefs)
+	}
+	for name := range interfaces {
+		interfaceNames[interfaceName(name)] = true
+	}
+
+	// 按模块收集接口，模块内按函数名排序
+	requestBodyTypes := make(map[string]bool) // 请求体引用的 schema 原始名称
+	typeUses := make(map[string][]string)     // 模块 -> 直接使用的类型
+	for _, op := range api.Operations {
+		if _, exists := modules[op.Module]; !exists {
+			modules[op.Module] = &ModuleData{Name: op.Module}
+		}
+		modules[op.Module].Operations = append(modules[op.Module].Operations, r.functionData(op))
+		if op.Request != nil && !synthetic[op.Request.Ref] {
+			requestBodyTypes[op.Request.Ref] = true
+		}
+		// 记录模块直接使用的类型，用于 -group-types
+		typeUses[op.Module] = append(typeUses[op.Module], op.Refs...)
+	}
+	// 并行渲染所有函数，按模块名称和函数名的顺序合并，模板错误也按这个顺序记录
+	for _, name := range sortedKeys(modules) {
+		mod := modules[name]
+		sort.SliceStable(mod.Operations, func(i, j int) bool {
+			return mod.Operations[i].FunctionName < mod.Operations[j].FunctionName
+		})
+	}
+	if r.renderCache != nil {
+		moduleDeps = make(map[string]map[string]bool, len(modules))
+		for name := range modules {
+			moduleDeps[name] = resolver.Closure(typeUses[name])
+		}
+		reuse = r.renderCache.reusableModules(modules, moduleDeps, changedSchemas, namesChanged)
+		r.logger.Debug("reuse unchanged modules", "modules", cachedModuleNames(reuse), "changed", len(changedSchemas))
+	}
+	var allOperations []FunctionData
+	for _, name := range sortedKeys(modules) {
+		if reuse[name] {
+			modules[name].Functions = r.renderCache.modules[name].functions
+			continue
+		}
+		allOperations = append(allOperations, modules[name].Operations...)
+	}
+	failed := make(map[string]bool) // 函数渲染出错的模块
+	functions := renderParallel(r, StageOperations, allOperations, func(data FunctionData) renderResult {
+		return renderFunction(data, functionTmpl)
+	})
+	for _, name := range sortedKeys(modules) {
+		mod := modules[name]
+		if reuse[name] {
+			continue
+		}
+		for _, fnData := range mod.Operations {
+			function := functions[0]
+			functions = functions[1:]
+			if function.err != nil {
+				failed[name] = true
+				r.logger.Error("failed to execute function template", "function", fnData.FunctionName, "err", function.err)
+			}
+			mod.Functions = append(mod.Functions, function.code)
+		}
+	}
+
+	// 首先生成所有接口文件，-group-types 时只被一个模块使用的类型拆分到 <模块>/types
+	var owners map[string]string
+	switch {
+	case !r.groupTypes && !r.groupEnums:
+	case r.groupTypesBy == "namespace":
+		owners = r.namespaceOwners(api)
+	default:
+		owners = typeOwners(typeUses, resolver)
+	}
+	// 类型文件 -> 其中的 schema 名称（已排序）；接口代码只保存在 interfaces 中，写入文件时取出
+	typeGroups := map[string][]string{"types": sortedKeys(interfaces)}
+	switch {
+	case r.layout == "split":
+		// 每个类型一个文件，见 writeModelFiles
+		typeGroups = nil
+	case r.groupTypes:
+		typeGroups = groupTypeNames(typeGroups["types"], owners)
+	}
+	var sharedNames []string
+	for _, name := range typeGroups["types"] {
+		sharedNames = append(sharedNames, interfaceName(name))
+	}
+	var groupExports []string
+	for moduleName, names := range typeGroups {
+		if moduleName != "types" && len(names) > 0 {
+			groupExports = append(groupExports, "../"+moduleName+"/index.ts")
+		}
+	}
+	sort.Strings(groupExports)
+
+	// types/index.ts 重新导出模块类型文件，模块类型文件再从它导入公共类型会形成循环导入，
+	// 这时公共类型移到 types/shared.ts，types/index.ts 只负责重新导出
+	shared := make(map[string][]string)
+	for _, moduleName := range sortedKeys(typeGroups) {
+		if moduleName != "types" {
+			if names := usedTypeNames(typeGroups[moduleName], typeRefs, sharedNames); len(names) > 0 {
+				shared[moduleName] = names
+			}
+		}
+	}
+	sharedFile, sharedFrom := "types", "../../types/index.ts"
+	if len(shared) > 0 {
+		sharedFile, sharedFrom = "types/shared", "../../types/shared.ts"
+		typeGroups[sharedFile] = typeGroups["types"]
+		typeGroups["types"] = nil
+		groupExports = append([]string{"./shared.ts"}, groupExports...)
+	}
+	// -group-types-by namespace 时模块类型文件之间可以互相引用，schema 名称 -> 所在的模块类型文件
+	groupFiles := make(map[string]string)
+	for moduleName, names := range typeGroups {
+		if moduleName != "types" && moduleName != sharedFile {
+			for _, name := range names {
+				groupFiles[name] = moduleName + "/index.ts"
+			}
+		}
+	}
+
+	for _, moduleName := range sortedKeys(typeGroups) {
+		sortedNames := typeGroups[moduleName]
+		if len(sortedNames) == 0 && (moduleName != "types" || len(groupExports) == 0) {
+			continue
+		}
+
+		// 接口代码从 interfaces 移到这个文件的数据中，文件写入后即可释放，不会在多个 map 中同时保留；
+		// 不直接写入磁盘：Generate 只返回文件内容，文件开头的哈希、合并为单个文件和扩展名转换都需要完整的文件
+		fileInterfaces := make(map[string]string, len(sortedNames))
+		size := 0
+		for _, name := range sortedNames {
+			fileInterfaces[name] = interfaces[name]
+			size += len(interfaces[name]) + 1
+			delete(interfaces, name)
+		}
+
+		// 生成接口文件
+		usedEnums := enumImports(sortedNames, typeRefs, enumTypes)
+		interfaceData := InterfaceFileData{
+			ModuleName:  moduleName,
+			Interfaces:  fileInterfaces,
+			UsedEnums:   usedEnums,
+			EnumFrom:    "./enum.ts",
+			SortedNames: sortedNames,
+		}
+		switch moduleName {
+		case "types":
+			interfaceData.Exports = groupExports
+		case sharedFile:
+		default:
+			interfaceData.EnumFrom = "../../types/enum.ts"
+			interfaceData.Shared = shared[moduleName]
+			interfaceData.SharedFrom = sharedFrom
+		}
+
+		filename := filepath.Join(moduleName, "index.ts")
+		if moduleName == sharedFile && moduleName != "types" {
+			filename = sharedFile + ".ts"
+		}
+		interfaceData.TypeImports = typeImportsFor(filepath.ToSlash(filename), sortedNames, typeImports)
+		interfaceData.TypeImports = append(interfaceData.TypeImports, groupTypeImports(filepath.ToSlash(filename), sortedNames, typeRefs, groupFiles)...)
+
+		// 预先分配文件的大小，大型文档的类型文件不会在渲染中反复扩容复制
+		var buf bytes.Buffer
+		buf.Grow(size + 4096)
+		if err := interfaceTmpl.Execute(&buf, interfaceData); err != nil {
+			r.logger.Error("interface template execution failed", "module", moduleName, "err", err)
+			continue
+		}
+		r.writeFile(filename, buf.Bytes())
+		r.logger.Debug("generate interface file", "file", filename)
+	}
+
+	// 生成枚举文件，-group-enums 时只被一个模块使用的枚举生成到 <模块>/enum.ts，由 types/enum.ts 重新导出
+	if len(api.Enums) > 0 && r.layout != "split" {
+		enumGroups := make(map[string][]EnumData)
+		for _, enum := range api.Enums {
+			dir := "types"
+			if owner, ok := owners[enum.Name]; ok && r.groupEnums {
+				dir = owner
+			}
+			enumGroups[dir] = append(enumGroups[dir], newEnumData(enum))
+		}
+		var enumExports []string
+		for _, dir := range sortedKeys(enumGroups) {
+			if dir != "types" {
+				enumExports = append(enumExports, "../"+dir+"/enum.ts")
+			}
+		}
+		if _, ok := enumGroups["types"]; !ok {
+			enumGroups["types"] = nil
+		}
+
+		enumFileTmpl, err := r.parseTemplate("templates/enum-file.tmpl")
+		for _, dir := range sortedKeys(enumGroups) {
+			if err != nil {
+				break
+			}
+			enumFileData := EnumFileData{Enums: enumGroups[dir]}
+			if dir == "types" {
+				enumFileData.Exports = enumExports
+			}
+			var buf bytes.Buffer
+			if err = enumFileTmpl.Execute(&buf, enumFileData); err == nil {
+				filename := filepath.Join(dir, "enum.ts")
+				r.writeFile(filename, buf.Bytes())
+				r.logger.Debug("generate enum file", "file", filename)
+			}
+		}
+	}
+
+	if r.layout == "split" {
+		r.writeModelFiles(interfaceTmpl, api, interfaces, typeRefs, typeImports)
+	}
+
+	// 生成按文档命名空间访问类型的别名
+	var namespaces bool
+	if r.naming.Namespaces == "namespace" {
+		namespaces = r.writeNamespaces(api)
+	}
+
+	// 生成运行时校验 schema
+	if emitter, ok := validatorEmitters[r.validators]; ok {
+		filename := filepath.Join("types", "schemas.ts")
+		r.writeFile(filename, []byte(renderValidators(emitter, api, nil)))
+		r.logger.Debug("generate schema file", "file", filename)
+	}
+
+	// 生成请求类型的表单校验 schema，只包含请求体及其引用的类型
+	if r.forms == "yup" {
+		var roots []string
+		for name := range requestBodyTypes {
+			roots = append(roots, name)
+		}
+		filename := filepath.Join("types", "forms.ts")
+		r.writeFile(filename, []byte(renderValidators(newYupEmitter(api, resolver.Closure(roots)), api, resolver.Closure(roots))))
+		r.logger.Debug("generate form file", "file", filename)
+	}
+
+	// 生成模拟数据工厂
+	if r.mocks {
+		filename := filepath.Join("types", "mocks.ts")
+		r.writeFile(filename, []byte(renderValidators(mockEmitter{}, api, nil)))
+		r.logger.Debug("generate mock file", "file", filename)
+	}
+
+	// 生成 JSON Schema 文件
+	if r.jsonSchema != "" {
+		r.writeJSONSchemas(data, r.jsonSchema)
+	}
+
+	// 并行渲染每个模块的API文件，再按模块名称顺序写入，日志和警告的顺序在多次运行间保持一致
+	var moduleNames []string
+	for _, name := range sortedKeys(modules) {
+		if reuse[name] {
+			for filename, data := range r.renderCache.modules[name].files {
+				r.writeFile(filename, data)
+			}
+			continue
+		}
+		if len(modules[name].Functions) > 0 {
+			moduleNames = append(moduleNames, name)
+		}
+	}
+	moduleFiles := renderParallel(r, StageModules, moduleNames, func(name string) renderResult {
+		mod := modules[name]
+		// 准备文件数据，包含导入语句
+		fileData := FileData{
+			ModuleName: name,
+			Functions:  mod.Functions,
+			Imports:    generateImports(mod.Operations, interfaceNames),
+			Parsers:    responseParsers(mod.Operations),
+			Runtime:    runtimeImports(mod.Operations),
+		}
+		var buf bytes.Buffer
+		err := fileTmpl.Execute(&buf, fileData)
+		return renderResult{code: buf.String(), imports: fileData.Imports, err: err}
+	})
+	for i, name := range moduleNames {
+		mod := modules[name]
+		moduleDir := name
+		if moduleFiles[i].err != nil {
+			r.logger.Error("template execution failed", "module", name, "err", moduleFiles[i].err)
+			continue
+		}
+
+		before := r.problems.errors.Load()
+		files := r.recordFiles(func() {
+			filename := filepath.Join(moduleDir, "index.ts")
+			r.writeFile(filename, []byte(moduleFiles[i].code))
+			r.logger.Debug("generate module file", "file", filename)
+			imports := moduleFiles[i].imports
+
+			// 生成模块的 API 类文件
+			if classTmpl != nil {
+				r.renderClass(classTmpl, moduleDir, mod, imports)
+			}
+
+			// 生成模块的 hooks 文件
+			if hooksTmpl != nil {
+				r.renderHooks(r.hooks, hooksTmpl, moduleDir, mod, imports)
+			}
+
+			// 生成模块的响应示例和模拟响应
+			r.renderFixtures(fixturesTmpl, moduleDir, mod, imports)
+			if mockHandlersTmpl != nil {
+				r.renderMockHandlers(mockHandlersTmpl, moduleDir, mod, imports)
+			}
+		})
+		// 渲染出错的模块不缓存，下次重新渲染并再次报告错误
+		if r.renderCache != nil && !failed[name] && r.problems.errors.Load() == before {
+			r.renderCache.storeModule(name, mod, moduleDeps[name], files)
+		}
+	}
+
+	// 生成契约测试
+	if r.contract != "" {
+		r.renderContractTests(r.contract, contractSetupTmpl, contractTestTmpl, modules)
+	}
+
+	// 生成单元测试脚手架
+	if r.unitTests != "" {
+		r.renderUnitTests(r.unitTests, unitTestTmpl, modules)
+	}
+
+	// 生成根目录的index.ts文件
+	rootIndexData := RootIndexData{
+		Modules:    modules,
+		Client:     r.client,
+		Classes:    r.classes,
+		Validators: r.validators,
+		Forms:      r.forms,
+		Validate:   r.validate,
+		Namespaces: namespaces,
+		Server:     r.serverData(data),
+	}
+
+	var buf bytes.Buffer
+	err = indexTmpl.Execute(&buf, rootIndexData)
+	if err != nil {
+		r.logger.Error("root index template execution failed", "err", err)
+	} else {
+		r.writeFile("index.ts", buf.Bytes())
+		r.logger.Debug("generate root index file", "file", "index.ts")
+	}
+
+	// 生成请求钩子运行时和客户端运行时文件
+	r.renderRuntimeFile("templates/runtime.tmpl", "runtime.ts", rootIndexData)
+	r.renderRuntimeFile("templates/config.tmpl", "config.ts", rootIndexData)
+	if tmplName := clientTemplates[r.client]; tmplName != "" {
+		r.renderRuntimeFile(tmplName, "http.ts", rootIndexData)
+	}
+	return api, nil
+}
+
+// writeJSONSchemas 写出 JSON Schema 文件
+func (r *run) writeJSONSchemas(data []byte, mode string) {
+	files, err := r.renderJSONSchemas(data, mode)
+	if err != nil {
+		r.logger.Error("generate json schema failed", "err", err)
+		return
+	}
+
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		filename := "schemas.json"
+		if name != "" {
+			filename = filepath.Join("schemas", name+".json")
+		}
+		r.writeFile(filename, files[name])
+		r.logger.Debug("generate json schema", "file", filename)
+	}
+}
+
+// renderRuntimeFile 生成根目录下的运行时文件，例如 runtime.ts、http.ts
+func (r *run) renderRuntimeFile(tmplName, name string, data RootIndexData) {
+	runtimeTmpl, err := r.parseTemplate(tmplName)
+	if err != nil {
+		r.logger.Error("failed to parse runtime template", "template", tmplName, "err", err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := runtimeTmpl.Execute(&buf, data); err != nil {
+		r.logger.Error("runtime template execution failed", "template", tmplName, "err", err)
+		return
+	}
+
+	r.writeFile(name, buf.Bytes())
+	r.logger.Debug("generate runtime file", "file", name)
+}
+
+type ModuleData struct {
+	Name       string
+	Interfaces []string
+	Functions  []string
+	Operations []FunctionData
+}
+
+type FunctionData struct {
+	Summary       string
+	FunctionName  string        // 函数名，与保留字冲突时加下划线，例如 delete_
+	Name          string        // 未加下划线的函数名，用于派生 deleteFixtures、useDeleteQuery 等名称
+	Args          []FunctionArg // 请求体之外的路径参数，在 params 之前传入
+	ParamType     string        // 参数类型，例如 CreateUserRequest、User[]
+	ResponseType  string        // 响应类型
+	ParamRefs     []string      // 参数类型引用的接口名称，用于计算导入
+	ResponseRefs  []string      // 响应类型引用的接口名称
+	ResponseModel string        // 响应的模型，数组响应为元素的模型，用于 parseXxx 和 mockXxx
+	ResponseArray bool          // 响应是模型数组
+	EmptyResponse bool          // 没有响应体，ResponseType 为 void
+	Method        string
+	Path          string
+	URL           string        // 请求地址表达式：'/users/{id}'，请求体接口为 expandPath('/users/{id}', { id })，请求体原样发送
+	Examples      []ExampleData // 200 响应的示例，按名称排序
+	Validate      bool          // 是否校验响应结构
+	Source        string        // 接口在文档中的位置，-provenance 时生成到 JSDoc 的 @see
+}
+
+// EnumFileData 枚举文件的模板数据，Exports 为 types/enum.ts 重新导出的模块枚举文件
+type EnumFileData struct {
+	Enums   []EnumData
+	Exports []string
+}
+
+type EnumData struct {
+	SchemaName string
+	TypeName   string
+	EnumValues []string
+	Members    []EnumMember
+}
+
+// newEnumData 枚举模板数据
+func newEnumData(enum ir.Enum) EnumData {
+	data := EnumData{SchemaName: enum.Name, TypeName: enum.TypeName, EnumValues: enum.Values}
+	for _, member := range enum.Members {
+		data.Members = append(data.Members, EnumMember{Key: enumMemberKey(member.Key), Value: singleQuoteEscaper.Replace(member.Value)})
+	}
+	return data
+}
+
+// EnumMember 枚举成员，Key 按 -enum-case 转换，不是合法标识符时加引号；Value 为转义后可直接放入单引号的原始值
+type EnumMember struct {
+	Key   string
+	Value string
+}
+
+type InterfaceFileData struct {
+	ModuleName  string
+	Interfaces  map[string]string
+	UsedEnums   []string
+	EnumFrom    string       // 枚举文件的相对路径
+	Shared      []string     // 模块类型文件引用的公共类型
+	SharedFrom  string       // 公共类型所在文件的相对路径
+	Exports     []string     // 公共类型文件重新导出的模块类型文件
+	TypeImports []typeImport // -type-mapping 映射的类型
+	SortedNames []string
+}
+
+type FileData struct {
+	ModuleName string
+	Functions  []string
+	Imports    []ImportData
+	Parsers    []string // 响应校验使用的 parseXxx
+	Runtime    []string // 从 runtime.ts 导入的函数
+}
+
+type ImportData struct {
+	Module     string
+	Interfaces []string
+}
+
+type RootIndexData struct {
+	Modules    map[string]*ModuleData
+	Client     string
+	Classes    bool
+	Validators string
+	Forms      string
+	Validate   string
+	Namespaces bool        // 生成了 types/namespaces.ts
+	Server     *ServerData // 第一个服务器的地址变量，为空时不生成 buildBaseUrl
+}
+
+type ProcessedProperty struct {
+	Key         string // 属性名，不是合法标识符时加引号
+	Field       ir.Field
+	TypeName    string
+	IsRequired  bool
+	Constraints []string
+}
+
+// renderInterface 使用 interface-definition 模板渲染组件 schema 对应的接口或类型别名
+func (r *run) renderInterface(model ir.Model, tmpl *template.Template) string {
+	processedProperties := make(map[string]ProcessedProperty)
+	for _, field := range model.Fields {
+		processedProperties[field.Name] = ProcessedProperty{
+			Key:         objectKey(field.Name),
+			Field:       field,
+			TypeName:    tsType(field.Type),
+			IsRequired:  field.Required,
+			Constraints: jsDocTags(field.Type),
+		}
+	}
+
+	// allOf 继承的基类（循环继承已被解析器打破）
+	var bases []string
+	for _, base := range model.Extends {
+		bases = append(bases, interfaceName(base))
+	}
+	extends := ""
+	if len(bases) > 0 {
+		extends = " extends " + strings.Join(bases, ", ")
+	}
+
+	alias := ""
+	if model.Alias != nil {
+		alias = tsType(*model.Alias)
+	}
+
+	data := struct {
+		SchemaName string
+		TypeName   string
+		Alias      string
+		Extends    string
+		Properties map[string]ProcessedProperty
+		Source     string // schema 在文档中的位置，见 schemaSource
+	}{
+		SchemaName: model.Name,
+		TypeName:   model.TypeName,
+		Alias:      alias,
+		Extends:    extends,
+		Properties: processedProperties,
+		Source:     r.schemaSource(model.Name),
+	}
+	var buf bytes.Buffer
+	tmpl.Execute(&buf, data)
+	return buf.String()
+}
+
+// renderRequestInterface 渲染查询参数合成的请求类型，字段按参数顺序
+func renderRequestInterface(model ir.Model) string {
+	var b strings.Builder
+	// 与 interface-definition 模板一致，以空行开头
+	fmt.Fprintf(&b, "\n/**\n * %s\n */\nexport interface %s {\n", model.TypeName, model.TypeName)
+	for _, field := range model.Fields {
+		// 描述与校验约束一起生成到 JSDoc 中
+		var docLines []string
+		if field.Description != "" {
+			docLines = append(docLines, field.Description)
+		}
+		docLines = append(docLines, jsDocTags(field.Type)...)
+
+		b.WriteString("  ")
+		if len(docLines) > 0 {
+			b.WriteString("/**\n")
+			for _, line := range docLines {
+				fmt.Fprintf(&b, "   * %s\n", line)
+			}
+			b.WriteString("   */\n  ")
+		}
+		optional := "?"
+		if field.Required {
+			optional = ""
+		}
+		fmt.Fprintf(&b, "%s%s: %s\n", objectKey(field.Name), optional, tsType(field.Type))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// emptyRequestType、emptyReplyType 没有参数和响应体的接口在签名中使用的类型，都是内置类型，不需要声明和导入
+const (
+	emptyRequestType = "Record<string, never>"
+	emptyReplyType   = "void"
+)
+
+// functionData 接口函数的模板数据，没有参数和响应时分别使用 emptyRequestType 和 emptyReplyType
+func (r *run) functionData(op ir.Operation) FunctionData {
+	data := FunctionData{
+		Summary:       op.Summary,
+		FunctionName:  sanitizeIdentifier(op.Name),
+		Name:          op.Name,
+		ParamType:     emptyRequestType,
+		ResponseType:  emptyReplyType,
+		EmptyResponse: op.Response == nil,
+		Method:        op.Method,
+		Path:          op.Path,
+		Examples:      r.responseExamples(op),
+		Source:        r.operationSource(op.Method, op.Path),
+	}
+	// 请求体和响应类型只支持 $ref 和 $ref 的数组，统一去除命名空间前缀
+	if op.Request != nil {
+		data.ParamType = signatureType(*op.Request)
+		data.ParamRefs = signatureRefs(*op.Request)
+	}
+	data.Args, data.URL = functionURL(op)
+	data.ResponseModel = data.ResponseType
+	if op.Response != nil {
+		data.ResponseType = signatureType(*op.Response)
+		data.ResponseRefs = signatureRefs(*op.Response)
+		data.ResponseArray = op.Response.Kind == ir.Array
+		data.ResponseModel = interfaceName(op.Response.Refs()[0])
+		// 数组响应没有对应的 parseXxx，不校验
+		data.Validate = r.validate != "" && !data.ResponseArray
+	}
+	return data
+}
+
+// FunctionArg 接口函数在 params 之前的路径参数
+type FunctionArg struct {
+	Key    string // 路径中的变量名称，例如 team_id
+	Name   string // 参数名称，例如 teamId
+	Type   string
+	Sample string // 单元测试中的参数值，例如 1、'teamId'
+}
+
+// functionURL 请求体之外的路径参数和请求地址表达式。合成的请求类型包含全部路径参数，由运行时替换后从参数中去除；
+// 有请求体的接口在函数中替换路径参数：请求体没有的作为函数参数传入，POST/PUT 请求体声明的从中读取但不去除，请求体原样发送；
+// GET/DELETE 的参数作为查询参数，请求体声明的路径参数仍由运行时替换并去除
+func functionURL(op ir.Operation) ([]FunctionArg, string) {
+	literal := "'" + op.Path + "'"
+	if !op.Body {
+		return nil, literal
+	}
+	names := uniqueNames{"params": true, "options": true}
+	args := make(map[string]string)
+	var result []FunctionArg
+	for _, field := range op.PathParams {
+		arg := FunctionArg{Key: field.Name, Name: names.unique(sanitizeIdentifier(camelCase(field.Name))), Type: tsType(field.Type)}
+		switch {
+		case field.Type.Kind == "integer" || field.Type.Kind == "number":
+			arg.Sample = "1"
+		case field.Type.Kind == "string" && field.Type.Ref == "" && field.Type.TSType == "":
+			arg.Sample = "'" + arg.Name + "'"
+		default:
+			arg.Sample = "'" + arg.Name + "' as unknown as " + arg.Type
+		}
+		args[field.Name] = arg.Name
+		result = append(result, arg)
+	}
+	query := op.Method == "GET" || op.Method == "DELETE"
+	var values []string
+	seen := make(map[string]bool)
+	for _, match := range pathTemplatePattern.FindAllStringSubmatch(op.Path, -1) {
+		name := match[1]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		key := objectKey(name)
+		switch arg, ok := args[name]; {
+		case ok && arg == key:
+			values = append(values, key)
+		case ok:
+			values = append(values, key+": "+arg)
+		case query:
+		case key == name:
+			values = append(values, key+": params."+name)
+		default:
+			values = append(values, key+": params["+key+"]")
+		}
+	}
+	if len(values) == 0 {
+		return result, literal
+	}
+	return result, "expandPath(" + literal + ", { " + strings.Join(values, ", ") + " })"
+}
+
+// ArgDecls 函数签名中 params 之前的路径参数，例如 "teamId: number, "
+func (d FunctionData) ArgDecls() string {
+	var b strings.Builder
+	for _, arg := range d.Args {
+		b.WriteString(arg.Name + ": " + arg.Type + ", ")
+	}
+	return b.String()
+}
+
+// ArgNames 调用函数时 params 之前的路径参数，例如 "teamId, "
+func (d FunctionData) ArgNames() string {
+	var b strings.Builder
+	for _, arg := range d.Args {
+		b.WriteString(arg.Name + ", ")
+	}
+	return b.String()
+}
+
+// ExpandsPath 函数是否用 expandPath 替换路径参数
+func (d FunctionData) ExpandsPath() bool {
+	return strings.HasPrefix(d.URL, "expandPath(")
+}
+
+// SampleURL 单元测试中用 Sample 调用函数时请求的地址，请求体声明的路径参数为空，保留 {name}
+func (d FunctionData) SampleURL() string {
+	path := d.Path
+	for _, arg := range d.Args {
+		value := arg.Name
+		if arg.Sample == "1" {
+			value = "1"
+		}
+		path = strings.ReplaceAll(path, "{"+arg.Key+"}", url.QueryEscape(value))
+	}
+	return path
+}
+
+// signatureType 函数签名中请求体或响应的类型，例如 User、User[]
+func signatureType(t ir.Type) string {
+	if t.Kind == ir.Array && t.Items != nil {
+		return signatureType(*t.Items) + "[]"
+	}
+	return interfaceName(t.Ref)
+}
+
+// signatureRefs 类型引用的接口名称，去除命名空间前缀
+func signatureRefs(t ir.Type) []string {
+	var names []string
+	for _, ref := range t.Refs() {
+		names = append(names, interfaceName(ref))
+	}
+	return names
+}
+
+// renderResult 并行渲染的结果，错误由调用方按顺序记录
+type renderResult struct {
+	code    string
+	imports []ImportData // 模块文件的类型导入，API 类和 hooks 等文件复用
+	err     error
+}
+
+// renderFunction 渲染一个接口函数，可以在 renderParallel 中调用
+func renderFunction(data FunctionData, tmpl *template.Template) renderResult {
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, data)
+	return renderResult{code: buf.String(), err: err}
+}
+
+// runtimeImports 接口函数使用的 runtime.ts 中的函数：替换路径参数的 expandPath 和校验响应的 validateResponse
+func runtimeImports(operations []FunctionData) []string {
+	var expand, validate bool
+	for _, op := range operations {
+		expand = expand || op.ExpandsPath()
+		validate = validate || op.Validate
+	}
+	var names []string
+	if expand {
+		names = append(names, "expandPath")
+	}
+	if validate {
+		names = append(names, "validateResponse")
+	}
+	return names
+}
+
+// responseParsers 返回需要校验响应的接口所使用的 parseXxx，已排序去重
+func responseParsers(operations []FunctionData) []string {
+	seen := make(map[string]bool)
+	var parsers []string
+	for _, op := range operations {
+		name := "parse" + op.ResponseType
+		if op.Validate && !seen[name] {
+			seen[name] = true
+			parsers = append(parsers, name)
+		}
+	}
+	sort.Strings(parsers)
+	return parsers
+}
+
+func toCamel(s string) string {
+	parts := strings.Split(s, "_")
+	for i, p := range parts {
+		if i == 0 {
+			continue
+		}
+		if len(p) > 0 {
+			parts[i] = strings.ToUpper(p[:1]) + strings.ToLower(p[1:])
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// generateImports 模块文件需要从公共 types 模块导入的接口：函数的参数和响应类型
+func generateImports(operations []FunctionData, interfaceNames map[string]bool) []ImportData {
+	used := make(map[string]bool)
+	var names []string
+	for _, op := range operations {
+		for _, name := range append(append([]string(nil), op.ParamRefs...), op.ResponseRefs...) {
+			if interfaceNames[name] && !used[name] {
+				used[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+	return []ImportData{{Module: "types", Interfaces: names}}
+}
+
+// enumImports 接口文件中的类型定义引用的枚举，按名称排序
+func enumImports(names []string, typeRefs map[string][]string, enumTypes map[string]bool) []string {
+	used := make(map[string]bool)
+	var result []string
+	for _, name := range names {
+		for _, ref := range typeRefs[name] {
+			if enumTypes[ref] && !used[ref] {
+				used[ref] = true
+				result = append(result, ref)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// requestTypeName 查询参数合成的请求类型名称，baseName 为 operationBaseName 推导的操作名称
+func (r *run) requestTypeName(baseName string) string {
+	return r.naming.Type(baseName + "Request")
+}
@@ -0,0 +1,486 @@
+// generator.go
+
+// Package generator 根据 OpenAPI 文档（或带 google.api.http 注解的 proto 定义）在内存中生成 TypeScript（或 Go）客户端代码，是 moonbeam 命令行工具的核心，
+// 可以嵌入到其他构建工具中：
+//
+//	files, err := generator.New(generator.Options{Client: "fetch"}).Generate(spec)
+//
+// 文档先转换为 ir 包定义的中间表示，所有输出都由中间表示生成；Generator.IR 返回中间表示本身。
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// 目标语言
+const (
+	LangTypeScript = "typescript"
+	LangGo         = "go"
+	LangPython     = "python"
+	LangDart       = "dart"
+)
+
+// Options 生成选项，与命令行参数一一对应；零值生成与命令行默认参数相同的代码
+type Options struct {
+	Source string // 文档来源（文件路径或 URL），写入生成文件的头部注释
+	Lang   string // 目标语言：typescript（默认）、go、python、dart
+
+	InputFormat string   // openapi、proto、descriptor-set、postman，为空时按 Source 的扩展名和内容判断
+	ProtoPaths  []string // 查找 .proto import 的目录，相当于 protoc -I
+	BundleRefs  bool     // 合并 $ref 引用的其他本地文件，相对路径相对于 Source；会读取本地文件，不应对上传的文档开启
+
+	Client            string // 客户端运行时：空（外部 request.ts）、axios、fetch
+	Hooks             string // react-query、swr
+	Classes           bool   // 每个模块额外生成 API 类
+	Validators        string // zod、io-ts
+	Forms             string // yup
+	JSONSchema        string // split、bundle
+	Mocks             bool   // 生成 types/mocks.ts
+	ContractTests     string // vitest、jest，需要 Client 和 Validators
+	UnitTests         string // vitest、jest，模拟 request 实例的单元测试脚手架
+	ValidateResponses string // warn、throw，需要 Validators
+	Pagination        string // 分页响应匹配规则 '<items regex>:<meta regex>'
+
+	IncludeTags, ExcludeTags             []string
+	IncludePaths, ExcludePaths           []string
+	IncludeOperations, ExcludeOperations []string
+
+	OperationName string // strip-tag（默认）、last、full 或模板
+	GroupBy       string // tag（默认）、path-prefix、x-module、operation-prefix
+	GroupTypes    bool
+	GroupEnums    bool
+	GroupTypesBy  string // -group-types 和 -group-enums 的分组方式：usage（默认）只被一个模块使用的类型，namespace 按 schema 名称的命名空间
+	Layout        string // 目录结构：modules（默认）每个模块一个目录，split 生成 models/<类型>.ts 和 api/<模块>.ts
+	APIVersions   string // 路径中的版本号：mixed（默认）不区分，suffix 函数名加版本后缀，split 每个版本一个目录
+	Naming        NamingConvention
+	Provenance    bool     // 在函数和接口的 JSDoc 中用 @see 标注它们在文档中的位置（方法和路径或 schema，以及行号）
+	TypeMappings  []string // 按 type/format 替换 TypeScript 类型：<type>[/<format>]=<类型>[ from <模块>]，例如 string/decimal=Big from big.js
+	ImportAliases []string // 跨目录导入使用的路径别名：<别名> 对应输出目录，<目录>=<别名> 对应其中一个顶层目录，例如 @/api
+
+	Strict           bool     // 存在无法解析的 $ref 等文档警告时生成失败，而不是只记录警告
+	WarningsAsErrors bool     // 生成过程中有任何警告时 Generate 返回错误
+	Diagnostics      []string // 诊断规则的级别：<规则>=ignore|warn|error，例如 missing-operation-id=error
+
+	SingleFile  string // 非空时合并为这一个文件
+	Ext         string // .ts（默认）、.mts、.cts、.d.ts
+	EmitJS      bool   // 用 tsc 编译为 .js + .d.ts
+	ImportStyle string // 相对导入的写法：source（默认，生成文件的扩展名）、js、none、directory
+	TSC         string // TypeScript 编译器路径
+
+	GoPackage string // -lang go 生成的包名，默认 api
+
+	PackageName    string // 非空时额外生成 npm 包的 package.json 和 tsconfig.json，例如 @acme/api-client
+	PackageVersion string // 包的版本，为空时使用文档的 info.version
+	Workspace      bool   // 每个模块生成一个 npm workspace 包（packages/<模块>-api），共用 packages/api-common，需要 PackageName
+
+	Plugins       []string      // 外部插件 name[:parameter]，按顺序运行，见 PluginRequest
+	PluginTimeout time.Duration // 单个插件的运行时间上限，0 时为 defaultPluginTimeout，超时后结束插件进程
+
+	TemplateDir string       // 覆盖内置模板的目录
+	Header      string       // 版权或许可声明，加在每个生成文件的开头（头部注释之后），纯文本会逐行加上注释符号
+	Logger      *slog.Logger // 为空时使用 slog.Default()
+	Jobs        int          // 并行渲染使用的 goroutine 数量，0 时与 GOMAXPROCS 相同
+	// Progress 为空时不报告进度；大型文档生成时每个阶段开始和每完成一项都会调用，调用不会同时进行，应尽快返回
+	Progress func(Progress)
+	// Cache 非空时复用上一次生成中没有受到文档变化影响的渲染结果，用于同一个文档的多次生成（-watch），见 Cache
+	Cache *Cache
+}
+
+// languageRenderers TypeScript 以外的目标语言，直接从中间表示生成，不经过合并和格式转换
+var languageRenderers = map[string]func(r *run, spec []byte) (*ir.API, error){
+	LangGo:     (*run).generateGo,
+	LangPython: (*run).generatePython,
+	LangDart:   (*run).generateDart,
+}
+
+// Files 生成的文件，key 为相对于输出目录、以 / 分隔的路径，内容已包含头部注释
+type Files map[string][]byte
+
+// Generator 按 Options 生成 TypeScript 客户端代码
+type Generator struct {
+	opts Options
+}
+
+// New 创建生成器，选项在 Generate 时校验
+func New(opts Options) *Generator {
+	return &Generator{opts: opts.withDefaults()}
+}
+
+// writeFile 记录生成的文件，filename 为相对于输出目录的路径
+func (r *run) writeFile(filename string, data []byte) {
+	filename = filepath.ToSlash(filepath.Clean(filename))
+	r.output[filename] = data
+	if r.recorded != nil {
+		r.recorded[filename] = data
+	}
+}
+
+// Generate 根据 OpenAPI 文档（YAML 或 JSON）生成全部文件，不访问输出目录；
+// 多个 goroutine 可以共用同一个 Generator，各次调用的状态互相独立，可以同时进行
+func (g *Generator) Generate(spec []byte) (Files, error) {
+	r, err := g.newRun()
+	if err != nil {
+		return nil, err
+	}
+	spec, err = r.openAPI(spec)
+	if err != nil {
+		return nil, err
+	}
+	if render := languageRenderers[r.lang]; render != nil {
+		api, err := render(r, spec)
+		if err != nil {
+			return nil, err
+		}
+		r.startProgress(StageFinish, 1)
+		if err := r.reportUnsupported(spec, r.parsedSpec); err != nil {
+			return nil, err
+		}
+		return r.finish(api, r.output)
+	}
+	if c := r.renderCache; c != nil {
+		// 共用同一个 Cache 的生成依次进行
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.begin(cacheKey(r.opts))
+	}
+	api, err := r.generate(spec)
+	if err != nil {
+		return nil, err
+	}
+	r.startProgress(StageFinish, 1)
+	files := r.output
+	if cycles := importCycles(files); len(cycles) > 0 {
+		var lines []string
+		for _, cycle := range cycles {
+			lines = append(lines, strings.Join(cycle, " -> "))
+		}
+		return nil, fmt.Errorf("import cycle between generated files, imports may be undefined while they load: %s; "+
+			"move the shared code into a file that none of them import, or use -single-file", strings.Join(lines, "; "))
+	}
+	if r.singleFile != "" {
+		code, err := bundleFiles(files, r.singleFile)
+		if err != nil {
+			return nil, fmt.Errorf("bundle single file: %w", err)
+		}
+		files = Files{filepath.ToSlash(r.singleFile): code}
+	}
+	if r.layout == "split" {
+		files = layoutFiles(files, api)
+	}
+	if r.apiVersions == "split" {
+		files = r.versionFiles(files, api)
+	}
+	files, err = r.convertFiles(files)
+	if err != nil {
+		return nil, fmt.Errorf("convert output to %s: %w", r.outputExt, err)
+	}
+	if len(r.importAliases) > 0 {
+		// 在转换之后改写，tsc 编译时仍按相对路径解析
+		files = r.aliasImports(files)
+	}
+	if r.importStyle == "directory" {
+		files = r.directoryImports(files)
+	}
+	if r.packageName != "" {
+		if files, err = r.packageFiles(spec, files, api); err != nil {
+			return nil, fmt.Errorf("generate npm package: %w", err)
+		}
+	}
+	if err := r.reportUnsupported(spec, r.parsedSpec); err != nil {
+		return nil, err
+	}
+	return r.finish(api, files)
+}
+
+// finish 运行插件并添加头部注释，插件输出不参与合并和格式转换；生成过程中记录过错误时返回错误，见 checkProblems
+func (r *run) finish(api *ir.API, files Files) (Files, error) {
+	if err := r.runPlugins(r.opts.Plugins, api, files); err != nil {
+		return nil, err
+	}
+	if err := r.checkProblems(); err != nil {
+		return nil, err
+	}
+	generated := bannerTime()
+	for name, data := range files {
+		files[name] = r.withBanner(name, r.withHeader(name, data), generated)
+	}
+	return files, nil
+}
+
+// IR 返回文档的中间表示，过滤、分组和命名规则与 Generate 相同，不渲染任何文件
+func (g *Generator) IR(spec []byte) (*ir.API, error) {
+	r, spec, api, err := g.parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	return r.buildAPI(api)
+}
+
+// Unsupported 返回文档中没有生成、或生成为 any / object 的部分，按行号排序，过滤规则与 Generate 相同；Generate 会将它们记录为警告
+func (g *Generator) Unsupported(spec []byte) ([]Unsupported, error) {
+	r, spec, api, err := g.parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	return r.unsupportedFeatures(spec, api)
+}
+
+// Validate 同时返回 IR 和 Unsupported 的结果，文档只解析一次，解析时的警告只记录一次
+func (g *Generator) Validate(spec []byte) (*ir.API, []Unsupported, error) {
+	r, spec, api, err := g.parse(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := r.buildAPI(api)
+	if err != nil {
+		return nil, nil, err
+	}
+	features, err := r.unsupportedFeatures(spec, api)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, features, nil
+}
+
+// parse 转换并解析文档，返回本次运行、使用的 OpenAPI 文档和解析结果
+func (g *Generator) parse(spec []byte) (*run, []byte, *OpenAPI, error) {
+	r, err := g.newRun()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	spec, err = r.openAPI(spec)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	api, err := r.parseSpec(spec)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return r, spec, api, nil
+}
+
+// buildAPI 构建中间表示；构建时记录过 error 级别的诊断时返回错误
+func (r *run) buildAPI(api *OpenAPI) (*ir.API, error) {
+	result := r.buildIR(api, newSchemaResolver(api.Components.Schemas, r.logger))
+	if n := r.problems.diagnostics.Load(); n > 0 {
+		return nil, fmt.Errorf("%d diagnostics with level error, see -diagnostic", n)
+	}
+	return result, nil
+}
+
+// OpenAPI 返回生成使用的 OpenAPI 文档：OpenAPI 输入原样返回，proto 输入返回转换后的文档（JSON）
+func (g *Generator) OpenAPI(spec []byte) ([]byte, error) {
+	r, err := g.newRun()
+	if err != nil {
+		return nil, err
+	}
+	return r.openAPI(spec)
+}
+
+// withDefaults 为空的选项填入默认值
+func (o Options) withDefaults() Options {
+	if o.OperationName == "" {
+		o.OperationName = "strip-tag"
+	}
+	if o.GroupBy == "" {
+		o.GroupBy = "tag"
+	}
+	if o.Lang == "" {
+		o.Lang = LangTypeScript
+	}
+	if o.GoPackage == "" {
+		o.GoPackage = "api"
+	}
+	if o.Ext == "" {
+		o.Ext = ".ts"
+	}
+	if o.Layout == "" {
+		o.Layout = "modules"
+	}
+	if o.GroupTypesBy == "" {
+		o.GroupTypesBy = "usage"
+	}
+	if o.APIVersions == "" {
+		o.APIVersions = "mixed"
+	}
+	if o.ImportStyle == "" {
+		o.ImportStyle = "source"
+	}
+	if o.Naming.FunctionCase == "" {
+		o.Naming.FunctionCase = "camel"
+	}
+	if o.Naming.DirCase == "" {
+		o.Naming.DirCase = "lower"
+	}
+	if o.Naming.EnumCase == "" {
+		o.Naming.EnumCase = "preserve"
+	}
+	if o.Naming.Namespaces == "" {
+		o.Naming.Namespaces = "strip"
+	}
+	if o.Logger == nil {
+		o.Logger = slog.Default()
+	}
+	return o
+}
+
+// Validate 校验选项的取值及其组合
+func (o Options) Validate() error {
+	o = o.withDefaults()
+	switch o.InputFormat {
+	case "", InputOpenAPI, InputProto, InputDescriptorSet, InputPostman:
+	default:
+		return fmt.Errorf("unsupported input format %q", o.InputFormat)
+	}
+	if err := o.validateLang(); err != nil {
+		return err
+	}
+	if _, ok := clientTemplates[o.Client]; !ok {
+		return fmt.Errorf("unsupported client %q", o.Client)
+	}
+	if _, ok := hooksTemplates[o.Hooks]; !ok {
+		return fmt.Errorf("unsupported hooks %q", o.Hooks)
+	}
+	if _, ok := validatorEmitters[o.Validators]; o.Validators != "" && !ok {
+		return fmt.Errorf("unsupported validators %q", o.Validators)
+	}
+	if _, ok := contractFrameworks[o.ContractTests]; o.ContractTests != "" && !ok {
+		return fmt.Errorf("unsupported contract tests %q", o.ContractTests)
+	}
+	if _, ok := contractFrameworks[o.UnitTests]; o.UnitTests != "" && !ok {
+		return fmt.Errorf("unsupported unit tests %q", o.UnitTests)
+	}
+	if o.ContractTests != "" && (o.Client == "" || o.Validators == "") {
+		return fmt.Errorf("-contract-tests requires -client and -validators")
+	}
+	if o.ValidateResponses != "" && o.ValidateResponses != "warn" && o.ValidateResponses != "throw" {
+		return fmt.Errorf("unsupported validate responses %q", o.ValidateResponses)
+	}
+	if o.ValidateResponses != "" && o.Validators == "" {
+		return fmt.Errorf("-validate-responses requires -validators")
+	}
+	if o.Jobs < 0 {
+		return fmt.Errorf("invalid -jobs %d, expected a positive number or 0 for GOMAXPROCS", o.Jobs)
+	}
+	if o.Forms != "" && o.Forms != "yup" {
+		return fmt.Errorf("unsupported forms %q", o.Forms)
+	}
+	if o.JSONSchema != "" && o.JSONSchema != "split" && o.JSONSchema != "bundle" {
+		return fmt.Errorf("unsupported json schema %q", o.JSONSchema)
+	}
+	if o.Pagination != "" {
+		if _, err := ParsePaginationPattern(o.Pagination); err != nil {
+			return err
+		}
+	}
+	if _, err := parseOperationName(o.OperationName, o.Naming); err != nil {
+		return err
+	}
+	if _, err := parseTypeMappings(o.TypeMappings); err != nil {
+		return err
+	}
+	if _, err := parseDiagnostics(o.Diagnostics); err != nil {
+		return err
+	}
+	if err := validateGroupBy(o.GroupBy); err != nil {
+		return err
+	}
+	switch o.GroupTypesBy {
+	case "usage":
+	case "namespace":
+		if !o.GroupTypes && !o.GroupEnums {
+			return fmt.Errorf("-group-types-by namespace requires -group-types or -group-enums")
+		}
+	default:
+		return fmt.Errorf("unsupported -group-types-by %q, expected usage or namespace", o.GroupTypesBy)
+	}
+	if err := o.Naming.Validate(); err != nil {
+		return err
+	}
+	if err := validateOutputExt(o.Ext, o.EmitJS); err != nil {
+		return err
+	}
+	if err := validateLayout(o); err != nil {
+		return err
+	}
+	if err := validateAPIVersions(o); err != nil {
+		return err
+	}
+	if err := validateImportStyle(o); err != nil {
+		return err
+	}
+	if err := validatePackage(o); err != nil {
+		return err
+	}
+	if err := validateWorkspace(o); err != nil {
+		return err
+	}
+	if err := validateImportAliases(o); err != nil {
+		return err
+	}
+	if o.SingleFile != "" {
+		// 这些输出依赖多文件布局
+		for _, option := range []struct {
+			name string
+			set  bool
+		}{
+			{"-hooks", o.Hooks != ""}, {"-classes", o.Classes}, {"-forms", o.Forms != ""}, {"-mocks", o.Mocks},
+			{"-contract-tests", o.ContractTests != ""}, {"-unit-tests", o.UnitTests != ""}, {"-json-schema", o.JSONSchema != ""},
+		} {
+			if option.set {
+				return fmt.Errorf("%s is not supported with -single-file", option.name)
+			}
+		}
+	}
+	for _, plugin := range o.Plugins {
+		if _, _, err := parsePlugin(plugin); err != nil {
+			return err
+		}
+	}
+	if o.PluginTimeout < 0 {
+		return fmt.Errorf("invalid -plugin-timeout %s, expected a positive duration", o.PluginTimeout)
+	}
+	_, err := NewOperationFilter(o.IncludeTags, o.ExcludeTags, o.IncludePaths, o.ExcludePaths, o.IncludeOperations, o.ExcludeOperations)
+	return err
+}
+
+// validateLang 校验目标语言，TypeScript 专有的选项不能用于其他语言
+func (o Options) validateLang() error {
+	switch o.Lang {
+	case LangTypeScript:
+		return nil
+	case LangGo:
+		if !token.IsIdentifier(o.GoPackage) || token.IsKeyword(o.GoPackage) {
+			return fmt.Errorf("invalid go package name %q", o.GoPackage)
+		}
+	case LangPython, LangDart:
+	default:
+		return fmt.Errorf("unsupported lang %q", o.Lang)
+	}
+	for _, option := range []struct {
+		name string
+		set  bool
+	}{
+		{"-client", o.Client != ""}, {"-hooks", o.Hooks != ""}, {"-classes", o.Classes}, {"-validators", o.Validators != ""},
+		{"-forms", o.Forms != ""}, {"-json-schema", o.JSONSchema != ""}, {"-mocks", o.Mocks}, {"-contract-tests", o.ContractTests != ""},
+		{"-unit-tests", o.UnitTests != ""},
+		{"-validate-responses", o.ValidateResponses != ""}, {"-pagination", o.Pagination != ""}, {"-group-types", o.GroupTypes},
+		{"-group-enums", o.GroupEnums}, {"-group-types-by", o.GroupTypesBy != "usage"}, {"-layout", o.Layout != "modules"},
+		{"-single-file", o.SingleFile != ""}, {"-ext", o.Ext != ".ts"}, {"-emit-js", o.EmitJS}, {"-package-name", o.PackageName != ""},
+		{"-provenance", o.Provenance}, {"-type-mapping", len(o.TypeMappings) > 0}, {"-import-alias", len(o.ImportAliases) > 0},
+		{"-import-style", o.ImportStyle != "source"}, {"-namespaces namespace", o.Naming.Namespaces == "namespace"},
+		{"-api-versions split", o.APIVersions == "split"},
+	} {
+		if option.set {
+			return fmt.Errorf("%s is not supported with -lang %s", option.name, o.Lang)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// generator_test.go
+package generator
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestValidateParsesOnce Validate 只解析一次文档，解析时的警告只记录一次
+func TestValidateParsesOnce(t *testing.T) {
+	spec, err := os.ReadFile(filepath.Join("testdata", "crud.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logs bytes.Buffer
+	api, _, err := New(Options{
+		Source:      "crud.yaml",
+		IncludeTags: []string{"missing"},
+		Logger:      slog.New(slog.NewTextHandler(&logs, nil)),
+	}).Validate(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(api.Operations) != 0 {
+		t.Errorf("operations = %d, want 0", len(api.Operations))
+	}
+	if n := strings.Count(logs.String(), "filters matched no operations"); n != 1 {
+		t.Errorf("warning logged %d times, want 1:\n%s", n, logs.String())
+	}
+}
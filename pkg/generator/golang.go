@@ -0,0 +1,411 @@
+// golang.go
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// goInitialisms Go 命名中保持全大写的缩写，例如 userId -> UserID
+var goInitialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true, "EOF": true, "GUID": true,
+	"HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JSON": true, "QPS": true, "RAM": true,
+	"RPC": true, "SLA": true, "SMTP": true, "SQL": true, "SSH": true, "TCP": true, "TLS": true, "TTL": true,
+	"UDP": true, "UI": true, "UID": true, "UUID": true, "URI": true, "URL": true, "UTF8": true, "VM": true,
+	"XML": true, "XSRF": true, "XSS": true,
+}
+
+// goReserved 客户端运行时（client.go）占用的名称，模型和枚举遇到时加上 Type 后缀
+var goReserved = map[string]bool{"Client": true, "NewClient": true, "Error": true}
+
+// goName 转换为导出的 Go 标识符，例如 user_id -> UserID，2fa -> X2fa
+func goName(s string) string {
+	var b strings.Builder
+	for _, word := range splitWords(s) {
+		if upper := strings.ToUpper(word); goInitialisms[upper] {
+			b.WriteString(upper)
+		} else {
+			b.WriteString(capitalize(word))
+		}
+	}
+	name := b.String()
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "X" + name
+	}
+	return name
+}
+
+// uniqueNames 为一组名称分配不重复的标识符，重复时加数字后缀
+type uniqueNames map[string]bool
+
+func (n uniqueNames) unique(name string) string {
+	candidate := name
+	for i := 2; n[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	n[candidate] = true
+	return candidate
+}
+
+// goModel 结构体或类型定义
+type goModel struct {
+	Name   string
+	Doc    []string
+	Type   string // 非空时生成 type Name Type
+	Embeds []string
+	Fields []goField
+}
+
+type goField struct {
+	Name string
+	Type string
+	Tag  string
+	Doc  []string
+}
+
+// goEnum 字符串类型及其常量
+type goEnum struct {
+	Name    string
+	Doc     []string
+	Members []goConst
+}
+
+type goConst struct {
+	Name  string
+	Value string
+}
+
+// goService 一个模块的接口，作为 Client 的字段，例如 client.User.Get(ctx, params)
+type goService struct {
+	Module  string
+	Field   string // Client 中的字段名称
+	Type    string // 服务类型名称
+	Methods []goMethod
+}
+
+// goMethod 服务类型上的接口方法
+type goMethod struct {
+	Name       string
+	Doc        []string
+	HTTPMethod string  // http.MethodGet 等常量
+	Path       string  // 路径表达式，有 Args 时先用 expandPath 替换其中的路径参数
+	Args       []goArg // 请求体之外的路径参数，位于 params 之前
+	Params     string  // 参数类型，为空表示没有参数
+	Body       bool    // params 是请求体，POST/PUT 时原样发送
+	Result     string  // 返回值类型，为空表示只返回 error
+}
+
+// goArg 方法参数
+type goArg struct {
+	Name string
+	Type string
+}
+
+// goRenderer 记录 IR 中模型和枚举的 Go 类型名称
+type goRenderer struct {
+	api     *ir.API
+	types   map[string]string // 原始名称 -> Go 类型名称
+	aliases map[string]bool   // 生成为非结构体类型的模型，引用时不加指针
+	imports map[string]bool   // types.go 需要导入的包
+}
+
+// generateGo 根据中间表示生成 Go 客户端包：client.go 运行时、types.go 类型、每个模块一个 <module>_service.go
+func (r *run) generateGo(data []byte) (*ir.API, error) {
+	spec, err := r.parseSpec(data)
+	if err != nil {
+		return nil, err
+	}
+	api := r.buildIR(spec, newSchemaResolver(spec.Components.Schemas, r.logger))
+	renderer := &goRenderer{api: api, types: make(map[string]string), aliases: make(map[string]bool), imports: make(map[string]bool)}
+
+	// 服务类型先占用名称，模型和枚举与之重名时加数字后缀
+	names := make(uniqueNames)
+	for name := range goReserved {
+		names[name] = true
+	}
+	modules := make(map[string][]ir.Operation)
+	for _, op := range api.Operations {
+		modules[op.Module] = append(modules[op.Module], op)
+	}
+	fields := uniqueNames{"BaseURL": true, "HTTPClient": true, "Header": true}
+	var services []goService
+	for _, module := range sortedKeys(modules) {
+		field := fields.unique(goName(module))
+		services = append(services, goService{Module: module, Field: field, Type: names.unique(field + "Service")})
+	}
+	typeName := func(name string) string {
+		if goReserved[name] {
+			name += "Type"
+		}
+		return names.unique(name)
+	}
+	for _, enum := range api.Enums {
+		renderer.types[enum.Name] = typeName(goName(enum.TypeName))
+	}
+	for _, model := range api.Models {
+		renderer.types[model.Name] = typeName(goName(model.TypeName))
+		if model.Alias != nil || (len(model.Fields) == 0 && len(model.Extends) == 0) {
+			renderer.aliases[model.Name] = true
+		}
+	}
+
+	var enums []goEnum
+	for _, enum := range api.Enums {
+		enums = append(enums, renderer.enum(enum, names))
+	}
+	var models []goModel
+	for _, model := range api.Models {
+		models = append(models, renderer.model(model))
+	}
+	tracker := r.startProgress(StageOperations, len(api.Operations))
+	for i := range services {
+		methodNames := make(uniqueNames)
+		for _, op := range modules[services[i].Module] {
+			services[i].Methods = append(services[i].Methods, renderer.method(op, methodNames.unique(goName(op.Name))))
+			tracker.step()
+		}
+		sort.Slice(services[i].Methods, func(a, b int) bool { return services[i].Methods[a].Name < services[i].Methods[b].Name })
+	}
+
+	clientTmpl, err := r.parseTemplate("templates/go-client.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parse go-client template: %w", err)
+	}
+	typesTmpl, err := r.parseTemplate("templates/go-types.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parse go-types template: %w", err)
+	}
+	serviceTmpl, err := r.parseTemplate("templates/go-service.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parse go-service template: %w", err)
+	}
+
+	if err := r.renderGoFile(clientTmpl, "client.go", map[string]interface{}{"Package": r.goPackage, "Services": services}); err != nil {
+		return nil, err
+	}
+	if err := r.renderGoFile(typesTmpl, "types.go", map[string]interface{}{
+		"Package": r.goPackage,
+		"Imports": sortedKeys(renderer.imports),
+		"Enums":   enums,
+		"Models":  models,
+	}); err != nil {
+		return nil, err
+	}
+	for _, service := range services {
+		data := map[string]interface{}{"Package": r.goPackage, "Service": service}
+		if err := r.renderGoFile(serviceTmpl, service.Module+"_service.go", data); err != nil {
+			return nil, err
+		}
+	}
+	return api, nil
+}
+
+// renderGoFile 渲染并用 gofmt 格式化 Go 文件，开头的空行把头部注释与 package 子句隔开
+func (r *run) renderGoFile(tmpl *template.Template, filename string, data interface{}) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("render %s: %w", filename, err)
+	}
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("format %s: %w", filename, err)
+	}
+	r.logger.Debug("generate go file", "file", filename)
+	r.writeFile(filename, append([]byte("\n"), code...))
+	return nil
+}
+
+// enum 枚举常量名称为类型名称加取值，例如 RoleAdmin
+func (r *goRenderer) enum(enum ir.Enum, names uniqueNames) goEnum {
+	result := goEnum{Name: r.types[enum.Name], Doc: goDoc(r.types[enum.Name], enum.Description)}
+	for _, member := range enum.Members {
+		suffix := goName(member.Value)
+		if member.Value == "" {
+			suffix = "Empty"
+		}
+		result.Members = append(result.Members, goConst{
+			Name:  names.unique(result.Name + suffix),
+			Value: strconv.Quote(member.Value),
+		})
+	}
+	return result
+}
+
+// model 有字段的模型生成结构体，allOf 基类嵌入；其余生成类型定义
+func (r *goRenderer) model(model ir.Model) goModel {
+	result := goModel{Name: r.types[model.Name], Doc: goDoc(r.types[model.Name], model.Description)}
+	switch {
+	case model.Alias != nil:
+		result.Type = r.typeName(*model.Alias, false)
+		return result
+	case len(model.Fields) == 0 && len(model.Extends) == 0:
+		result.Type = "map[string]any"
+		return result
+	}
+	for _, base := range model.Extends {
+		result.Embeds = append(result.Embeds, r.types[base])
+	}
+
+	names := make(uniqueNames)
+	for _, base := range result.Embeds {
+		names[base] = true
+	}
+	for _, field := range model.Fields {
+		name := names.unique(goName(field.Name))
+		tag := field.Name
+		if !field.Required {
+			tag += ",omitempty"
+		}
+		result.Fields = append(result.Fields, goField{
+			Name: name,
+			Type: r.typeName(field.Type, true),
+			Tag:  fmt.Sprintf("`json:%q`", tag),
+			Doc:  goDoc(name, field.Description),
+		})
+	}
+	return result
+}
+
+// method 生成接口方法，请求参数和返回的模型都使用指针
+func (r *goRenderer) method(op ir.Operation, name string) goMethod {
+	summary := op.Summary
+	if summary == "" {
+		summary = "调用 " + op.Method + " " + op.Path
+	}
+	method := goMethod{
+		Name:       name,
+		Doc:        append(goDoc(name, summary), "", op.Method+" "+op.Path),
+		HTTPMethod: "http.Method" + capitalize(op.Method),
+		Path:       strconv.Quote(op.Path),
+		Body:       op.Body,
+	}
+	if op.Request != nil {
+		method.Params = r.typeName(*op.Request, true)
+	}
+	if op.Response != nil {
+		method.Result = r.typeName(*op.Response, true)
+	}
+	// 请求体之外的路径参数作为方法参数，合成的请求类型中的路径参数是必填字段，由 do 替换
+	if len(op.PathParams) > 0 {
+		names := uniqueNames{"ctx": true, "params": true, "s": true, "out": true, "err": true}
+		var values []string
+		for _, field := range op.PathParams {
+			arg := goArg{Name: names.unique(goArgName(field.Name)), Type: r.typeName(field.Type, false)}
+			method.Args = append(method.Args, arg)
+			values = append(values, fmt.Sprintf("%q: %s", field.Name, arg.Name))
+		}
+		method.Path = fmt.Sprintf("expandPath(%s, map[string]any{%s})", method.Path, strings.Join(values, ", "))
+	}
+	return method
+}
+
+// goArgName 转换为小写开头的 Go 参数名称，例如 user_id -> userID；关键字加上 Param 后缀
+func goArgName(s string) string {
+	words := splitWords(s)
+	if len(words) == 0 {
+		return "param"
+	}
+	name := strings.ToLower(words[0])
+	for _, word := range words[1:] {
+		name += goName(word)
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "x" + name
+	}
+	if token.IsKeyword(name) {
+		name += "Param"
+	}
+	return name
+}
+
+// typeName 类型表达式对应的 Go 类型；pointer 为 true 时（字段、参数和返回值）结构体和时间使用指针，
+// 数组和字典的元素使用值类型
+func (r *goRenderer) typeName(t ir.Type, pointer bool) string {
+	switch t.Kind {
+	case ir.String:
+		switch t.Format {
+		case "date-time":
+			r.imports["time"] = true
+			if pointer {
+				return "*time.Time"
+			}
+			return "time.Time"
+		case "byte", "binary":
+			return "[]byte"
+		}
+		return "string"
+	case ir.Integer:
+		if t.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case ir.Number:
+		if t.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case ir.Boolean:
+		return "bool"
+	case ir.Object:
+		return "map[string]any"
+	case ir.Ref:
+		name, ok := r.types[t.Ref]
+		if !ok {
+			return "any"
+		}
+		if pointer && !r.aliases[t.Ref] {
+			return "*" + name
+		}
+		return name
+	case ir.EnumRef:
+		if name, ok := r.types[t.Ref]; ok {
+			return name
+		}
+		return "string"
+	case ir.Array:
+		if t.Items == nil {
+			return "[]any"
+		}
+		return "[]" + r.typeName(*t.Items, false)
+	case ir.Map:
+		if t.Items == nil {
+			return "map[string]any"
+		}
+		return "map[string]" + r.typeName(*t.Items, false)
+	case ir.Tuple:
+		return "[]any"
+	}
+	return "any"
+}
+
+// goDoc 以名称开头的 Go 文档注释，每行一个元素
+func goDoc(name, description string) []string {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return nil
+	}
+	lines := strings.Split(description, "\n")
+	lines[0] = name + " " + lines[0]
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return lines
+}
+
+// sortedKeys 返回 map 的有序 key
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,182 @@
+// grouping.go
+package generator
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// groupStrategies 各分组方式，返回空字符串时回退到按 tag 分组
+var groupStrategies = map[string]func(path string, op *Operation) string{
+	"tag": func(path string, op *Operation) string {
+		if len(op.Tags) > 0 {
+			return op.Tags[0]
+		}
+		return ""
+	},
+	"path-prefix": pathPrefix,
+	"x-module": func(path string, op *Operation) string {
+		return op.XModule
+	},
+	"operation-prefix": func(path string, op *Operation) string {
+		if i := strings.IndexAny(op.OperationID, "_."); i > 0 {
+			return op.OperationID[:i]
+		}
+		return ""
+	},
+}
+
+// versionSegment 路径中的版本号，例如 v1
+var versionSegment = regexp.MustCompile(`^v\d+$`)
+
+// pathPrefix 路径中第一个有意义的段，跳过 api、版本号和路径参数，例如 /api/v1/users/{id} -> users
+func pathPrefix(path string, op *Operation) string {
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" || segment == "api" || versionSegment.MatchString(segment) || strings.HasPrefix(segment, "{") {
+			continue
+		}
+		return segment
+	}
+	return ""
+}
+
+// operationModule 接口所属的模块（目录）名称，x-moonbeam-module 优先于 -group-by；-api-versions split 时加上版本前缀
+func (r *run) operationModule(path string, op *Operation) string {
+	if op.MoonbeamModule != "" {
+		return r.versionedModule(path, r.naming.Dir(strings.TrimSpace(op.MoonbeamModule)))
+	}
+	if name := groupStrategies[r.groupBy](path, op); name != "" {
+		return r.versionedModule(path, r.naming.Dir(name))
+	}
+	return r.versionedModule(path, r.getModuleName(op.Tags))
+}
+
+// validateGroupBy 校验 -group-by 取值
+func validateGroupBy(value string) error {
+	if _, ok := groupStrategies[value]; ok {
+		return nil
+	}
+	var names []string
+	for name := range groupStrategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("unsupported -group-by %q, expected one of %s", value, strings.Join(names, ", "))
+}
+
+// typeOwners 返回只被一个模块使用的类型及其模块，uses 为模块 -> 直接使用的类型名称
+// 被多个模块使用的类型及其引用的类型仍在公共的 types 模块中
+func typeOwners(uses map[string][]string, resolver *SchemaResolver) map[string]string {
+	modules := make(map[string]map[string]bool) // 类型 -> 使用它的模块
+	add := func(name, module string) {
+		if modules[name] == nil {
+			modules[name] = make(map[string]bool)
+		}
+		modules[name][module] = true
+	}
+	for module, roots := range uses {
+		for _, name := range roots {
+			add(name, module)
+		}
+		for name := range resolver.Closure(roots) {
+			add(name, module)
+		}
+	}
+
+	owners := make(map[string]string)
+	for name, users := range modules {
+		if len(users) != 1 {
+			continue
+		}
+		for module := range users {
+			owners[name] = module
+		}
+	}
+	return owners
+}
+
+// namespaceOwners -group-types-by namespace 时类型和枚举所属的模块：schema 名称的第一段命名空间，例如 team.v1.Team 属于 team；
+// 没有命名空间的 schema 仍在公共的 types 模块中
+func (r *run) namespaceOwners(api *ir.API) map[string]string {
+	owners := make(map[string]string)
+	add := func(name string) {
+		namespace := r.schemaNamespace(name)
+		if namespace == "" {
+			return
+		}
+		first, _, _ := strings.Cut(namespace, ".")
+		// 与公共类型目录同名时 <模块>/types 会落在 types/types
+		if module := r.naming.Dir(first); module != "" && module != "types" {
+			owners[name] = module
+		}
+	}
+	for _, model := range api.Models {
+		add(model.Name)
+	}
+	for _, enum := range api.Enums {
+		add(enum.Name)
+	}
+	return owners
+}
+
+// groupTypeNames 将公共 types 模块中的接口按 owners 拆分到 <模块>/types，names 已排序，每组中的名称保持顺序；
+// 只记录名称，接口代码在写入各自的文件时才从 interfaces 中取出
+func groupTypeNames(names []string, owners map[string]string) map[string][]string {
+	groups := map[string][]string{"types": nil}
+	for _, name := range names {
+		group := "types"
+		if owner, ok := owners[name]; ok {
+			group = owner + "/types"
+		}
+		groups[group] = append(groups[group], name)
+	}
+	return groups
+}
+
+// groupTypeImports file 中的类型定义引用的其他模块类型文件中的类型，按文件排序；只有 -group-types-by namespace 时会出现，
+// 用 import type 导入，模块类型文件之间互相引用也不会形成运行时的循环导入
+func groupTypeImports(file string, names []string, typeRefs map[string][]string, groupFiles map[string]string) []typeImport {
+	imported := make(map[string]map[string]bool) // 文件 -> 类型名称
+	for _, name := range names {
+		for _, ref := range typeRefs[name] {
+			target, ok := groupFiles[ref]
+			if !ok || target == file {
+				continue
+			}
+			if imported[target] == nil {
+				imported[target] = make(map[string]bool)
+			}
+			imported[target][interfaceName(ref)] = true
+		}
+	}
+	var result []typeImport
+	for _, target := range sortedKeys(imported) {
+		result = append(result, typeImport{Names: sortedKeys(imported[target]), From: relativeSpecifier(path.Dir(file), target)})
+	}
+	return result
+}
+
+// usedTypeNames 返回接口文件中的类型定义引用的公共类型名称，按名称排序
+func usedTypeNames(names []string, typeRefs map[string][]string, typeNames []string) []string {
+	shared := make(map[string]bool)
+	for _, typeName := range typeNames {
+		shared[typeName] = true
+	}
+	seen := make(map[string]bool)
+	var used []string
+	for _, name := range names {
+		for _, ref := range typeRefs[name] {
+			if typeName := interfaceName(ref); shared[typeName] && !seen[typeName] {
+				seen[typeName] = true
+				used = append(used, typeName)
+			}
+		}
+	}
+	sort.Strings(used)
+	return used
+}
@@ -0,0 +1,48 @@
+// header.go
+package generator
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+)
+
+// withHeader 在生成的文件开头加上 header：纯文本的每一行加上文件类型的注释符号，已经是注释时原样使用；
+// 不支持注释的文件（JSON）不加；声明之后空一行，Go 文件中不会成为包注释
+func (r *run) withHeader(filename string, data []byte) []byte {
+	comment, ok := bannerComments[filepath.Ext(filename)]
+	text := strings.TrimRight(strings.ReplaceAll(r.header, "\r\n", "\n"), " \t\n")
+	if !ok || strings.TrimSpace(text) == "" {
+		return data
+	}
+	var buf bytes.Buffer
+	commented := isComment(text, comment)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t")
+		switch {
+		case commented:
+			buf.WriteString(line)
+		case line == "":
+			buf.WriteString(comment)
+		default:
+			buf.WriteString(comment + " " + line)
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+// isComment 判断 text 是否已经是 comment 注释，或 /* */ 块注释（# 注释的语言除外）
+func isComment(text, comment string) bool {
+	if comment == "//" && strings.HasPrefix(text, "/*") && strings.HasSuffix(text, "*/") {
+		return true
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, comment) {
+			return false
+		}
+	}
+	return true
+}
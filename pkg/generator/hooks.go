@@ -0,0 +1,79 @@
+// hooks.go
+package generator
+
+import (
+	"bytes"
+	"path/filepath"
+	"text/template"
+)
+
+// hooksTemplates 各 hooks 模式对应的模板
+var hooksTemplates = map[string]string{
+	"":            "",
+	"react-query": "templates/hooks-react-query.tmpl",
+	"swr":         "templates/hooks-swr.tmpl",
+}
+
+// HookData 单个接口对应的 hook
+type HookData struct {
+	FunctionData
+	HookName string // 例如 useGetTeamQuery
+	KeyName  string // 查询 key 构造函数，例如 getTeamQueryKey
+	IsQuery  bool   // GET 请求生成查询 hook，其余生成 mutation hook
+}
+
+// HooksFileData hooks 文件模板数据
+type HooksFileData struct {
+	ModuleName string
+	Imports    []ImportData
+	Functions  []string
+	Hooks      []HookData
+}
+
+// renderHooks 生成模块的 hooks.ts，复用模块 API 文件的类型导入
+// swr 只为 GET 请求生成 useXxx，react-query 生成 useXxxQuery/useXxxMutation
+func (r *run) renderHooks(kind string, tmpl *template.Template, moduleDir string, mod *ModuleData, imports []ImportData) {
+	data := HooksFileData{
+		ModuleName: mod.Name,
+	}
+	usedTypes := make(map[string]bool)
+	for _, op := range mod.Operations {
+		isQuery := op.Method == "GET"
+		if kind == "swr" && !isQuery {
+			continue
+		}
+		suffix := "Mutation"
+		if kind == "swr" {
+			suffix = ""
+		} else if isQuery {
+			suffix = "Query"
+		}
+		for _, name := range append(append([]string(nil), op.ParamRefs...), op.ResponseRefs...) {
+			usedTypes[name] = true
+		}
+		data.Functions = append(data.Functions, op.FunctionName)
+		data.Hooks = append(data.Hooks, HookData{
+			FunctionData: op,
+			HookName:     "use" + toPascal(op.Name) + suffix,
+			KeyName:      r.naming.Function(op.Name + suffix + "Key"),
+			IsQuery:      isQuery,
+		})
+	}
+
+	if len(data.Hooks) == 0 {
+		return
+	}
+
+	// 只导入 hooks 实际使用的类型
+	data.Imports = filterImports(imports, usedTypes)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		r.logger.Error("hooks template execution failed", "module", mod.Name, "err", err)
+		return
+	}
+
+	filename := filepath.Join(moduleDir, "hooks.ts")
+	r.writeFile(filename, buf.Bytes())
+	r.logger.Debug("generate hooks file", "file", filename)
+}
@@ -0,0 +1,222 @@
+// imports.go
+package generator
+
+import (
+	"bytes"
+	"strings"
+)
+
+// moduleImport 生成的 TypeScript 文件中的一个模块路径：import / export ... from 'x'、import 'x' 或 import('x')
+type moduleImport struct {
+	Specifier  string // 引号中的模块路径
+	Start, End int    // 模块路径（不含引号）在文件中的位置
+	Statement  int    // 静态导入所在语句的开始位置，语句到 End+1 结束；动态导入为 -1
+	TypeOnly   bool   // import type / export type，编译后会被删除
+}
+
+// scanImports 按 TypeScript 的词法找出 data 中的全部模块路径，注释、字符串、模板字符串和正则表达式中的文本不会被当作导入；
+// 静态的 import / export 只在顶层且从行首开始时识别，生成的代码和模板都是这样写的
+func scanImports(data []byte) []moduleImport {
+	var result []moduleImport
+	var prev, prev2 string // 前两个记号，字符串记为 '
+	depth := 0             // { ( [ 的嵌套层数
+	var templates []int    // 模板字符串中每层 ${ 之外的嵌套层数
+	decl, declType := -1, false
+	lineStart := true
+	token := func(t string) {
+		prev2, prev = prev, t
+		lineStart = false
+	}
+	for i := 0; i < len(data); {
+		c := data[i]
+		switch {
+		case c == '\n':
+			lineStart = true
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			if end := bytes.IndexByte(data[i:], '\n'); end >= 0 {
+				i += end
+			} else {
+				i = len(data)
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			if end := bytes.Index(data[i+2:], []byte("*/")); end >= 0 {
+				i += end + 4
+			} else {
+				i = len(data)
+			}
+		case c == '\'' || c == '"':
+			end := skipString(data, i)
+			start := i + 1
+			i = end
+			closed := end > start && data[end-1] == c
+			if !closed {
+				token("'")
+				continue
+			}
+			imp := moduleImport{Specifier: string(data[start : end-1]), Start: start, End: end - 1, Statement: -1}
+			switch {
+			case prev == "(" && prev2 == "import":
+				result = append(result, imp)
+			case decl >= 0 && depth == 0 && (prev == "from" || prev == "import"):
+				imp.Statement, imp.TypeOnly = decl, declType
+				result = append(result, imp)
+			}
+			decl = -1
+			token("'")
+		case c == '`':
+			i = skipTemplate(data, i+1)
+			if i <= len(data) && i > 0 && data[i-1] == '{' {
+				templates = append(templates, depth)
+				depth++
+			}
+			token("`")
+		case c == '/' && regexAllowed(prev):
+			i = skipRegexp(data, i)
+			token("/")
+		case isWordByte(c):
+			start := i
+			for i < len(data) && isWordByte(data[i]) {
+				i++
+			}
+			word := string(data[start:i])
+			switch {
+			case (word == "import" || word == "export") && lineStart && depth == 0:
+				decl, declType = start, false
+			case word == "type" && decl >= 0 && (prev == "import" || prev == "export"):
+				declType = true
+			}
+			token(word)
+		default:
+			i++
+			switch c {
+			case '{', '[':
+				depth++
+			case '(':
+				depth++
+				decl = -1
+			case '}':
+				depth--
+				if n := len(templates); n > 0 && depth == templates[n-1] {
+					// 模板字符串中 ${...} 结束，继续读取模板字符串
+					templates = templates[:n-1]
+					i = skipTemplate(data, i)
+					if i > 0 && i <= len(data) && data[i-1] == '{' {
+						templates = append(templates, depth)
+						depth++
+					}
+					token("`")
+					continue
+				}
+			case ')', ']':
+				depth--
+			case ';', '=':
+				decl = -1
+			}
+			token(string(c))
+		}
+	}
+	return result
+}
+
+// rewriteImports 用 rewrite 的返回值替换 data 中每个模块路径，返回值与原路径相同时保持不变
+func rewriteImports(data []byte, rewrite func(imp moduleImport) string) []byte {
+	var b bytes.Buffer
+	last := 0
+	for _, imp := range scanImports(data) {
+		specifier := rewrite(imp)
+		if specifier == imp.Specifier {
+			continue
+		}
+		if b.Len() == 0 {
+			b.Grow(len(data) + 64)
+		}
+		b.Write(data[last:imp.Start])
+		b.WriteString(specifier)
+		last = imp.End
+	}
+	if last == 0 {
+		return data
+	}
+	b.Write(data[last:])
+	return b.Bytes()
+}
+
+// isRelativeSpecifier 模块路径是否为相对路径
+func isRelativeSpecifier(specifier string) bool {
+	return strings.HasPrefix(specifier, "./") || strings.HasPrefix(specifier, "../")
+}
+
+// skipString 返回从 data[i] 的引号开始的字符串之后的位置；字符串不能跨行，没有结束引号时停在行尾
+func skipString(data []byte, i int) int {
+	quote := data[i]
+	for i++; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		case '\n':
+			return i
+		}
+	}
+	return len(data)
+}
+
+// skipTemplate 从模板字符串的内容 data[i] 开始读取，返回结束的反引号或 ${ 之后的位置
+func skipTemplate(data []byte, i int) int {
+	for ; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '`':
+			return i + 1
+		case '$':
+			if i+1 < len(data) && data[i+1] == '{' {
+				return i + 2
+			}
+		}
+	}
+	return len(data)
+}
+
+// skipRegexp 返回从 data[i] 的 / 开始的正则表达式字面量（包括标志）之后的位置
+func skipRegexp(data []byte, i int) int {
+	class := false
+	for i++; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '[':
+			class = true
+		case ']':
+			class = false
+		case '\n':
+			return i
+		case '/':
+			if !class {
+				for i++; i < len(data) && isWordByte(data[i]); i++ {
+				}
+				return i
+			}
+		}
+	}
+	return len(data)
+}
+
+// regexAllowed 前一个记号之后的 / 是否开始正则表达式，而不是除号
+func regexAllowed(prev string) bool {
+	switch prev {
+	case "", "(", ",", "=", ":", "[", "!", "&", "|", "?", "{", "}", ";", "+", "-", "*", "%", "<", ">", "~", "^",
+		"return", "typeof", "case", "do", "else", "in", "of", "new", "delete", "void", "throw", "yield", "await":
+		return true
+	}
+	return false
+}
+
+// isWordByte 标识符、关键字和数字中的字符，非 ASCII 字符按标识符处理
+func isWordByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 0x80 || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
@@ -0,0 +1,69 @@
+// imports_test.go
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestScanImports 只有真正的导入语句中的模块路径会被找到，注释、字符串和正则表达式中的相同文本不算
+func TestScanImports(t *testing.T) {
+	source := strings.Join([]string{
+		"/**",
+		" * Ported from './legacy/pets.ts' and import('./old.ts')",
+		" */",
+		"import { a } from './a.ts'",
+		"import type {",
+		"  B,",
+		"  C",
+		"} from '../types/index.ts'",
+		"import './side-effect.ts'",
+		"export * from \"./d.ts\"",
+		"export type { E } from './e.ts'",
+		"export { request }",
+		"// import { x } from './comment.ts'",
+		"export interface Route {",
+		"  from: './not-a-module.ts'",
+		"}",
+		"const text = \"import { y } from './string.ts'\"",
+		"const template = `from './template.ts' ${'./expr.ts'} import('./nested.ts')`",
+		"const re = /from '.\\/regexp.ts'/g",
+		"export const load = () => import('./lazy.ts')",
+	}, "\n")
+	want := []struct {
+		specifier string
+		static    bool
+		typeOnly  bool
+	}{
+		{"./a.ts", true, false},
+		{"../types/index.ts", true, true},
+		{"./side-effect.ts", true, false},
+		{"./d.ts", true, false},
+		{"./e.ts", true, true},
+		{"./lazy.ts", false, false},
+	}
+	got := scanImports([]byte(source))
+	if len(got) != len(want) {
+		var specifiers []string
+		for _, imp := range got {
+			specifiers = append(specifiers, imp.Specifier)
+		}
+		t.Fatalf("scanImports found %q, want %d imports", specifiers, len(want))
+	}
+	for i, w := range want {
+		imp := got[i]
+		if imp.Specifier != w.specifier || (imp.Statement >= 0) != w.static || imp.TypeOnly != w.typeOnly {
+			t.Errorf("import %d = %+v, want %s static=%v typeOnly=%v", i, imp, w.specifier, w.static, w.typeOnly)
+		}
+		if source[imp.Start:imp.End] != imp.Specifier {
+			t.Errorf("import %d position %d:%d = %q, want %q", i, imp.Start, imp.End, source[imp.Start:imp.End], imp.Specifier)
+		}
+	}
+
+	rewritten := string(rewriteImportExt("index.ts", []byte(source), ".js", nil))
+	for _, text := range []string{"from './a.js'", "import('./lazy.js')", "Ported from './legacy/pets.ts'", "from: './not-a-module.ts'", "from './string.ts'"} {
+		if !strings.Contains(rewritten, text) {
+			t.Errorf("rewriteImportExt result lacks %q:\n%s", text, rewritten)
+		}
+	}
+}
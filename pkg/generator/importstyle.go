@@ -0,0 +1,74 @@
+// importstyle.go
+package generator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// importStyles 支持的写法：source 使用生成文件的扩展名，js 使用编译产物的扩展名（NodeNext），
+// none 不写扩展名，directory 不写扩展名并把 index 文件写成所在的目录
+var importStyles = []string{"source", "js", "none", "directory"}
+
+// validateImportStyle 校验 -import-style 及其组合
+func validateImportStyle(o Options) error {
+	if !contains(importStyles, o.ImportStyle) {
+		return fmt.Errorf("unsupported -import-style %q, expected one of %s", o.ImportStyle, strings.Join(importStyles, ", "))
+	}
+	switch {
+	case o.ImportStyle == "source":
+		return nil
+	case o.PackageName != "":
+		// 包的构建用 rewriteRelativeImportExtensions 改写 .ts 导入
+		return fmt.Errorf("-import-style %s is not supported with -package-name, the package build rewrites .ts imports itself", o.ImportStyle)
+	case o.Ext == ".mts" && o.ImportStyle != "js":
+		return fmt.Errorf("-import-style %s is not supported with -ext .mts, ES modules need explicit extensions, use -import-style js", o.ImportStyle)
+	}
+	return nil
+}
+
+// importExtFor 相对导入使用的扩展名，sourceExt 为生成文件的扩展名，compile 表示输出由 tsc 编译
+func (r *run) importExtFor(sourceExt string, compile bool) string {
+	switch {
+	case r.importStyle == "none" || r.importStyle == "directory":
+		return ""
+	case compile || r.importStyle == "js":
+		// tsc 将 ./index.js 解析到 index.ts，编译后的导入路径无需再改写
+		return compiledExts[sourceExt]
+	}
+	return sourceExt
+}
+
+// directoryImports 把指向 index 文件的相对导入和别名导入改为所在的目录，例如 ../types/index 改为 ../types、@/api/index 改为 @/api；
+// 在 -import-alias 之后调用，第三方包的导入保持不变
+func (r *run) directoryImports(files Files) Files {
+	result := make(Files, len(files))
+	for name, data := range files {
+		if !aliasSourceExts[path.Ext(name)] {
+			result[name] = data
+			continue
+		}
+		result[name] = rewriteImports(data, func(imp moduleImport) string {
+			dir, ok := strings.CutSuffix(imp.Specifier, "/index")
+			if !ok || !r.isGeneratedSpecifier(dir) {
+				return imp.Specifier
+			}
+			return dir
+		})
+	}
+	return result
+}
+
+// isGeneratedSpecifier 导入路径是否为相对路径或 -import-alias 的别名路径
+func (r *run) isGeneratedSpecifier(specifier string) bool {
+	if specifier == "." || specifier == ".." || strings.HasPrefix(specifier, "./") || strings.HasPrefix(specifier, "../") {
+		return true
+	}
+	for _, alias := range r.importAliases {
+		if specifier == alias || strings.HasPrefix(specifier, alias+"/") {
+			return true
+		}
+	}
+	return false
+}
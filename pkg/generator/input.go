@@ -0,0 +1,99 @@
+// input.go
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+)
+
+// normalizeText 统一文本输入（OpenAPI、Postman、.proto）的编码和换行：Windows 工具导出的 UTF-16 转换为 UTF-8，
+// 去除 BOM，CRLF 转换为 LF，保证同一文档在不同平台上生成相同的代码
+func normalizeText(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, utf8BOM):
+		data = data[len(utf8BOM):]
+	case bytes.HasPrefix(data, utf16LEBOM), bytes.HasPrefix(data, utf16BEBOM):
+		decoded, err := decodeUTF16(data[2:], bytes.HasPrefix(data, utf16BEBOM))
+		if err != nil {
+			return nil, err
+		}
+		data = decoded
+	}
+	if !utf8.Valid(data) {
+		return nil, errors.New("the document is not valid UTF-8, save it as UTF-8 (or UTF-16 with a BOM)")
+	}
+	return bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n")), nil
+}
+
+// decodeUTF16 将不含 BOM 的 UTF-16 文本转换为 UTF-8
+func decodeUTF16(data []byte, bigEndian bool) ([]byte, error) {
+	if len(data)%2 != 0 {
+		return nil, errors.New("the document starts with a UTF-16 BOM but has an odd number of bytes")
+	}
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		if bigEndian {
+			units[i] = uint16(data[2*i])<<8 | uint16(data[2*i+1])
+		} else {
+			units[i] = uint16(data[2*i+1])<<8 | uint16(data[2*i])
+		}
+	}
+	return []byte(string(utf16.Decode(units))), nil
+}
+
+// checkSingleDocument 拒绝包含多个 YAML 文档（以 --- 分隔）的输入，只解析第一个文档会静默丢失其余接口；
+// 开头的 --- 和结尾的 ... 不算作多个文档
+func (r *run) checkSingleDocument(data []byte) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	var lines []int
+	var first *yaml.Node
+	for {
+		var doc yaml.Node
+		err := decoder.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// 语法错误由解析文档时报告
+			return nil
+		}
+		if first == nil {
+			first = &doc
+		}
+		if len(doc.Content) > 0 {
+			lines = append(lines, doc.Content[0].Line)
+		}
+	}
+	if len(lines) <= 1 && first != nil {
+		// 只有一个文档时后续的校验和解码直接使用这次的解析结果
+		r.rememberDocument(data, first)
+	}
+	if len(lines) > 1 {
+		return fmt.Errorf("the spec contains %d YAML documents (starting at lines %s), but only one OpenAPI document is supported; "+
+			"split them into separate files and pass each with -f", len(lines), joinInts(lines))
+	}
+	return nil
+}
+
+func joinInts(values []int) string {
+	var b bytes.Buffer
+	for i, value := range values {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprint(&b, value)
+	}
+	return b.String()
+}
@@ -0,0 +1,133 @@
+// iots.go
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// iotsEmitter 生成 io-ts codec（XxxCodec）及 parseXxx 辅助函数
+type iotsEmitter struct{}
+
+func (iotsEmitter) Header(typeNames, enumNames []string) string {
+	var b strings.Builder
+	b.WriteString("// io-ts 运行时校验 codec\n")
+	b.WriteString("import * as t from 'io-ts'\n")
+	b.WriteString("import { isLeft } from 'fp-ts/Either'\n")
+	b.WriteString("import { PathReporter } from 'io-ts/PathReporter'\n")
+	if len(typeNames) > 0 {
+		b.WriteString("import type {\n  " + strings.Join(typeNames, ",\n  ") + "\n} from './index.ts'\n")
+	}
+	if len(enumNames) > 0 {
+		b.WriteString("import {\n  " + strings.Join(enumNames, ",\n  ") + "\n} from './enum.ts'\n")
+	}
+	b.WriteString(`
+// decodeOrThrow 解码数据，失败时抛出包含所有错误路径的异常
+function decodeOrThrow<A>(codec: t.Decoder<unknown, A>, data: unknown): A {
+  const result = codec.decode(data)
+  if (isLeft(result)) {
+    throw new Error(PathReporter.report(result).join('\n'))
+  }
+  return result.right
+}
+
+// enumCodec 字符串枚举的 codec
+function enumCodec<E extends Record<string, string>>(e: E, name: string): t.Type<E[keyof E]> {
+  const values: unknown[] = Object.values(e)
+  const is = (u: unknown): u is E[keyof E] => values.includes(u)
+  return new t.Type<E[keyof E]>(name, is, (u, c) => (is(u) ? t.success(u) : t.failure(u, c)), t.identity)
+}
+`)
+	return b.String()
+}
+
+// Declare 使用 t.recursion 支持递归和任意声明顺序
+func (iotsEmitter) Declare(typeName, expr string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n/**\n * %sCodec 校验 %s\n */\n", typeName, typeName)
+	fmt.Fprintf(&b, "export const %sCodec: t.Type<%s> = t.recursion<%s>('%s', () =>\n  %s\n)\n", typeName, typeName, typeName, typeName, expr)
+	fmt.Fprintf(&b, "\n/**\n * parse%s 校验数据并返回 %s，校验失败时抛出异常\n */\n", typeName, typeName)
+	fmt.Fprintf(&b, "export function parse%s(data: unknown): %s {\n  return decodeOrThrow(%sCodec, data)\n}\n", typeName, typeName, typeName)
+	return b.String()
+}
+
+func (iotsEmitter) Enum(typeName string) string {
+	return fmt.Sprintf("enumCodec(%s, '%s')", typeName, typeName)
+}
+
+func (iotsEmitter) Unknown() string {
+	return "t.unknown"
+}
+
+// Object 必填字段使用 t.type，可选字段使用 t.partial
+func (iotsEmitter) Object(fields []validatorField) string {
+	var required, optional []string
+	for _, field := range fields {
+		line := fmt.Sprintf("    %s: %s", field.Key, field.Expr)
+		if field.Optional {
+			optional = append(optional, line)
+		} else {
+			required = append(required, line)
+		}
+	}
+	switch {
+	case len(required) > 0 && len(optional) > 0:
+		return "t.intersection([\n  t.type({\n" + strings.Join(required, ",\n") + "\n  }),\n  t.partial({\n" +
+			strings.Join(optional, ",\n") + "\n  })\n  ])"
+	case len(optional) > 0:
+		return "t.partial({\n" + strings.Join(optional, ",\n") + "\n  })"
+	case len(required) > 0:
+		return "t.type({\n" + strings.Join(required, ",\n") + "\n  })"
+	default:
+		return "t.type({})"
+	}
+}
+
+func (iotsEmitter) Extend(base, expr string) string {
+	return fmt.Sprintf("t.intersection([%sCodec, %s])", base, expr)
+}
+
+func (iotsEmitter) Ref(typeName string) string {
+	return typeName + "Codec"
+}
+
+// Tuple io-ts 无法表达带剩余元素的元组，此时退化为 t.any
+func (iotsEmitter) Tuple(elements []string, rest string) string {
+	if rest != "" {
+		return "t.any"
+	}
+	return "t.tuple([" + strings.Join(elements, ", ") + "])"
+}
+
+func (iotsEmitter) Array(element string, t ir.Type) string {
+	return "t.array(" + element + ")"
+}
+
+func (iotsEmitter) Record() string {
+	return "t.record(t.string, t.string)"
+}
+
+func (iotsEmitter) Literals(values []string) string {
+	var keys []string
+	for _, value := range values {
+		keys = append(keys, value+": null")
+	}
+	return "t.keyof({ " + strings.Join(keys, ", ") + " })"
+}
+
+func (iotsEmitter) Primitive(t ir.Type) string {
+	switch t.Kind {
+	case "string":
+		return "t.string"
+	case "integer", "number":
+		return "t.number"
+	case "boolean":
+		return "t.boolean"
+	case "object":
+		return "t.UnknownRecord"
+	default:
+		return "t.unknown"
+	}
+}
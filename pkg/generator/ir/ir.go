@@ -0,0 +1,144 @@
+// ir.go
+
+// Package ir 是 OpenAPI 文档解析后、模板渲染前的中间表示：接口、模型、枚举以及它们之间的引用。
+// 所有输出（TypeScript 类型、函数、校验器等）都从 IR 生成，IR 也可以序列化为 JSON 供外部工具使用。
+package ir
+
+// API 一份文档的中间表示，过滤和命名规则已经应用
+type API struct {
+	Operations []Operation `json:"operations"` // 按路径排序，同一路径按 POST、GET、PUT、DELETE 排列
+	Models     []Model     `json:"models"`     // 按名称排序
+	Enums      []Enum      `json:"enums"`      // 按名称排序
+}
+
+// Operation 一个接口
+type Operation struct {
+	ID         string    `json:"id,omitempty"` // operationId
+	Name       string    `json:"name"`         // 函数名称，模块内唯一
+	Module     string    `json:"module"`       // 所属模块（目录）名称
+	Method     string    `json:"method"`       // 大写的 HTTP 方法
+	Path       string    `json:"path"`
+	Summary    string    `json:"summary,omitempty"`
+	Tags       []string  `json:"tags,omitempty"`
+	Request    *Type     `json:"request,omitempty"`    // 请求体或参数合成的请求类型，为空表示没有参数
+	Body       bool      `json:"body,omitempty"`       // 有请求体，Request 为请求体类型，原样发送；否则 Request 是合成的请求类型，其中的路径参数替换后从参数中去除
+	PathParams []Field   `json:"pathParams,omitempty"` // 请求体类型中没有的路径参数；合成的请求类型已包含全部路径参数
+	Response   *Type     `json:"response,omitempty"`   // 200 响应类型，为空表示没有响应体
+	Examples   []Example `json:"examples,omitempty"`   // 200 响应的示例，按名称排序
+	Refs       []string  `json:"refs,omitempty"`       // 请求体、响应和参数直接引用的模型
+}
+
+// Example 响应示例
+type Example struct {
+	Name  string      `json:"name"` // 单个 example 命名为 default
+	Value interface{} `json:"value"`
+}
+
+// Model 组件 schema 或由路径参数和查询参数合成的请求类型
+type Model struct {
+	Name        string   `json:"name"`     // 原始名称，可能带命名空间，例如 pkg.User
+	TypeName    string   `json:"typeName"` // 去除命名空间后的类型名称
+	Description string   `json:"description,omitempty"`
+	Extends     []string `json:"extends,omitempty"` // allOf 基类的原始名称，循环继承已打破
+	Alias       *Type    `json:"alias,omitempty"`   // 非 object 的 schema 生成类型别名
+	Fields      []Field  `json:"fields,omitempty"`  // 组件 schema 按名称排序，请求类型按参数顺序
+	Synthetic   bool     `json:"synthetic,omitempty"`
+}
+
+// Field 模型字段
+type Field struct {
+	Name        string `json:"name"`
+	Type        Type   `json:"type"`
+	Required    bool   `json:"required,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Enum 字符串枚举
+type Enum struct {
+	Name        string   `json:"name"`
+	TypeName    string   `json:"typeName"`
+	Description string   `json:"description,omitempty"`
+	Values      []string `json:"values"` // 按值排序
+	Members     []Member `json:"members"`
+}
+
+// Member 枚举成员，Key 已按命名规则转换
+type Member struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Kind 类型种类
+type Kind string
+
+const (
+	String  Kind = "string"
+	Integer Kind = "integer"
+	Number  Kind = "number"
+	Boolean Kind = "boolean"
+	Object  Kind = "object"  // 没有字段定义的对象
+	Any     Kind = "any"     // 未声明类型
+	Unknown Kind = "unknown" // 无法确定的类型，例如循环别名
+	Ref     Kind = "model"   // 引用模型
+	EnumRef Kind = "enum"    // 引用枚举，Ref 为空时为内联枚举，取值在 Values 中
+	Array   Kind = "array"
+	Map     Kind = "map" // 值类型在 Items 中
+	Tuple   Kind = "tuple"
+)
+
+// Type 类型表达式
+type Type struct {
+	Kind        Kind         `json:"kind"`
+	Ref         string       `json:"ref,omitempty"` // 引用的模型或枚举原始名称
+	Format      string       `json:"format,omitempty"`
+	Items       *Type        `json:"items,omitempty"`    // 数组元素或字典值
+	Elements    []Type       `json:"elements,omitempty"` // 元组元素
+	Rest        *Type        `json:"rest,omitempty"`     // 元组剩余元素
+	Values      []string     `json:"values,omitempty"`   // 内联枚举的取值
+	Constraints *Constraints `json:"constraints,omitempty"`
+	TSType      string       `json:"tsType,omitempty"`   // x-moonbeam-type 或 -type-mapping 指定的 TypeScript 类型，代替按 Kind 推导的类型；其他语言仍按 Kind 生成
+	TSImport    string       `json:"tsImport,omitempty"` // 导入 TSType 的模块，为空时不需要导入
+}
+
+// Constraints 校验约束；3.1 的数值 exclusiveMinimum 转换为 Minimum + ExclusiveMinimum
+type Constraints struct {
+	MinLength        *int     `json:"minLength,omitempty"`
+	MaxLength        *int     `json:"maxLength,omitempty"`
+	Pattern          string   `json:"pattern,omitempty"`
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum bool     `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum bool     `json:"exclusiveMaximum,omitempty"`
+	MultipleOf       *float64 `json:"multipleOf,omitempty"`
+	MinItems         *int     `json:"minItems,omitempty"`
+	MaxItems         *int     `json:"maxItems,omitempty"`
+	UniqueItems      bool     `json:"uniqueItems,omitempty"`
+}
+
+// Model 返回指定原始名称的模型
+func (a *API) Model(name string) (*Model, bool) {
+	for i := range a.Models {
+		if a.Models[i].Name == name {
+			return &a.Models[i], true
+		}
+	}
+	return nil, false
+}
+
+// Refs 返回类型直接引用的模型和枚举原始名称
+func (t Type) Refs() []string {
+	var refs []string
+	if t.Ref != "" {
+		refs = append(refs, t.Ref)
+	}
+	if t.Items != nil {
+		refs = append(refs, t.Items.Refs()...)
+	}
+	for _, element := range t.Elements {
+		refs = append(refs, element.Refs()...)
+	}
+	if t.Rest != nil {
+		refs = append(refs, t.Rest.Refs()...)
+	}
+	return refs
+}
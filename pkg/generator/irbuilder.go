@@ -0,0 +1,431 @@
+// irbuilder.go
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// parseSpec 解析文档并应用过滤和命名规则
+func (r *run) parseSpec(data []byte) (*OpenAPI, error) {
+	tracker := r.startProgress(StageParse, 1)
+	// 先校验结构再解码，字段类型错误时报告全部问题和位置，而不是只有解码错误
+	if err := r.checkSpec(data); err != nil {
+		return nil, err
+	}
+	api, err := r.decodeOpenAPI(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse OpenAPI: %w", err)
+	}
+	if err := r.checkRefs(data); err != nil {
+		return nil, err
+	}
+	r.applySkips(api)
+	r.filterSpec(api, r.operationFilter)
+	if err := r.renameSchemas(api); err != nil {
+		return nil, fmt.Errorf("apply naming convention: %w", err)
+	}
+	r.parsedSpec = api
+	tracker.step()
+	return api, nil
+}
+
+// irBuilder 将文档转换为中间表示
+type irBuilder struct {
+	*run
+	api      *OpenAPI
+	resolver *SchemaResolver
+	names    map[string]string // operationKey -> 操作名称，见 operationNames
+	requests map[string]bool   // requestModels 声明的请求类型，operations 只引用其中的类型
+}
+
+// buildIR 生成文档的中间表示，resolver 用于打破循环引用
+func (r *run) buildIR(api *OpenAPI, resolver *SchemaResolver) *ir.API {
+	b := &irBuilder{run: r, api: api, resolver: resolver, names: r.operationNames(api), requests: make(map[string]bool)}
+
+	result := &ir.API{}
+	var names []string
+	for name := range api.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		schema := api.Components.Schemas[name]
+		if len(schema.Enum) > 0 {
+			result.Enums = append(result.Enums, b.enum(name, schema))
+		} else {
+			result.Models = append(result.Models, b.model(name, schema))
+		}
+	}
+
+	result.Models = append(result.Models, b.requestModels()...)
+	sort.SliceStable(result.Models, func(i, j int) bool {
+		return result.Models[i].Name < result.Models[j].Name
+	})
+	result.Operations = b.operations()
+	return result
+}
+
+// enum 字符串枚举，非字符串取值被忽略
+func (b *irBuilder) enum(name string, schema Schema) ir.Enum {
+	values := make([]string, 0, len(schema.Enum))
+	for _, value := range schema.Enum {
+		if str, ok := value.(string); ok {
+			values = append(values, str)
+		}
+	}
+	sort.Strings(values)
+
+	members := make([]ir.Member, 0, len(values))
+	for _, value := range values {
+		members = append(members, ir.Member{Key: b.naming.EnumKey(value), Value: value})
+	}
+	return ir.Enum{
+		Name:        name,
+		TypeName:    cleanRef(name),
+		Description: schema.Description,
+		Values:      values,
+		Members:     members,
+	}
+}
+
+// model 组件 schema 对应的模型
+func (b *irBuilder) model(name string, schema Schema) ir.Model {
+	model := ir.Model{
+		Name:        name,
+		TypeName:    interfaceName(name),
+		Description: schema.Description,
+		Extends:     b.resolver.Bases(name),
+	}
+	switch {
+	case b.resolver.IsCyclicAlias(name):
+		model.Alias = &ir.Type{Kind: ir.Unknown}
+	case schema.Ref != "" || schema.Type == "array" || len(schema.PrefixItems) > 0 || schema.MoonbeamType != "":
+		alias := b.propertyType(schema.asProperty())
+		model.Alias = &alias
+	}
+
+	var keys []string
+	for key := range schema.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		prop := schema.Properties[key]
+		model.Fields = append(model.Fields, ir.Field{
+			Name:        key,
+			Type:        b.propertyType(prop),
+			Required:    contains(schema.Required, key),
+			Description: prop.Description,
+		})
+	}
+	return model
+}
+
+// requestModels 为没有请求体的接口按路径参数和查询参数合成请求类型，同名类型只保留第一个
+func (b *irBuilder) requestModels() []ir.Model {
+	var models []ir.Model
+	seen := make(map[string]bool)
+	for _, path := range b.sortedPaths() {
+		item := b.api.Paths[path]
+		for _, entry := range []struct {
+			op     *Operation
+			method string
+		}{
+			{item.Get, "GET"},
+			{item.Delete, "DELETE"},
+			{item.Put, "PUT"},
+			{item.Post, "POST"},
+		} {
+			op := entry.op
+			if op == nil || len(op.Parameters) == 0 || op.RequestBody != nil {
+				continue
+			}
+			typeName := b.requestTypeName(b.splitVersionName(path, b.names[operationKey(entry.method, path)]))
+			if seen[typeName] {
+				continue
+			}
+			seen[typeName] = true
+			if model, ok := b.requestModel(typeName, op.Parameters); ok {
+				models = append(models, model)
+				b.requests[typeName] = true
+			}
+		}
+	}
+	return models
+}
+
+// requestModel 由路径参数和查询参数组成的请求类型，路径参数总是必填；字段名保持参数的原始名称（例如 filter.name），
+// 由各语言在需要时加引号或转换为合法标识符；没有这两种参数时返回 false
+func (b *irBuilder) requestModel(typeName string, parameters []Parameter) (ir.Model, bool) {
+	model := ir.Model{Name: typeName, TypeName: typeName, Synthetic: true}
+	for _, param := range parameters {
+		if param.In == "query" || param.In == "path" {
+			model.Fields = append(model.Fields, b.parameterField(param))
+		}
+	}
+	return model, len(model.Fields) > 0
+}
+
+// parameterField 参数对应的字段
+func (b *irBuilder) parameterField(param Parameter) ir.Field {
+	return ir.Field{
+		Name: param.Name,
+		Type: b.propertyType(Property{
+			Type:        param.Schema.Type,
+			Format:      param.Schema.Format,
+			Ref:         param.Schema.Ref,
+			Items:       param.Schema.Items,
+			Constraints: param.Schema.Constraints,
+		}),
+		Required:    param.Required || param.In == "path",
+		Description: param.Description,
+	}
+}
+
+// bodyPathParams 请求体类型中没有同名字段的路径参数，由各语言作为额外的参数传入
+func (b *irBuilder) bodyPathParams(op *Operation, request *ir.Type) []ir.Field {
+	var properties map[string]Property
+	if request != nil && request.Kind == ir.Ref {
+		properties = b.api.Components.Schemas[request.Ref].Properties
+	}
+	var fields []ir.Field
+	for _, param := range op.Parameters {
+		if _, ok := properties[param.Name]; param.In == "path" && !ok {
+			fields = append(fields, b.parameterField(param))
+		}
+	}
+	return fields
+}
+
+// operations 按路径排序、同一路径按 POST、GET、PUT、DELETE 的顺序生成接口，函数名取自 operationNames；
+// 没有请求体的接口只在 requestModels 声明了请求类型时才有参数类型
+func (b *irBuilder) operations() []ir.Operation {
+	var operations []ir.Operation
+	for _, path := range b.sortedPaths() {
+		item := b.api.Paths[path]
+		for _, entry := range []struct {
+			op     *Operation
+			method string
+		}{
+			{item.Post, "POST"},
+			{item.Get, "GET"},
+			{item.Put, "PUT"},
+			{item.Delete, "DELETE"},
+		} {
+			op := entry.op
+			if op == nil {
+				continue
+			}
+			baseName := b.names[operationKey(entry.method, path)]
+			module := b.operationModule(path, op)
+			fnName := b.functionName(baseName)
+
+			summary := op.Summary
+			if summary == "" && len(op.Tags) > 0 {
+				summary = baseName + " " + strings.Join(op.Tags, ", ")
+			}
+			operation := ir.Operation{
+				ID:       op.OperationID,
+				Name:     fnName,
+				Module:   module,
+				Method:   entry.method,
+				Path:     path,
+				Summary:  summary,
+				Tags:     op.Tags,
+				Response: b.responseType(op),
+				Refs:     uniqueStrings(operationRefs(op)),
+			}
+			if op.RequestBody != nil {
+				operation.Request = b.requestBodyType(op)
+				operation.Body = true
+				operation.PathParams = b.bodyPathParams(op, operation.Request)
+			} else if typeName := b.requestTypeName(b.splitVersionName(path, baseName)); b.requests[typeName] {
+				operation.Request = &ir.Type{Kind: ir.Ref, Ref: typeName}
+				operation.Refs = uniqueStrings(append(operation.Refs, typeName))
+			}
+			for name, value := range responseExampleValues(op) {
+				operation.Examples = append(operation.Examples, ir.Example{Name: name, Value: value})
+			}
+			sort.Slice(operation.Examples, func(i, j int) bool {
+				return operation.Examples[i].Name < operation.Examples[j].Name
+			})
+			operations = append(operations, operation)
+		}
+	}
+	return operations
+}
+
+// uniqueStrings 去除重复值，保持原有顺序
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// requestBodyType 请求体的类型，只支持 $ref 和元素为 $ref 的数组；有多个内容类型时取排序后第一个支持的
+func (b *irBuilder) requestBodyType(op *Operation) *ir.Type {
+	for _, contentType := range sortedKeys(op.RequestBody.Content) {
+		if t := b.mediaType(op.RequestBody.Content[contentType].Schema); t != nil {
+			return t
+		}
+	}
+	return nil
+}
+
+// responseType 200 响应的类型，支持的 schema 和内容类型的选择同 requestBodyType
+func (b *irBuilder) responseType(op *Operation) *ir.Type {
+	resp, ok := op.Responses["200"]
+	if !ok {
+		return nil
+	}
+	for _, contentType := range sortedKeys(resp.Content) {
+		if t := b.mediaType(resp.Content[contentType].Schema); t != nil {
+			return t
+		}
+	}
+	return nil
+}
+
+// mediaType 请求体或响应 schema 对应的类型，不支持的 schema 返回 nil
+func (b *irBuilder) mediaType(schema MediaSchema) *ir.Type {
+	switch {
+	case schema.RefValue != "":
+		t := b.refType(schema.RefValue)
+		return &t
+	case schema.Type == "array" && schema.Items != nil && schema.Items.RefValue != "":
+		items := b.refType(schema.Items.RefValue)
+		return &ir.Type{Kind: ir.Array, Items: &items}
+	}
+	return nil
+}
+
+func (b *irBuilder) sortedPaths() []string {
+	var paths []string
+	for path := range b.api.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// propertyType 属性的类型，依次检查 $ref、allOf、元组、数组、字典和内联枚举
+func (b *irBuilder) propertyType(p Property) ir.Type {
+	var t ir.Type
+	switch {
+	case p.Ref != "":
+		t = b.refType(p.Ref)
+	case len(p.AllOf) > 0:
+		t = b.elementType(p.AllOf[0])
+	case len(p.PrefixItems) > 0 || (p.Type == "array" && p.Items != nil && len(p.Items.Tuple) > 0):
+		t = b.tupleType(p.PrefixItems, p.Items)
+	case p.Type == "array":
+		t = ir.Type{Kind: ir.Array}
+		if p.Items != nil {
+			items := b.elementType(p.Items.Ref)
+			t.Items = &items
+		}
+	case p.Type == "object" && p.AdditionalProperties != nil && p.AdditionalProperties.Type == "string":
+		t = ir.Type{Kind: ir.Map, Items: &ir.Type{Kind: ir.String}}
+	case len(p.Enum) > 0:
+		t = ir.Type{Kind: ir.EnumRef}
+		for _, value := range p.Enum {
+			if str, ok := value.(string); ok {
+				t.Values = append(t.Values, str)
+			}
+		}
+	default:
+		t = ir.Type{Kind: primitiveKind(p.Type)}
+	}
+	t.Format = p.Format
+	t.Constraints = irConstraints(p.Constraints)
+	t.TSType = strings.TrimSpace(p.MoonbeamType)
+	return b.mapType(t, p.Format)
+}
+
+// tupleType 3.1 prefixItems 或 items 数组对应的元组，prefixItems 之后的 items 作为剩余元素
+func (b *irBuilder) tupleType(prefixItems []Ref, items *Items) ir.Type {
+	elements := prefixItems
+	if len(elements) == 0 && items != nil {
+		elements = items.Tuple
+	}
+	t := ir.Type{Kind: ir.Tuple, Elements: make([]ir.Type, 0, len(elements))}
+	for _, element := range elements {
+		t.Elements = append(t.Elements, b.elementType(element))
+	}
+	if len(prefixItems) > 0 && items != nil && !items.Disabled && len(items.Tuple) == 0 &&
+		(items.RefValue != "" || items.Type != "") {
+		rest := b.elementType(items.Ref)
+		t.Rest = &rest
+	}
+	return t
+}
+
+// elementType 数组元素、元组元素和 allOf 成员，只有引用和基础类型
+func (b *irBuilder) elementType(r Ref) ir.Type {
+	if r.RefValue != "" {
+		return b.refType(r.RefValue)
+	}
+	return b.mapType(ir.Type{Kind: primitiveKind(r.Type)}, r.Format)
+}
+
+// refType 引用组件 schema，枚举和模型分别处理
+func (b *irBuilder) refType(ref string) ir.Type {
+	target := b.resolver.target(ref)
+	if target.Enum {
+		return ir.Type{Kind: ir.EnumRef, Ref: target.Name}
+	}
+	return ir.Type{Kind: ir.Ref, Ref: target.Name}
+}
+
+// primitiveKind 基础类型，未知类型视为 any
+func primitiveKind(typ string) ir.Kind {
+	switch kind := ir.Kind(typ); kind {
+	case ir.String, ir.Integer, ir.Number, ir.Boolean, ir.Object:
+		return kind
+	default:
+		return ir.Any
+	}
+}
+
+// irConstraints 转换校验约束，exclusiveMinimum/exclusiveMaximum 的 3.0 和 3.1 写法统一为边界值 + 是否排除
+func irConstraints(c Constraints) *ir.Constraints {
+	result := ir.Constraints{
+		MinLength:   c.MinLength,
+		MaxLength:   c.MaxLength,
+		Pattern:     c.Pattern,
+		MultipleOf:  c.MultipleOf,
+		MinItems:    c.MinItems,
+		MaxItems:    c.MaxItems,
+		UniqueItems: c.UniqueItems,
+	}
+	result.Minimum, result.ExclusiveMinimum = irBound(c.Minimum, c.ExclusiveMinimum)
+	result.Maximum, result.ExclusiveMaximum = irBound(c.Maximum, c.ExclusiveMaximum)
+	if result == (ir.Constraints{}) {
+		return nil
+	}
+	return &result
+}
+
+// irBound 3.0 中 exclusiveMinimum: true 修饰 minimum，3.1 中 exclusiveMinimum 本身是边界值
+func irBound(bound *float64, exclusive interface{}) (*float64, bool) {
+	switch v := exclusive.(type) {
+	case bool:
+		return bound, v && bound != nil
+	case int:
+		value := float64(v)
+		return &value, true
+	case float64:
+		return &v, true
+	}
+	return bound, false
+}
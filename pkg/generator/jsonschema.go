@@ -0,0 +1,170 @@
+// jsonschema.go
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+	componentRefBase  = "#/components/schemas/"
+)
+
+// openAPIOnlyKeywords JSON Schema 中不存在的 OpenAPI 关键字，输出时移除以兼容 ajv 严格模式
+var openAPIOnlyKeywords = map[string]bool{
+	"nullable":      true,
+	"example":       true,
+	"xml":           true,
+	"externalDocs":  true,
+	"discriminator": true,
+}
+
+// renderJSONSchemas 生成自包含的 JSON Schema，引用的 schema 放入 $defs
+// mode 为 split 时每个 schema 一个文件（key 为 schema 名称），为 bundle 时所有 schema 合并到一个文件（key 为空）
+func (r *run) renderJSONSchemas(data []byte, mode string) (map[string][]byte, error) {
+	var doc struct {
+		Components struct {
+			Schemas map[string]interface{} `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := r.decodeDocument(data, &doc); err != nil {
+		return nil, err
+	}
+	schemas := doc.Components.Schemas
+
+	var names []string
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	files := make(map[string][]byte)
+	if mode == "bundle" {
+		defs := make(map[string]interface{})
+		for _, name := range names {
+			defs[name] = toJSONSchema(schemas[name], "")
+		}
+		out, err := json.MarshalIndent(map[string]interface{}{
+			"$schema": jsonSchemaDialect,
+			"$defs":   defs,
+		}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		files[""] = append(out, '\n')
+		return files, nil
+	}
+
+	for _, name := range names {
+		root, ok := toJSONSchema(schemas[name], name).(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("schema %s is not an object", name)
+		}
+		root["$schema"] = jsonSchemaDialect
+		if _, exists := root["title"]; !exists {
+			root["title"] = name
+		}
+
+		// 收集直接和间接引用的 schema 放入 $defs
+		defs := make(map[string]interface{})
+		pending := collectSchemaRefs(schemas[name])
+		for len(pending) > 0 {
+			dep := pending[0]
+			pending = pending[1:]
+			if _, done := defs[dep]; done || dep == name {
+				continue
+			}
+			schema, exists := schemas[dep]
+			if !exists {
+				return nil, fmt.Errorf("schema %s references unknown schema %s", name, dep)
+			}
+			defs[dep] = toJSONSchema(schema, name)
+			pending = append(pending, collectSchemaRefs(schema)...)
+		}
+		if len(defs) > 0 {
+			root["$defs"] = defs
+		}
+
+		out, err := json.MarshalIndent(root, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		files[name] = append(out, '\n')
+	}
+	return files, nil
+}
+
+// toJSONSchema 将 OpenAPI schema 转换为 JSON Schema：改写 $ref、处理 nullable、移除 OpenAPI 专有关键字
+// root 为当前文件的根 schema 名称，对它的引用改写为 "#"
+func toJSONSchema(node interface{}, root string) interface{} {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			if openAPIOnlyKeywords[key] {
+				continue
+			}
+			if key == "$ref" {
+				if ref, ok := value.(string); ok && strings.HasPrefix(ref, componentRefBase) {
+					name := strings.TrimPrefix(ref, componentRefBase)
+					if name == root {
+						out[key] = "#"
+					} else {
+						out[key] = "#/$defs/" + name
+					}
+					continue
+				}
+			}
+			out[key] = toJSONSchema(value, root)
+		}
+		// OpenAPI 3.0 的 nullable 转换为类型数组
+		if nullable, _ := v["nullable"].(bool); nullable {
+			if t, ok := v["type"].(string); ok {
+				out["type"] = []interface{}{t, "null"}
+			}
+		}
+		return out
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			converted[fmt.Sprint(key)] = value
+		}
+		return toJSONSchema(converted, root)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = toJSONSchema(item, root)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// collectSchemaRefs 收集节点中引用的组件 schema 名称
+func collectSchemaRefs(node interface{}) []string {
+	var refs []string
+	switch v := node.(type) {
+	case map[string]interface{}:
+		var keys []string
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if ref, ok := v[key].(string); ok && key == "$ref" && strings.HasPrefix(ref, componentRefBase) {
+				refs = append(refs, strings.TrimPrefix(ref, componentRefBase))
+				continue
+			}
+			refs = append(refs, collectSchemaRefs(v[key])...)
+		}
+	case []interface{}:
+		for _, item := range v {
+			refs = append(refs, collectSchemaRefs(item)...)
+		}
+	}
+	return refs
+}
@@ -0,0 +1,221 @@
+// keep.go
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// 受保护区域的标记，位于注释符号之后，例如 // moonbeam:keep-start helpers；名称可以省略
+const (
+	keepStart = "moonbeam:keep-start"
+	keepEnd   = "moonbeam:keep-end"
+)
+
+// keepRegion 已有文件中的一个受保护区域
+type keepRegion struct {
+	name   string
+	lines  [][]byte // 包括开始和结束标记，以及紧随其后的空行
+	anchor string   // 区域之后第一行在文件中只出现一次的生成代码，为空时区域放在文件末尾
+	back   int      // 区域位于 anchor 之前第几行非空的生成代码之前，重新生成后放回相同的位置
+	nth    int      // 区域之后没有只出现一次的行时，anchor 是紧随区域的行，nth 是它第几次出现
+}
+
+// HasKeepRegions 判断文件内容中是否有受保护区域
+func HasKeepRegions(data []byte) bool {
+	return bytes.Contains(data, []byte(keepStart))
+}
+
+// PreserveKeepRegions 把已有文件 old 中的受保护区域（// moonbeam:keep-start 到 // moonbeam:keep-end）放回新生成的 data：
+// 模板中有同名区域时替换它的内容，否则放回原来紧随其后的那行生成代码之前，这一行不再生成时放在文件末尾。
+// moved 返回放到文件末尾的区域名称（没有名称时为第几个区域）；old 中的标记不成对时返回错误，不覆盖已有文件
+func PreserveKeepRegions(old, data []byte) (result []byte, moved []string, err error) {
+	regions, err := parseKeepRegions(old)
+	if err != nil || len(regions) == 0 {
+		return data, nil, err
+	}
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	named := make(map[string]*keepRegion)
+	for _, region := range regions {
+		if region.name != "" {
+			named[region.name] = region
+		}
+	}
+
+	// 新内容中模板生成的同名区域，用已有的内容替换
+	var out [][]byte
+	used := make(map[*keepRegion]bool)
+	for i := 0; i < len(lines); i++ {
+		name, ok := keepMarker(lines[i], keepStart)
+		region := named[name]
+		if !ok || region == nil || used[region] {
+			out = append(out, lines[i])
+			continue
+		}
+		end := i + 1
+		for end < len(lines) {
+			if _, ok := keepMarker(lines[end], keepEnd); ok {
+				break
+			}
+			end++
+		}
+		if end == len(lines) {
+			out = append(out, lines[i])
+			continue
+		}
+		used[region] = true
+		out = append(out, region.lines...)
+		i = end
+	}
+
+	// 其余区域放回锚定的行之前
+	var keyed []int // 非空行的下标
+	occurrences := make(map[string][]int)
+	for i, line := range out {
+		if key := keepAnchorKey(line); key != "" {
+			occurrences[key] = append(occurrences[key], len(keyed))
+			keyed = append(keyed, i)
+		}
+	}
+	before := make(map[int][]*keepRegion)
+	var tail []*keepRegion
+	for n, region := range regions {
+		if used[region] || region.anchor == "" {
+			if !used[region] {
+				tail = append(tail, region)
+			}
+			continue
+		}
+		positions := occurrences[region.anchor]
+		nth := max(region.nth, 1)
+		if len(positions) < nth || positions[nth-1] < region.back {
+			tail = append(tail, region)
+			name := region.name
+			if name == "" {
+				name = fmt.Sprintf("#%d", n+1)
+			}
+			moved = append(moved, name)
+			continue
+		}
+		i := keyed[positions[nth-1]-region.back]
+		before[i] = append(before[i], region)
+	}
+
+	var buf bytes.Buffer
+	for i, line := range out {
+		for _, region := range before[i] {
+			for _, l := range region.lines {
+				buf.Write(l)
+			}
+		}
+		buf.Write(line)
+	}
+	for _, region := range tail {
+		// 与之前的生成代码之间空一行
+		if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n\n")) {
+			buf.WriteByte('\n')
+		}
+		for _, l := range region.lines {
+			buf.Write(l)
+		}
+	}
+	return buf.Bytes(), moved, nil
+}
+
+// parseKeepRegions 读取已有文件中的受保护区域
+func parseKeepRegions(data []byte) ([]*keepRegion, error) {
+	if !HasKeepRegions(data) {
+		return nil, nil
+	}
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	var regions []*keepRegion
+	var current, last *keepRegion // last 刚结束的区域，之后的空行属于它
+	start := 0
+	var keys []string                 // 区域之外的非空行
+	next := make(map[*keepRegion]int) // 区域之后第一行非空行在 keys 中的下标
+	count := make(map[string]int)
+	for i, line := range lines {
+		if current != nil {
+			current.lines = append(current.lines, line)
+			if _, ok := keepMarker(line, keepEnd); ok {
+				next[current] = len(keys)
+				last, current = current, nil
+			} else if _, ok := keepMarker(line, keepStart); ok {
+				return nil, fmt.Errorf("line %d: %s inside the region started at line %d", i+1, keepStart, start+1)
+			}
+			continue
+		}
+		if name, ok := keepMarker(line, keepStart); ok {
+			current = &keepRegion{name: name, lines: [][]byte{line}}
+			regions = append(regions, current)
+			start = i
+			continue
+		}
+		if last != nil && len(bytes.TrimSpace(line)) == 0 && i < len(lines)-1 {
+			last.lines = append(last.lines, line)
+			continue
+		}
+		last = nil
+		if _, ok := keepMarker(line, keepEnd); ok {
+			return nil, fmt.Errorf("line %d: %s without %s", i+1, keepEnd, keepStart)
+		}
+		if key := keepAnchorKey(line); key != "" {
+			keys = append(keys, key)
+			count[key]++
+		}
+	}
+	if current != nil {
+		return nil, fmt.Errorf("line %d: %s without %s", start+1, keepStart, keepEnd)
+	}
+
+	for _, region := range regions {
+		first := next[region]
+		if first == len(keys) {
+			// 位于文件末尾
+			continue
+		}
+		// 优先锚定在之后第一行只出现一次的行上，例如函数的注释或声明，生成的内容增减时位置不变
+		for j := first; j < len(keys); j++ {
+			if count[keys[j]] == 1 {
+				region.anchor, region.back = keys[j], j-first
+				break
+			}
+		}
+		if region.anchor == "" {
+			region.anchor = keys[first]
+			for _, key := range keys[:first+1] {
+				if key == region.anchor {
+					region.nth++
+				}
+			}
+		}
+	}
+	return regions, nil
+}
+
+// keepMarker 判断 line 是否为 marker 标记行，返回标记之后的区域名称
+func keepMarker(line []byte, marker string) (string, bool) {
+	text := strings.TrimSpace(string(line))
+	text = strings.TrimSpace(strings.TrimLeft(text, "/#"))
+	if !strings.HasPrefix(text, marker) {
+		return "", false
+	}
+	rest := text[len(marker):]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return "", false
+	}
+	return strings.TrimSpace(rest), true
+}
+
+// keepAnchorKey 用于锚定的行内容：忽略首尾空白和行尾，空行和头部注释不作为锚定行
+func keepAnchorKey(line []byte) string {
+	text := strings.TrimSpace(string(line))
+	if text == "" || strings.Contains(text, hashMarker) || strings.Contains(text, "Code generated by moonbeam") {
+		return ""
+	}
+	return text
+}
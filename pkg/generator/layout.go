@@ -0,0 +1,214 @@
+// layout.go
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// layouts 支持的目录结构：modules 每个模块一个目录，类型在 types/index.ts；
+// split 每个类型和枚举一个文件 models/<类型>.ts，每个模块的函数一个文件 api/<模块>.ts
+var layouts = []string{"modules", "split"}
+
+// validateLayout 校验 -layout 及其组合
+func validateLayout(o Options) error {
+	if !contains(layouts, o.Layout) {
+		return fmt.Errorf("unsupported -layout %q, expected one of %s", o.Layout, strings.Join(layouts, ", "))
+	}
+	if o.Layout == "modules" {
+		return nil
+	}
+	// 这些选项决定类型和模块文件的位置，与 split 的目录结构冲突
+	for _, option := range []struct {
+		name string
+		set  bool
+	}{
+		{"-single-file", o.SingleFile != ""}, {"-workspace", o.Workspace}, {"-group-types", o.GroupTypes}, {"-group-enums", o.GroupEnums},
+	} {
+		if option.set {
+			return fmt.Errorf("%s is not supported with -layout %s", option.name, o.Layout)
+		}
+	}
+	return nil
+}
+
+// writeModelFiles -layout split 时把每个接口和枚举生成到 models/<类型>.ts，文件之间用 import type 互相引用；
+// models/index.ts 重新导出全部类型，代替 types/index.ts 和 types/enum.ts
+func (r *run) writeModelFiles(interfaceTmpl *template.Template, api *ir.API, interfaces map[string]string, typeRefs map[string][]string, typeImports map[string]map[string]map[string]bool) {
+	for _, op := range api.Operations {
+		if op.Module == "models" {
+			r.logger.Error("module models clashes with the models directory of -layout split, rename it with x-moonbeam-module or -group-by")
+			return
+		}
+	}
+	enumFileTmpl, err := r.parseTemplate("templates/enum-file.tmpl")
+	if err != nil {
+		r.logger.Error("parse enum file template failed", "err", err)
+		return
+	}
+
+	// 文档中的名称 -> 生成的类型名称，也是文件名
+	typeNames := make(map[string]string)
+	for _, model := range api.Models {
+		typeNames[model.Name] = model.TypeName
+	}
+	for _, enum := range api.Enums {
+		typeNames[enum.Name] = enum.TypeName
+	}
+	typeName := func(name string) string {
+		if typeName, ok := typeNames[name]; ok {
+			return typeName
+		}
+		return interfaceName(name)
+	}
+
+	// 生成相同文件名的类型会互相覆盖，例如 admin.v1.Team 和 team.v1.Team 都生成 models/Team.ts；
+	// 不区分大小写的文件系统上 Team.ts 和 TEAM.ts 也是同一个文件
+	sources := make(map[string][]string) // 小写的文件名 -> 文档中的名称
+	for name := range interfaces {
+		key := strings.ToLower(typeName(name))
+		sources[key] = append(sources[key], name)
+	}
+	for _, enum := range api.Enums {
+		key := strings.ToLower(enum.TypeName)
+		sources[key] = append(sources[key], enum.Name)
+	}
+	collided := false
+	for _, key := range sortedKeys(sources) {
+		if names := sources[key]; len(names) > 1 {
+			sort.Strings(names)
+			r.logger.Error("schemas generate the same model file in -layout split, keep their namespaces with -namespaces prefix",
+				"file", "models/"+typeName(names[0])+".ts", "schemas", strings.Join(names, ", "))
+			collided = true
+		}
+	}
+	if collided {
+		return
+	}
+
+	var exports []string
+	for _, name := range sortedKeys(interfaces) {
+		modelName := typeName(name)
+		filename := "models/" + modelName + ".ts"
+		data := InterfaceFileData{
+			ModuleName:  "models",
+			Interfaces:  map[string]string{name: interfaces[name]},
+			SortedNames: []string{name},
+		}
+		// 枚举在类型定义中只作为类型使用，与其他模型一样用 import type 导入
+		refs := make(map[string]bool)
+		for _, ref := range typeRefs[name] {
+			if ref != name {
+				refs[typeName(ref)] = true
+			}
+		}
+		for _, ref := range sortedKeys(refs) {
+			data.TypeImports = append(data.TypeImports, typeImport{Names: []string{ref}, From: "./" + ref + ".ts"})
+		}
+		data.TypeImports = append(data.TypeImports, typeImportsFor(filename, []string{name}, typeImports)...)
+
+		var buf bytes.Buffer
+		if err := interfaceTmpl.Execute(&buf, data); err != nil {
+			r.logger.Error("interface template execution failed", "model", name, "err", err)
+			continue
+		}
+		r.writeFile(filename, buf.Bytes())
+		delete(interfaces, name)
+		exports = append(exports, "./"+modelName+".ts")
+	}
+	for _, enum := range api.Enums {
+		var buf bytes.Buffer
+		if err := enumFileTmpl.Execute(&buf, EnumFileData{Enums: []EnumData{newEnumData(enum)}}); err != nil {
+			r.logger.Error("enum file template execution failed", "enum", enum.Name, "err", err)
+			continue
+		}
+		r.writeFile("models/"+enum.TypeName+".ts", buf.Bytes())
+		exports = append(exports, "./"+enum.TypeName+".ts")
+	}
+	if len(exports) == 0 {
+		return
+	}
+	sort.Strings(exports)
+
+	var buf bytes.Buffer
+	if err := interfaceTmpl.Execute(&buf, InterfaceFileData{ModuleName: "models", Exports: exports}); err != nil {
+		r.logger.Error("interface template execution failed", "model", "index", "err", err)
+		return
+	}
+	r.writeFile("models/index.ts", buf.Bytes())
+	r.logger.Debug("generate model files", "models", len(exports))
+}
+
+// layoutFiles -layout split 时把模块目录中的文件移到 api/：<模块>/index.ts 改为 api/<模块>.ts，
+// 其他文件改为 api/<模块>.<文件名>，例如 user/hooks.ts 改为 api/user.hooks.ts；
+// 所有相对导入按新的位置改写，指向 types/index.ts 和 types/enum.ts 的导入改为 models/index.ts
+func layoutFiles(files Files, api *ir.API) Files {
+	modules := make(map[string]bool)
+	for _, op := range api.Operations {
+		modules[op.Module] = true
+	}
+	moved := map[string]string{"types/index.ts": "models/index.ts", "types/enum.ts": "models/index.ts"}
+	for name := range files {
+		if module, rest, found := strings.Cut(name, "/"); found && modules[module] {
+			if rest == "index.ts" {
+				moved[name] = "api/" + module + ".ts"
+			} else {
+				moved[name] = "api/" + module + "." + strings.ReplaceAll(rest, "/", ".")
+			}
+		}
+	}
+	return moveFiles(files, moved)
+}
+
+// moveFiles 按 moved（原文件名 -> 新文件名）移动文件，并按新的位置改写所有相对导入
+func moveFiles(files Files, moved map[string]string) Files {
+	result := make(Files, len(files))
+	for name, data := range files {
+		target := name
+		if to, ok := moved[name]; ok {
+			target = to
+		}
+		if aliasSourceExts[path.Ext(name)] {
+			data = rewriteImports(data, func(imp moduleImport) string {
+				if !isRelativeSpecifier(imp.Specifier) {
+					return imp.Specifier
+				}
+				imported := path.Join(path.Dir(name), imp.Specifier)
+				if to, ok := moved[imported]; ok {
+					imported = to
+				} else if target == name {
+					return imp.Specifier
+				}
+				return relativeSpecifier(path.Dir(target), imported)
+			})
+		}
+		result[target] = data
+	}
+	return result
+}
+
+// relativeSpecifier 从目录 dir 导入 target 的相对路径，两者都相对于输出目录，例如 api 导入 models/index.ts 为 ../models/index.ts
+func relativeSpecifier(dir, target string) string {
+	if dir == "." {
+		dir = ""
+	}
+	var up []string
+	for dir != "" && dir != target && !strings.HasPrefix(target, dir+"/") {
+		up = append(up, "..")
+		dir = path.Dir(dir)
+		if dir == "." {
+			dir = ""
+		}
+	}
+	rest := strings.TrimPrefix(strings.TrimPrefix(target, dir), "/")
+	if len(up) == 0 {
+		return "./" + rest
+	}
+	return strings.Join(append(up, rest), "/")
+}
@@ -0,0 +1,210 @@
+// merge.go
+package generator
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// mergeExts 支持合并的文件类型
+var mergeExts = map[string]bool{".ts": true, ".tsx": true, ".mts": true, ".cts": true}
+
+// CanMerge 判断文件是否支持按声明合并
+func CanMerge(filename string) bool {
+	return mergeExts[filepath.Ext(filename)]
+}
+
+// declarationPattern 顶层声明的名称
+var declarationPattern = regexp.MustCompile(`^(?:export\s+)?(?:default\s+)?(?:declare\s+)?(?:abstract\s+)?(?:async\s+)?(?:function\*?|interface|type|const|let|var|class|enum|namespace)\s+([A-Za-z_$][\w$]*)`)
+
+// tsStatement TypeScript 文件中的一条顶层语句及其之前的注释
+type tsStatement struct {
+	key    string // 合并时识别语句的键：声明的名称，import / export ... from 为整条语句；为空时不参与合并
+	lines  [][]byte
+	first  int // 第一行（包括注释）在文件中的下标
+	end    int // 最后一行之后的下标
+	isImpt bool
+}
+
+// Declarations 返回生成的 TypeScript 文件中顶层语句的键：函数、接口、类型等声明的名称，以及 import 和 export ... from 语句，
+// 用于下次 -merge 时区分已不再生成的声明和用户添加的声明
+func Declarations(data []byte) []string {
+	var keys []string
+	for _, statement := range tsStatements(data) {
+		if statement.key != "" {
+			keys = append(keys, statement.key)
+		}
+	}
+	return keys
+}
+
+// MergeDeclarations 按顶层语句合并：新生成的 data 中的声明替换已有文件 old 中的同名声明，old 中用户添加的声明、import 和
+// export ... from 保留下来，import 放在生成的 import 之后，其余放在文件末尾；generated 是上次生成的键（见 Declarations），
+// 其中的声明已不再生成，不会保留；没有上次生成的记录时 old 中多出的声明都视为用户添加的
+func MergeDeclarations(old, data []byte, generated []string) []byte {
+	previous := make(map[string]bool, len(generated))
+	for _, key := range generated {
+		previous[key] = true
+	}
+	statements := tsStatements(data)
+	current := make(map[string]bool, len(statements))
+	for _, statement := range statements {
+		current[statement.key] = true
+	}
+	var imports, declarations [][]byte
+	for _, statement := range tsStatements(old) {
+		if statement.key == "" || current[statement.key] || previous[statement.key] {
+			continue
+		}
+		current[statement.key] = true
+		text := bytes.TrimRight(bytes.Join(statement.lines, nil), " \t\r\n")
+		if statement.isImpt {
+			imports = append(imports, text)
+		} else {
+			declarations = append(declarations, text)
+		}
+	}
+	if len(imports) == 0 && len(declarations) == 0 {
+		return data
+	}
+
+	newline := []byte("\n")
+	if bytes.Contains(data, []byte("\r\n")) {
+		newline = []byte("\r\n")
+	}
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	// import 放在最后一条生成的 import 之后，没有 import 时放在第一条语句之前
+	at := len(lines)
+	for _, statement := range statements {
+		if statement.isImpt {
+			at = statement.end
+		}
+	}
+	if at == len(lines) && len(statements) > 0 {
+		at = statements[0].first
+	}
+	var buf bytes.Buffer
+	for i, line := range lines {
+		if i == at {
+			for _, text := range imports {
+				buf.Write(text)
+				buf.Write(newline)
+			}
+		}
+		buf.Write(line)
+	}
+	if at == len(lines) {
+		for _, text := range imports {
+			buf.Write(text)
+			buf.Write(newline)
+		}
+	}
+	for _, text := range declarations {
+		if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.Write(newline)
+		}
+		buf.Write(newline)
+		buf.Write(text)
+		buf.Write(newline)
+	}
+	return buf.Bytes()
+}
+
+// tsStatements 按行切分顶层语句：不缩进、不以 } ) ] 开头的行开始一条新语句，紧挨在它之前的注释属于这条语句；
+// 生成的代码和 prettier 格式化后的代码都满足这个约定。受保护区域（见 PreserveKeepRegions）另行处理，不计入任何语句
+func tsStatements(data []byte) []tsStatement {
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	var statements []tsStatement
+	var current *tsStatement
+	comment := -1 // 尚未归属的注释开始的行
+	inBlock := false
+	inKeep := false
+	for i, line := range lines {
+		text := strings.TrimRight(string(line), "\r\n")
+		trimmed := strings.TrimSpace(text)
+		if _, ok := keepMarker(line, keepStart); ok || inKeep {
+			_, end := keepMarker(line, keepEnd)
+			inKeep = !end
+			current, comment = nil, -1
+			continue
+		}
+		switch {
+		case inBlock:
+			inBlock = !strings.Contains(text, "*/")
+			continue
+		case trimmed == "":
+			if current != nil {
+				current.lines = append(current.lines, line)
+			}
+			comment = -1
+			continue
+		case text[0] == ' ' || text[0] == '\t' || strings.ContainsRune("})]", rune(text[0])):
+			if current != nil {
+				current.lines = append(current.lines, line)
+				current.end = i + 1
+			}
+			continue
+		case strings.HasPrefix(text, "/*"):
+			if comment < 0 {
+				comment = i
+			}
+			inBlock = !strings.Contains(text[2:], "*/")
+			current = nil
+			continue
+		case strings.HasPrefix(text, "//"):
+			if comment < 0 {
+				comment = i
+			}
+			current = nil
+			continue
+		}
+		first := i
+		if comment >= 0 {
+			first = comment
+		}
+		statements = append(statements, tsStatement{lines: append([][]byte(nil), lines[first:i+1]...), first: first, end: i + 1})
+		current = &statements[len(statements)-1]
+		comment = -1
+	}
+	for i := range statements {
+		statements[i].key, statements[i].isImpt = statementKey(statements[i].lines)
+	}
+	return statements
+}
+
+// statementKey 语句的键，见 tsStatement
+func statementKey(lines [][]byte) (string, bool) {
+	var code []string
+	inBlock := false
+	for _, line := range lines {
+		text := strings.TrimSpace(string(line))
+		switch {
+		case inBlock:
+			inBlock = !strings.Contains(text, "*/")
+			continue
+		case strings.HasPrefix(text, "/*"):
+			inBlock = !strings.Contains(text[2:], "*/")
+			continue
+		case strings.HasPrefix(text, "//"), text == "":
+			continue
+		}
+		code = append(code, text)
+	}
+	if len(code) == 0 {
+		return "", false
+	}
+	statement := strings.Join(strings.Fields(strings.Join(code, " ")), " ")
+	switch {
+	case strings.HasPrefix(statement, "import "), strings.HasPrefix(statement, "import{"):
+		return statement, true
+	case strings.HasPrefix(statement, "export *"), strings.HasPrefix(statement, "export {") && strings.Contains(statement, " from "),
+		strings.HasPrefix(statement, "export type {") && strings.Contains(statement, " from "):
+		return statement, false
+	}
+	if m := declarationPattern.FindStringSubmatch(statement); m != nil {
+		return m[1], false
+	}
+	return "", false
+}
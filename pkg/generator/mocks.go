@@ -0,0 +1,216 @@
+// mocks.go
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// mockEmitter 生成基于 faker 的模拟数据工厂函数（mockXxx）
+type mockEmitter struct{}
+
+func (mockEmitter) Header(typeNames, enumNames []string) string {
+	var b strings.Builder
+	b.WriteString("// 模拟数据工厂，基于 @faker-js/faker 按类型和格式生成数据\n")
+	b.WriteString("import { faker } from '@faker-js/faker'\n")
+	if len(typeNames) > 0 {
+		b.WriteString("import type {\n  " + strings.Join(typeNames, ",\n  ") + "\n} from './index.ts'\n")
+	}
+	if len(enumNames) > 0 {
+		b.WriteString("import {\n  " + strings.Join(enumNames, ",\n  ") + "\n} from './enum.ts'\n")
+	}
+	b.WriteString(`
+// 嵌套引用的最大深度，超过后只生成必填字段，数组只生成最少的元素
+const MAX_DEPTH = 3
+let depth = 0
+
+// nested 生成必填的嵌套类型数据，每层嵌套计一次深度；只由必填字段构成的循环没有有限的数据，超过两倍最大深度时返回 undefined
+function nested<T>(fn: () => T): T {
+  if (depth >= MAX_DEPTH * 2) {
+    return undefined as T
+  }
+  depth++
+  try {
+    return fn()
+  } finally {
+    depth--
+  }
+}
+
+// optional 生成可选的嵌套类型数据，超过最大深度时返回 undefined
+function optional<T>(fn: () => T): T | undefined {
+  if (depth >= MAX_DEPTH) {
+    return undefined
+  }
+  return nested(fn)
+}
+
+// many 生成数组数据，元素的深度由 nested 计算；超过最大深度时只生成 min 个元素
+function many<T>(fn: () => T, min = 0, max = 3): T[] {
+  const length = depth >= MAX_DEPTH ? min : faker.number.int({ min: Math.min(Math.max(min, 1), max), max })
+  return Array.from({ length }, fn)
+}
+
+// merge 合并对象数据与覆盖字段
+function merge<T>(value: T, overrides?: Partial<T>): T {
+  if (overrides && value && typeof value === 'object' && !Array.isArray(value)) {
+    return { ...value, ...overrides }
+  }
+  return value
+}
+`)
+	return b.String()
+}
+
+func (mockEmitter) Declare(typeName, expr string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n/**\n * mock%s 生成 %s 模拟数据，overrides 覆盖指定字段\n */\n", typeName, typeName)
+	fmt.Fprintf(&b, "export function mock%s(overrides?: Partial<%s>): %s {\n", typeName, typeName, typeName)
+	fmt.Fprintf(&b, "  return merge<%s>(\n    %s,\n    overrides\n  )\n}\n", typeName, expr)
+	return b.String()
+}
+
+func (mockEmitter) Enum(typeName string) string {
+	return fmt.Sprintf("faker.helpers.enumValue(%s)", typeName)
+}
+
+func (mockEmitter) Unknown() string {
+	return "undefined as unknown"
+}
+
+func (mockEmitter) Object(fields []validatorField) string {
+	if len(fields) == 0 {
+		return "{}"
+	}
+	var lines []string
+	for _, field := range fields {
+		expr := field.Expr
+		if field.Optional && field.Ref != "" {
+			expr = fmt.Sprintf("optional(() => mock%s())", field.Ref)
+		}
+		lines = append(lines, fmt.Sprintf("      %s: %s", field.Key, expr))
+	}
+	return "{\n" + strings.Join(lines, ",\n") + "\n    }"
+}
+
+func (mockEmitter) Extend(base, expr string) string {
+	return fmt.Sprintf("{ ...mock%s(), ...%s }", base, expr)
+}
+
+func (mockEmitter) Ref(typeName string) string {
+	return fmt.Sprintf("nested(() => mock%s())", typeName)
+}
+
+func (mockEmitter) Tuple(elements []string, rest string) string {
+	if rest != "" {
+		elements = append(elements, fmt.Sprintf("...many(() => %s)", rest))
+	}
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+func (mockEmitter) Array(element string, t ir.Type) string {
+	c := typeConstraints(t)
+	if c.MinItems == nil && c.MaxItems == nil {
+		return fmt.Sprintf("many(() => %s)", element)
+	}
+	min, max := 0, 3
+	if c.MinItems != nil {
+		min = *c.MinItems
+		if max < min {
+			max = min
+		}
+	}
+	if c.MaxItems != nil {
+		max = *c.MaxItems
+		if min > max {
+			min = max
+		}
+	}
+	return fmt.Sprintf("many(() => %s, %d, %d)", element, min, max)
+}
+
+func (mockEmitter) Record() string {
+	return "{ [faker.lorem.word()]: faker.lorem.word() }"
+}
+
+func (mockEmitter) Literals(values []string) string {
+	return "faker.helpers.arrayElement([" + strings.Join(values, ", ") + "] as const)"
+}
+
+func (mockEmitter) Primitive(t ir.Type) string {
+	switch t.Kind {
+	case "string":
+		return mockString(t)
+	case "integer":
+		return "faker.number.int(" + mockRange(t, "0", "1000", "") + ")"
+	case "number":
+		return "faker.number.float(" + mockRange(t, "0", "1000", ", fractionDigits: 2") + ")"
+	case "boolean":
+		return "faker.datatype.boolean()"
+	case "object":
+		return "{}"
+	default:
+		return "undefined"
+	}
+}
+
+// mockString 按格式和约束生成字符串
+func mockString(t ir.Type) string {
+	c := typeConstraints(t)
+	switch t.Format {
+	case "email":
+		return "faker.internet.email()"
+	case "uuid":
+		return "faker.string.uuid()"
+	case "date-time":
+		return "faker.date.recent().toISOString()"
+	case "date":
+		return "faker.date.recent().toISOString().slice(0, 10)"
+	case "uri", "url":
+		return "faker.internet.url()"
+	case "hostname":
+		return "faker.internet.domainName()"
+	case "ipv4":
+		return "faker.internet.ipv4()"
+	case "ipv6":
+		return "faker.internet.ipv6()"
+	case "int64", "uint64":
+		return "String(faker.number.int({ min: 0, max: 1000000 }))"
+	}
+	if c.Pattern != "" {
+		return "faker.helpers.fromRegExp(" + strconv.Quote(c.Pattern) + ")"
+	}
+	if c.MinLength != nil || c.MaxLength != nil {
+		min, max := 1, 16
+		if c.MinLength != nil {
+			min = *c.MinLength
+			if max < min {
+				max = min
+			}
+		}
+		if c.MaxLength != nil {
+			max = *c.MaxLength
+			if min > max {
+				min = max
+			}
+		}
+		return fmt.Sprintf("faker.string.alpha({ length: { min: %d, max: %d } })", min, max)
+	}
+	return "faker.lorem.word()"
+}
+
+// mockRange 生成 faker.number 的 min/max 参数
+func mockRange(t ir.Type, defaultMin, defaultMax, extra string) string {
+	c := typeConstraints(t)
+	min, max := defaultMin, defaultMax
+	if c.Minimum != nil {
+		min = formatNumber(*c.Minimum)
+	}
+	if c.Maximum != nil {
+		max = formatNumber(*c.Maximum)
+	}
+	return fmt.Sprintf("{ min: %s, max: %s%s }", min, max, extra)
+}
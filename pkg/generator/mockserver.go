@@ -0,0 +1,329 @@
+// mockserver.go
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// mockRoute 模拟服务的单个接口
+type mockRoute struct {
+	method   string
+	path     string
+	segments []string // 路径分段，{name} 匹配任意分段
+	op       *Operation
+}
+
+// MockServer 根据 OpenAPI 文档返回接口示例或随机生成的数据，实现 http.Handler
+type MockServer struct {
+	logger  *slog.Logger
+	schemas map[string]Schema
+	routes  []mockRoute
+	rand    *rand.Rand
+}
+
+// NewMockServer 根据 OpenAPI 文档创建模拟服务，请求日志写入 logger，为空时使用 slog.Default()
+func NewMockServer(spec []byte, logger *slog.Logger) (*MockServer, error) {
+	api, err := ParseOpenAPI(spec)
+	if err != nil {
+		return nil, err
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	s := newMockServer(api)
+	s.logger = logger
+	return s, nil
+}
+
+// Routes 返回模拟的接口数量
+func (s *MockServer) Routes() int {
+	return len(s.routes)
+}
+
+// newMockServer 收集所有接口，静态路径优先于带参数的路径
+func newMockServer(api *OpenAPI) *MockServer {
+	s := &MockServer{
+		schemas: api.Components.Schemas,
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for path, item := range api.Paths {
+		operations := []struct {
+			op     *Operation
+			method string
+		}{
+			{item.Get, "GET"},
+			{item.Post, "POST"},
+			{item.Put, "PUT"},
+			{item.Delete, "DELETE"},
+		}
+		for _, o := range operations {
+			if o.op == nil {
+				continue
+			}
+			s.routes = append(s.routes, mockRoute{
+				method:   o.method,
+				path:     path,
+				segments: strings.Split(strings.Trim(path, "/"), "/"),
+				op:       o.op,
+			})
+		}
+	}
+	sort.Slice(s.routes, func(i, j int) bool {
+		pi, pj := strings.Count(s.routes[i].path, "{"), strings.Count(s.routes[j].path, "{")
+		if pi != pj {
+			return pi < pj
+		}
+		return s.routes[i].path < s.routes[j].path
+	})
+	return s
+}
+
+func (s *MockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	status := s.serve(w, r)
+	s.logger.Info("request", "method", r.Method, "path", r.URL.Path, "status", status, "duration", time.Since(start).Round(time.Microsecond))
+}
+
+// serve 匹配接口并写入响应，返回状态码
+func (s *MockServer) serve(w http.ResponseWriter, r *http.Request) int {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	pathMatched := false
+	for _, route := range s.routes {
+		if !matchSegments(route.segments, segments) {
+			continue
+		}
+		pathMatched = true
+		if route.method != r.Method {
+			continue
+		}
+
+		body, ok := s.response(route.op, r.Header.Get("Prefer"))
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return http.StatusNoContent
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(body); err != nil {
+			s.logger.Error("write mock response failed", "method", r.Method, "path", r.URL.Path, "err", err)
+		}
+		return http.StatusOK
+	}
+
+	if pathMatched {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return http.StatusMethodNotAllowed
+	}
+	http.NotFound(w, r)
+	return http.StatusNotFound
+}
+
+// matchSegments 判断请求路径是否匹配接口路径模板
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) != len(segments) {
+		return false
+	}
+	for i, p := range pattern {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			continue
+		}
+		if p != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// response 优先返回接口示例（可通过 Prefer: example=<name> 指定），否则按响应 schema 生成随机数据
+func (s *MockServer) response(op *Operation, prefer string) (interface{}, bool) {
+	if examples := responseExampleValues(op); len(examples) > 0 {
+		if name := strings.TrimPrefix(prefer, "example="); name != prefer {
+			if value, ok := examples[name]; ok {
+				return value, true
+			}
+		}
+		var names []string
+		for name := range examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return examples[names[0]], true
+	}
+
+	resp, ok := op.Responses["200"]
+	if !ok {
+		return nil, false
+	}
+	for _, contentType := range sortedKeys(resp.Content) {
+		if content := resp.Content[contentType]; content.Schema.RefValue != "" || content.Schema.Type != "" {
+			return s.fakeRef(content.Schema.Ref, 0), true
+		}
+	}
+	return nil, false
+}
+
+// mockMaxDepth 嵌套引用的最大深度，避免递归类型无限展开
+const mockMaxDepth = 3
+
+// fakeSchema 按 schema 生成随机数据
+func (s *MockServer) fakeSchema(name string, depth int) interface{} {
+	schema, ok := s.schemas[name]
+	if !ok || depth > mockMaxDepth {
+		return nil
+	}
+	if len(schema.Enum) > 0 {
+		return schema.Enum[s.rand.Intn(len(schema.Enum))]
+	}
+	if schema.Ref != "" || schema.Type == "array" || len(schema.PrefixItems) > 0 {
+		return s.fakeProperty(schema.asProperty(), depth)
+	}
+
+	result := make(map[string]interface{})
+	for _, base := range schema.AllOf {
+		if merged, ok := s.fakeRef(base, depth+1).(map[string]interface{}); ok {
+			for key, value := range merged {
+				result[key] = value
+			}
+		}
+	}
+	for key, prop := range schema.Properties {
+		if value := s.fakeProperty(prop, depth); value != nil {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// fakeRef 按引用或基础类型生成随机数据
+func (s *MockServer) fakeRef(r Ref, depth int) interface{} {
+	if r.RefValue != "" {
+		return s.fakeSchema(cleanRef(r.RefValue), depth+1)
+	}
+	return s.fakeProperty(Property{Type: r.Type}, depth)
+}
+
+// fakeProperty 按属性类型、格式和约束生成随机数据
+func (s *MockServer) fakeProperty(p Property, depth int) interface{} {
+	switch {
+	case p.Ref != "":
+		return s.fakeRef(Ref{RefValue: p.Ref}, depth)
+	case len(p.AllOf) > 0:
+		return s.fakeRef(p.AllOf[0], depth)
+	case len(p.Enum) > 0:
+		return p.Enum[s.rand.Intn(len(p.Enum))]
+	case len(p.PrefixItems) > 0:
+		var values []interface{}
+		for _, element := range p.PrefixItems {
+			values = append(values, s.fakeRef(element, depth))
+		}
+		return values
+	}
+
+	switch p.Type {
+	case "array":
+		if p.Items == nil || depth >= mockMaxDepth {
+			return []interface{}{}
+		}
+		min, max := 1, 3
+		if p.MinItems != nil && *p.MinItems > min {
+			min = *p.MinItems
+		}
+		if p.MaxItems != nil && *p.MaxItems < max {
+			max = *p.MaxItems
+		}
+		if max < min {
+			max = min
+		}
+		values := []interface{}{}
+		for i := 0; i < min+s.rand.Intn(max-min+1); i++ {
+			values = append(values, s.fakeRef(p.Items.Ref, depth))
+		}
+		return values
+	case "object":
+		if p.AdditionalProperties != nil {
+			return map[string]interface{}{s.word(): s.word()}
+		}
+		return map[string]interface{}{}
+	case "string":
+		return s.fakeString(p)
+	case "integer":
+		min, max := s.bounds(p)
+		return int64(min) + s.rand.Int63n(int64(max-min)+1)
+	case "number":
+		min, max := s.bounds(p)
+		return float64(int((min+s.rand.Float64()*(max-min))*100)) / 100
+	case "boolean":
+		return s.rand.Intn(2) == 0
+	}
+	return nil
+}
+
+// mockWords 随机字符串使用的词表
+var mockWords = []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel", "india", "juliet"}
+
+func (s *MockServer) word() string {
+	return mockWords[s.rand.Intn(len(mockWords))]
+}
+
+// fakeString 按格式生成字符串
+func (s *MockServer) fakeString(p Property) string {
+	switch p.Format {
+	case "email":
+		return fmt.Sprintf("%s%d@example.com", s.word(), s.rand.Intn(1000))
+	case "uuid":
+		b := make([]byte, 16)
+		s.rand.Read(b)
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	case "date-time":
+		return time.Now().Add(-time.Duration(s.rand.Intn(720)) * time.Hour).UTC().Format(time.RFC3339)
+	case "date":
+		return time.Now().AddDate(0, 0, -s.rand.Intn(30)).Format("2006-01-02")
+	case "uri", "url":
+		return "https://example.com/" + s.word()
+	case "hostname":
+		return s.word() + ".example.com"
+	case "ipv4":
+		return fmt.Sprintf("192.168.%d.%d", s.rand.Intn(256), s.rand.Intn(256))
+	case "int64", "uint64":
+		return fmt.Sprint(s.rand.Intn(1000000))
+	}
+	value := s.word()
+	if p.MinLength != nil {
+		for len(value) < *p.MinLength {
+			value += s.word()
+		}
+	}
+	if p.MaxLength != nil && len(value) > *p.MaxLength {
+		value = value[:*p.MaxLength]
+	}
+	return value
+}
+
+// bounds 数值的取值范围，默认 [0, 1000]
+func (s *MockServer) bounds(p Property) (float64, float64) {
+	min, max := 0.0, 1000.0
+	if p.Minimum != nil {
+		min = *p.Minimum
+	}
+	if p.Maximum != nil {
+		max = *p.Maximum
+	}
+	if max < min {
+		max = min
+	}
+	return min, max
+}
@@ -0,0 +1,93 @@
+// namespaces.go
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// namespaceMember 命名空间中的一个类型：Name 为命名空间中的名称，TypeName 为生成的带前缀的类型
+type namespaceMember struct {
+	Name, TypeName string
+	Enum           bool
+}
+
+// writeNamespaces -namespaces namespace 时生成 types/namespaces.ts：按文档中的命名空间声明 TypeScript 命名空间，
+// 其中的类型是生成的带前缀类型的别名，例如 api.v1.Team 是 ApiV1Team；没有带命名空间的 schema 时不生成，返回 false
+func (r *run) writeNamespaces(api *ir.API) bool {
+	namespaces := make(map[string][]namespaceMember)
+	add := func(name, typeName string, enum bool) {
+		original, ok := r.namespacedSchemas[name]
+		if !ok {
+			return
+		}
+		i := strings.LastIndexByte(original, '.')
+		segments := strings.Split(original[:i], ".")
+		for j, segment := range segments {
+			segments[j] = sanitizeIdentifier(segment)
+		}
+		member := namespaceMember{Name: sanitizeIdentifier(original[i+1:]), TypeName: typeName, Enum: enum}
+		if !enum {
+			member.Name = r.naming.Type(member.Name)
+		}
+		namespace := strings.Join(segments, ".")
+		namespaces[namespace] = append(namespaces[namespace], member)
+	}
+	for _, model := range api.Models {
+		add(model.Name, model.TypeName, false)
+	}
+	for _, enum := range api.Enums {
+		add(enum.Name, enum.TypeName, true)
+	}
+	if len(namespaces) == 0 {
+		return false
+	}
+
+	// 顶层命名空间与同名的类型都从根目录的 index.ts 导出，会互相覆盖
+	typeNames := make(map[string]bool)
+	for _, model := range api.Models {
+		typeNames[model.TypeName] = true
+	}
+	for _, enum := range api.Enums {
+		typeNames[enum.TypeName] = true
+	}
+
+	var types, enums []string
+	var body strings.Builder
+	for _, namespace := range sortedKeys(namespaces) {
+		if root, _, _ := strings.Cut(namespace, "."); typeNames[root] {
+			r.logger.Error("namespace clashes with a generated type of the same name", "namespace", namespace, "type", root)
+		}
+		members := namespaces[namespace]
+		sort.Slice(members, func(i, j int) bool { return members[i].Name < members[j].Name })
+		fmt.Fprintf(&body, "\nexport namespace %s {\n", namespace)
+		for _, member := range members {
+			if member.Enum {
+				enums = append(enums, member.TypeName)
+				fmt.Fprintf(&body, "  export const %s = %s\n", member.Name, member.TypeName)
+			} else {
+				types = append(types, member.TypeName)
+			}
+			fmt.Fprintf(&body, "  export type %s = %s\n", member.Name, member.TypeName)
+		}
+		body.WriteString("}\n")
+	}
+
+	var b strings.Builder
+	b.WriteString("// 按文档中的命名空间访问生成的类型，例如 api.v1.Team\n")
+	if len(types) > 0 {
+		sort.Strings(types)
+		fmt.Fprintf(&b, "import type { %s } from './index.ts'\n", strings.Join(types, ", "))
+	}
+	if len(enums) > 0 {
+		sort.Strings(enums)
+		fmt.Fprintf(&b, "import { %s } from './enum.ts'\n", strings.Join(enums, ", "))
+	}
+	b.WriteString(body.String())
+	r.writeFile("types/namespaces.ts", []byte(b.String()))
+	r.logger.Debug("generate namespace file", "file", "types/namespaces.ts", "namespaces", len(namespaces))
+	return true
+}
@@ -0,0 +1,383 @@
+// naming.go
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// splitWords 将名称拆分为单词，按非字母数字字符及大小写边界拆分，例如 getHTTPStatus_code -> get HTTP Status code
+func splitWords(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		prev := runes[i-1]
+		// aB 或 ABc 中的 B 开始新单词
+		if unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+			(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// capitalize 首字母大写，其余小写
+func capitalize(word string) string {
+	runes := []rune(strings.ToLower(word))
+	if len(runes) == 0 {
+		return ""
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// camelCase 例如 user_id -> userId
+func camelCase(s string) string {
+	words := splitWords(s)
+	for i, word := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(word)
+		} else {
+			words[i] = capitalize(word)
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// pascalCase 例如 user_id -> UserId
+func pascalCase(s string) string {
+	words := splitWords(s)
+	for i, word := range words {
+		words[i] = capitalize(word)
+	}
+	return strings.Join(words, "")
+}
+
+// kebabCase 例如 UserId -> user-id
+func kebabCase(s string) string {
+	return strings.ToLower(strings.Join(splitWords(s), "-"))
+}
+
+// snakeCase 例如 UserId -> user_id
+func snakeCase(s string) string {
+	return strings.ToLower(strings.Join(splitWords(s), "_"))
+}
+
+// pluralize 按英文常见规则返回复数形式，例如 user -> users、category -> categories、box -> boxes
+func pluralize(word string) string {
+	lower := strings.ToLower(word)
+	switch {
+	case word == "":
+		return ""
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return word[:len(word)-1] + matchCase("ies", word)
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return word + matchCase("es", word)
+	default:
+		return word + matchCase("s", word)
+	}
+}
+
+// singular 英文复数的简单还原，例如 users -> user，categories -> category
+func singular(word string) string {
+	lower := strings.ToLower(word)
+	switch {
+	case strings.HasSuffix(lower, "ies") && len(word) > 3:
+		return word[:len(word)-3] + matchCase("y", word)
+	case strings.HasSuffix(lower, "sses"), strings.HasSuffix(lower, "xes"), strings.HasSuffix(lower, "ches"), strings.HasSuffix(lower, "shes"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(lower, "s") && !strings.HasSuffix(lower, "ss") && len(word) > 1:
+		return word[:len(word)-1]
+	}
+	return word
+}
+
+// matchCase 全大写单词的后缀同样使用大写，例如 CATEGORY -> CATEGORIES
+func matchCase(suffix, word string) string {
+	if strings.ToUpper(word) == word && strings.ToLower(word) != word {
+		return strings.ToUpper(suffix)
+	}
+	return suffix
+}
+
+// tsReservedWords 不能作为 TypeScript 标识符的保留字
+var tsReservedWords = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true, "continue": true,
+	"debugger": true, "default": true, "delete": true, "do": true, "else": true, "enum": true,
+	"export": true, "extends": true, "false": true, "finally": true, "for": true, "function": true,
+	"if": true, "import": true, "in": true, "instanceof": true, "new": true, "null": true,
+	"return": true, "super": true, "switch": true, "this": true, "throw": true, "true": true,
+	"try": true, "typeof": true, "var": true, "void": true, "while": true, "with": true,
+	"implements": true, "interface": true, "let": true, "package": true, "private": true,
+	"protected": true, "public": true, "static": true, "yield": true, "await": true,
+}
+
+// sanitizeIdentifier 将任意名称转换为合法的 TypeScript 标识符：非法字符替换为下划线，
+// 数字开头或与保留字冲突时加下划线
+func sanitizeIdentifier(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '_' || r == '$' || unicode.IsLetter(r):
+			b.WriteRune(r)
+		case unicode.IsDigit(r):
+			if i == 0 {
+				b.WriteRune('_')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	id := b.String()
+	if id == "" || tsReservedWords[id] {
+		return id + "_"
+	}
+	return id
+}
+
+// enumMemberKey TypeScript 枚举成员名：合法标识符原样使用，数字不能作为成员名，转换为标识符，其余加引号
+func enumMemberKey(key string) string {
+	if identifierPattern.MatchString(key) {
+		return key
+	}
+	if _, err := strconv.ParseFloat(key, 64); err == nil {
+		return sanitizeIdentifier(key)
+	}
+	return objectKey(key)
+}
+
+// singleQuoteEscaper 转义放入单引号字符串字面量的内容
+var singleQuoteEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`)
+
+// NamingConvention 生成代码的命名规则，由 -function-case、-type-prefix 等参数设置
+type NamingConvention struct {
+	FunctionCase string // 函数名：camel、snake
+	TypePrefix   string // 接口名前缀，例如 I
+	TypeSuffix   string // 接口名后缀，例如 Dto
+	DirCase      string // 模块目录：lower、kebab、snake、camel
+	EnumCase     string // 枚举成员名：preserve、upper、pascal、camel
+	Namespaces   string // 带命名空间的 schema 名称（例如 api.v1.Team）：strip 只取最后一段，prefix 改为 ApiV1Team，namespace 另外生成 TypeScript 命名空间 api.v1.Team
+}
+
+// Validate 校验各项取值
+func (n NamingConvention) Validate() error {
+	choices := []struct {
+		flag, value string
+		allowed     []string
+	}{
+		{"-function-case", n.FunctionCase, []string{"camel", "snake"}},
+		{"-dir-case", n.DirCase, []string{"lower", "kebab", "snake", "camel"}},
+		{"-enum-case", n.EnumCase, []string{"preserve", "upper", "pascal", "camel"}},
+		{"-namespaces", n.Namespaces, []string{"strip", "prefix", "namespace"}},
+	}
+	for _, c := range choices {
+		if !contains(c.allowed, c.value) {
+			return fmt.Errorf("unsupported %s %q, expected one of %s", c.flag, c.value, strings.Join(c.allowed, ", "))
+		}
+	}
+	for _, affix := range []string{n.TypePrefix, n.TypeSuffix} {
+		if affix != "" && sanitizeIdentifier(affix) != affix {
+			return fmt.Errorf("type prefix/suffix %q is not a valid identifier", affix)
+		}
+	}
+	return nil
+}
+
+// Function 函数名，name 为 camelCase
+func (n NamingConvention) Function(name string) string {
+	if n.FunctionCase == "snake" {
+		return snakeCase(name)
+	}
+	return name
+}
+
+// Type 接口名，带命名空间的 schema 名称只修改最后一段，例如 api.User -> api.IUser
+func (n NamingConvention) Type(name string) string {
+	if n.TypePrefix == "" && n.TypeSuffix == "" {
+		return name
+	}
+	i := strings.LastIndex(name, ".") + 1
+	return name[:i] + n.TypePrefix + name[i:] + n.TypeSuffix
+}
+
+// Namespace schema 名称中的命名空间改为类型名称的前缀，例如 api.v1.Team -> ApiV1Team；-namespaces strip 时不修改，生成的类型名称只取最后一段
+func (n NamingConvention) Namespace(name string) string {
+	i := strings.LastIndexByte(name, '.')
+	if n.Namespaces == "strip" || i < 0 {
+		return name
+	}
+	var b strings.Builder
+	for _, segment := range strings.Split(name[:i], ".") {
+		b.WriteString(pascalCase(segment))
+	}
+	return b.String() + name[i+1:]
+}
+
+// Dir 模块目录名，name 为 tag
+func (n NamingConvention) Dir(name string) string {
+	switch n.DirCase {
+	case "kebab":
+		return kebabCase(name)
+	case "snake":
+		return snakeCase(name)
+	case "camel":
+		return camelCase(name)
+	default:
+		return strings.ToLower(name)
+	}
+}
+
+// EnumKey 枚举成员名，成员值保持不变；转换后为空时使用原值
+func (n NamingConvention) EnumKey(value string) string {
+	var key string
+	switch n.EnumCase {
+	case "upper":
+		key = strings.ToUpper(snakeCase(value))
+	case "pascal":
+		key = pascalCase(value)
+	case "camel":
+		key = camelCase(value)
+	default:
+		return value
+	}
+	if key == "" {
+		return value
+	}
+	return key
+}
+
+// schemaNamespace schema 在文档中的命名空间，例如 api.v1.Team（改名后为 ApiV1Team）的命名空间为 api.v1；没有命名空间时返回空字符串
+func (r *run) schemaNamespace(name string) string {
+	if original, ok := r.namespacedSchemas[name]; ok {
+		name = original
+	}
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		return name[:i]
+	}
+	return ""
+}
+
+// renameSchemas 按 naming.Namespace 和 naming.Type 重命名 schema（naming.Type 只用于非枚举），并同步修改所有 $ref；
+// -namespaces strip 时去除命名空间后同名的 schema 记录警告
+func (r *run) renameSchemas(api *OpenAPI) error {
+	renamed := make(map[string]string)
+	names := make(map[string]string)    // 新名称 -> 原名称
+	stripped := make(map[string]string) // 去除命名空间后的类型名称 -> 原名称
+	r.namespacedSchemas = make(map[string]string)
+	// 按名称遍历，名称冲突时报错信息中的两个 schema 顺序稳定
+	for _, name := range sortedKeys(api.Components.Schemas) {
+		schema := api.Components.Schemas[name]
+		newName := r.naming.Namespace(name)
+		if len(schema.Enum) == 0 {
+			newName = r.naming.Type(newName)
+			if other, exists := stripped[interfaceName(newName)]; exists && r.naming.Namespaces == "strip" {
+				r.diagnose("duplicate-name", "schemas generate the same type name, keep their namespaces with -namespaces prefix",
+					"schemas", other+", "+name, "type", interfaceName(newName))
+			}
+			stripped[interfaceName(newName)] = name
+		}
+		if other, exists := names[newName]; exists {
+			return fmt.Errorf("schemas %s and %s are both named %s", other, name, newName)
+		}
+		names[newName] = name
+		if newName != name {
+			renamed[name] = newName
+		}
+		if newName != name && strings.Contains(name, ".") {
+			r.namespacedSchemas[newName] = name
+		}
+	}
+	r.schemaNames = names
+	if len(renamed) == 0 {
+		return nil
+	}
+
+	const prefix = "#/components/schemas/"
+	fix := func(ref *string) {
+		if newName, ok := renamed[strings.TrimPrefix(*ref, prefix)]; ok && strings.HasPrefix(*ref, prefix) {
+			*ref = prefix + newName
+		}
+	}
+	fixRefs := func(refs []Ref) {
+		for i := range refs {
+			fix(&refs[i].RefValue)
+		}
+	}
+	fixItems := func(items *Items) {
+		if items != nil {
+			fix(&items.RefValue)
+			fixRefs(items.Tuple)
+		}
+	}
+
+	schemas := make(map[string]Schema, len(api.Components.Schemas))
+	for name, schema := range api.Components.Schemas {
+		fix(&schema.Ref)
+		fixRefs(schema.AllOf)
+		fixRefs(schema.PrefixItems)
+		fixItems(schema.Items)
+		for key, prop := range schema.Properties {
+			fix(&prop.Ref)
+			fixRefs(prop.AllOf)
+			fixRefs(prop.PrefixItems)
+			fixItems(prop.Items)
+			schema.Properties[key] = prop
+		}
+		if newName, ok := renamed[name]; ok {
+			name = newName
+		}
+		schemas[name] = schema
+	}
+	api.Components.Schemas = schemas
+
+	for _, item := range api.Paths {
+		for _, op := range []*Operation{item.Get, item.Post, item.Put, item.Delete} {
+			if op == nil {
+				continue
+			}
+			for i := range op.Parameters {
+				fix(&op.Parameters[i].Schema.Ref)
+				fixItems(op.Parameters[i].Schema.Items)
+			}
+			if op.RequestBody != nil {
+				for key, content := range op.RequestBody.Content {
+					fix(&content.Schema.RefValue)
+					if content.Schema.Items != nil {
+						fix(&content.Schema.Items.RefValue)
+					}
+					op.RequestBody.Content[key] = content
+				}
+			}
+			for _, resp := range op.Responses {
+				for key, content := range resp.Content {
+					fix(&content.Schema.RefValue)
+					if content.Schema.Items != nil {
+						fix(&content.Schema.Items.RefValue)
+					}
+					resp.Content[key] = content
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+// npm.go
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// packageNamePattern npm 包名，可以带 scope，例如 @acme/api-client
+var packageNamePattern = regexp.MustCompile(`^(?:@[a-z0-9-~][a-z0-9-._~]*/)?[a-z0-9-~][a-z0-9-._~]*$`)
+
+// packageDependencies 各选项生成的代码在运行时依赖的包及版本范围
+var packageDependencies = map[string]map[string]string{
+	"axios":  {"axios": "^1.7.0"},
+	"zod":    {"zod": "^3.23.0"},
+	"io-ts":  {"io-ts": "^2.2.21", "fp-ts": "^2.16.0"},
+	"yup":    {"yup": "^1.4.0"},
+	"mocks":  {"@faker-js/faker": "^8.4.0"},
+	"vitest": {"vitest": "^2.0.0", "@types/node": "^20.0.0"},
+	"jest":   {"jest": "^29.7.0", "@jest/globals": "^29.7.0", "ts-jest": "^29.2.0", "@types/node": "^20.0.0"},
+
+	"react-query": {"@tanstack/react-query": "^5.0.0", "react": ">=18"},
+	"swr":         {"swr": "^2.2.0", "react": ">=18"},
+}
+
+// contractScripts 运行契约测试和单元测试的 npm test 命令
+var contractScripts = map[string]string{"vitest": "vitest run", "jest": "jest --preset ts-jest"}
+
+// typescriptVersion 构建使用的 TypeScript 版本，rewriteRelativeImportExtensions 需要 5.7
+const typescriptVersion = "^5.7.0"
+
+// validatePackage 校验 -package-name 及其组合
+func validatePackage(o Options) error {
+	if o.PackageName == "" {
+		if o.PackageVersion != "" {
+			return fmt.Errorf("-package-version requires -package-name")
+		}
+		return nil
+	}
+	if len(o.PackageName) > 214 || !packageNamePattern.MatchString(o.PackageName) {
+		return fmt.Errorf("invalid npm package name %q, expected lowercase name or @scope/name", o.PackageName)
+	}
+	if o.Client == "" {
+		return fmt.Errorf("-package-name requires -client axios or fetch, the default client imports ../request.ts from outside the package")
+	}
+	if o.EmitJS || o.Ext == ".d.ts" {
+		return fmt.Errorf("-package-name builds the package with tsc and cannot be combined with -emit-js or -ext .d.ts")
+	}
+	return nil
+}
+
+// packageJSON package.json 的内容，字段按 npm 的惯例排列
+type packageJSON struct {
+	Name             string                 `json:"name"`
+	Version          string                 `json:"version"`
+	Description      string                 `json:"description,omitempty"`
+	Type             string                 `json:"type"`
+	Main             string                 `json:"main"`
+	Types            string                 `json:"types"`
+	Exports          map[string]interface{} `json:"exports"`
+	Files            []string               `json:"files"`
+	Scripts          map[string]string      `json:"scripts"`
+	Dependencies     map[string]string      `json:"dependencies,omitempty"`
+	PeerDependencies map[string]string      `json:"peerDependencies,omitempty"`
+	DevDependencies  map[string]string      `json:"devDependencies"`
+}
+
+// packageExport exports 中的一个入口，types 必须排在 default 之前
+type packageExport struct {
+	Types   string `json:"types"`
+	Default string `json:"default"`
+}
+
+// packageInfo 文档的 info，用于包的版本和描述
+type packageInfo struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+// packageFiles 为生成的代码添加 package.json 和 tsconfig.json：npm run build 用 tsc 编译到 dist，
+// exports 为每个模块提供子路径，例如 @acme/api-client/user；契约测试不参与构建和发布；
+// -workspace 时改为按模块拆分的 npm workspace，见 workspaceFiles
+func (r *run) packageFiles(spec []byte, files Files, api *ir.API) (Files, error) {
+	var doc struct {
+		Info packageInfo `yaml:"info"`
+	}
+	if err := r.decodeDocument(spec, &doc); err != nil {
+		return nil, err
+	}
+	if r.packageVersion != "" {
+		doc.Info.Version = r.packageVersion
+	}
+	if doc.Info.Version == "" {
+		doc.Info.Version = "0.0.0"
+	}
+	if r.workspace {
+		return r.workspaceFiles(files, api, doc.Info)
+	}
+	if err := r.addPackage(files, r.packageName, doc.Info, nil); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// addPackage 为 files（相对于包目录）添加 package.json 和 tsconfig.json，requires 是额外依赖的包及版本
+func (r *run) addPackage(files Files, name string, info packageInfo, requires map[string]string) error {
+	jsExt := compiledExts[r.outputExt]
+	dtsExt := ".d" + r.outputExt
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	exports := map[string]interface{}{"./package.json": "./package.json"}
+	published := []string{"dist"}
+	main := ""
+	for _, name := range names {
+		if strings.HasPrefix(name, "contract/") || strings.HasPrefix(name, "__tests__/") {
+			continue
+		}
+		if path.Ext(name) != r.outputExt {
+			// JSON Schema 等原样发布
+			exports["./"+name] = "./" + name
+			if top, _, _ := strings.Cut(name, "/"); !contains(published, top) {
+				published = append(published, top)
+			}
+			continue
+		}
+		base := strings.TrimSuffix(name, r.outputExt)
+		subpath := r.packageSubpath(name)
+		if r.singleFile != "" {
+			subpath = "."
+		}
+		exports[subpath] = packageExport{Types: "./dist/" + base + dtsExt, Default: "./dist/" + base + jsExt}
+		if subpath == "." {
+			main = base
+		}
+	}
+	sort.Strings(published)
+
+	pkg := packageJSON{
+		Name:        name,
+		Version:     info.Version,
+		Description: info.Title,
+		Type:        "module",
+		Main:        "./dist/" + main + jsExt,
+		Types:       "./dist/" + main + dtsExt,
+		Exports:     exports,
+		Files:       published,
+		Scripts:     map[string]string{"build": "tsc -p tsconfig.json", "prepublishOnly": "npm run build"},
+		DevDependencies: map[string]string{
+			"typescript": typescriptVersion,
+		},
+	}
+	if main == "" {
+		// 没有 index 的包只能通过子路径导入
+		pkg.Main, pkg.Types = "", ""
+	}
+	if r.outputExt == ".cts" {
+		pkg.Type = "commonjs"
+	}
+	// 只列出包中的文件实际导入的包，例如 workspace 中的公共包不依赖 hooks 使用的库
+	imported := importedPackages(files)
+	add := func(deps *map[string]string, requires map[string]string, all bool) {
+		for name, version := range requires {
+			if !all && !imported[name] {
+				continue
+			}
+			if *deps == nil {
+				*deps = make(map[string]string)
+			}
+			(*deps)[name] = version
+		}
+	}
+	add(&pkg.Dependencies, requires, true)
+	for _, option := range []string{r.client, r.validators, r.forms} {
+		add(&pkg.Dependencies, packageDependencies[option], false)
+	}
+	if r.mocks {
+		add(&pkg.Dependencies, packageDependencies["mocks"], false)
+	}
+	if imported[hooksPackages[r.hooks]] {
+		// 包括 React
+		add(&pkg.PeerDependencies, packageDependencies[r.hooks], true)
+	}
+	add(&pkg.DevDependencies, packageDependencies[r.contract], true)
+	add(&pkg.DevDependencies, packageDependencies[r.unitTests], true)
+	// 构建时同样需要
+	add(&pkg.DevDependencies, pkg.PeerDependencies, true)
+	if r.contract != "" {
+		pkg.Scripts["test"] = contractScripts[r.contract]
+	} else if r.unitTests != "" {
+		pkg.Scripts["test"] = contractScripts[r.unitTests]
+	}
+
+	tsconfig := map[string]interface{}{
+		"compilerOptions": map[string]interface{}{
+			"target":           "ES2020",
+			"lib":              []string{"ES2020", "DOM"},
+			"module":           "NodeNext",
+			"moduleResolution": "NodeNext",
+			"declaration":      true,
+			"outDir":           "dist",
+			"rootDir":          ".",
+			"strict":           true,
+			"skipLibCheck":     true,
+			"esModuleInterop":  true,
+			// 生成的代码以 .ts 扩展名相互导入，编译时改写为 .js
+			"rewriteRelativeImportExtensions": true,
+		},
+		"include": []string{"**/*" + r.outputExt},
+		"exclude": []string{"dist", "node_modules", "contract", "__tests__"},
+	}
+	return addJSONFiles(files, map[string]interface{}{"package.json": pkg, "tsconfig.json": tsconfig})
+}
+
+// hooksPackages -hooks 生成的代码导入的库
+var hooksPackages = map[string]string{"react-query": "@tanstack/react-query", "swr": "swr"}
+
+// importedPackages 返回 files 导入的 npm 包，例如 io-ts/PathReporter 记为 io-ts
+func importedPackages(files Files) map[string]bool {
+	imported := make(map[string]bool)
+	for _, data := range files {
+		for _, imp := range scanImports(data) {
+			name := imp.Specifier
+			if name == "" || name[0] == '.' || name[0] == '/' {
+				continue
+			}
+			parts := strings.SplitN(name, "/", 3)
+			if strings.HasPrefix(name, "@") && len(parts) > 1 {
+				name = parts[0] + "/" + parts[1]
+			} else {
+				name = parts[0]
+			}
+			imported[name] = true
+		}
+	}
+	return imported
+}
+
+// packageSubpath 生成的文件在 exports 中的子路径：目录的 index 作为目录的子路径，根目录的 index 作为包的入口
+func (r *run) packageSubpath(name string) string {
+	base := strings.TrimSuffix(name, r.outputExt)
+	if base == "index" {
+		return "."
+	}
+	return "./" + strings.TrimSuffix(base, "/index")
+}
+
+// addJSONFiles 把 values 编码为 JSON 文件加入 files，文件已经存在时返回错误
+func addJSONFiles(files Files, values map[string]interface{}) error {
+	for name, value := range values {
+		if _, exists := files[name]; exists {
+			return fmt.Errorf("%s is already generated", name)
+		}
+		// 版本范围中的 >= 不转义
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+		files[name] = buf.Bytes()
+	}
+	return nil
+}
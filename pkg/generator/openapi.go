@@ -0,0 +1,200 @@
+// openapi.go
+package generator
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type OpenAPI struct {
+	Paths      map[string]PathItem `yaml:"paths"`
+	Components struct {
+		Schemas map[string]Schema `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+type PathItem struct {
+	Post   *Operation `yaml:"post"`
+	Get    *Operation `yaml:"get"`
+	Put    *Operation `yaml:"put"`
+	Delete *Operation `yaml:"delete"`
+}
+
+type Operation struct {
+	Tags        []string    `yaml:"tags"`
+	Summary     string      `yaml:"summary"`
+	OperationID string      `yaml:"operationId"`
+	XModule     string      `yaml:"x-module"` // -group-by x-module 使用的模块名称
+	Parameters  []Parameter `yaml:"parameters"`
+	RequestBody *struct {
+		Content map[string]struct {
+			Schema MediaSchema `yaml:"schema"`
+		} `yaml:"content"`
+	} `yaml:"requestBody"`
+	Responses map[string]struct {
+		Content map[string]struct {
+			Schema   MediaSchema        `yaml:"schema"`
+			Example  interface{}        `yaml:"example"`
+			Examples map[string]Example `yaml:"examples"`
+		} `yaml:"content"`
+	} `yaml:"responses"`
+
+	// x-moonbeam-* 扩展覆盖生成结果，见 extensions.go
+	MoonbeamName   string `yaml:"x-moonbeam-name"`   // 函数名称，代替由 operationId 推导的名称
+	MoonbeamModule string `yaml:"x-moonbeam-module"` // 模块名称，优先于 -group-by
+	MoonbeamSkip   bool   `yaml:"x-moonbeam-skip"`   // 不生成这个接口
+}
+
+// Example 响应示例
+type Example struct {
+	Summary string      `yaml:"summary"`
+	Value   interface{} `yaml:"value"`
+}
+
+type Schema struct {
+	Ref                  string                      `yaml:"$ref"`
+	Type                 string                      `yaml:"type"`
+	Properties           map[string]Property         `yaml:"properties"`
+	Required             []string                    `yaml:"required"` // 必填的属性名称
+	AdditionalProperties *AdditionalPropertiesSchema `yaml:"additionalProperties"`
+	Description          string                      `yaml:"description"`
+	Format               string                      `yaml:"format"`
+	Items                *Items                      `yaml:"items"`
+	PrefixItems          []Ref                       `yaml:"prefixItems"`
+	AllOf                []Ref                       `yaml:"allOf"`
+	Enum                 []interface{}               `yaml:"enum"`
+	MoonbeamType         string                      `yaml:"x-moonbeam-type"` // TypeScript 类型，代替推导的类型
+}
+
+type Property struct {
+	Type                 string                      `yaml:"type"`
+	Format               string                      `yaml:"format"`
+	Description          string                      `yaml:"description"`
+	Ref                  string                      `yaml:"$ref"`
+	AllOf                []Ref                       `yaml:"allOf"`
+	Items                *Items                      `yaml:"items"`
+	PrefixItems          []Ref                       `yaml:"prefixItems"`
+	AdditionalProperties *AdditionalPropertiesSchema `yaml:"additionalProperties"`
+	Enum                 []interface{}               `yaml:"enum"`
+	MoonbeamType         string                      `yaml:"x-moonbeam-type"` // TypeScript 类型，代替推导的类型
+	MoonbeamSkip         bool                        `yaml:"x-moonbeam-skip"` // 不生成这个字段
+	Constraints          `yaml:",inline"`
+}
+
+// Constraints 字段校验约束，转换为 ir.Constraints 后生成到 JSDoc 和校验器中
+type Constraints struct {
+	MinLength        *int        `yaml:"minLength"`
+	MaxLength        *int        `yaml:"maxLength"`
+	Pattern          string      `yaml:"pattern"`
+	Minimum          *float64    `yaml:"minimum"`
+	Maximum          *float64    `yaml:"maximum"`
+	ExclusiveMinimum interface{} `yaml:"exclusiveMinimum"` // 3.0 为 bool，3.1 为数值
+	ExclusiveMaximum interface{} `yaml:"exclusiveMaximum"`
+	MultipleOf       *float64    `yaml:"multipleOf"`
+	MinItems         *int        `yaml:"minItems"`
+	MaxItems         *int        `yaml:"maxItems"`
+	UniqueItems      bool        `yaml:"uniqueItems"`
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+type AdditionalPropertiesSchema struct {
+	Type string `yaml:"type"`
+}
+
+type Parameter struct {
+	Name        string `yaml:"name"`
+	In          string `yaml:"in"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+	Schema      struct {
+		Type        string `yaml:"type"`
+		Format      string `yaml:"format"`
+		Ref         string `yaml:"$ref"`
+		Items       *Items `yaml:"items"` // 数组参数的元素
+		Constraints `yaml:",inline"`
+	} `yaml:"schema"`
+}
+
+type Ref struct {
+	RefValue string `yaml:"$ref"`
+	Type     string `yaml:"type"`
+	Format   string `yaml:"format"` // 只用于 -type-mapping
+}
+
+// MediaSchema 请求体和响应的 schema，支持组件引用以及元素为组件引用的数组（Foo[]）
+type MediaSchema struct {
+	Ref   `yaml:",inline"`
+	Items *Items `yaml:"items"`
+}
+
+// refs 引用的组件 schema 的原始名称
+func (s MediaSchema) refs() []string {
+	switch {
+	case s.RefValue != "":
+		return []string{cleanRef(s.RefValue)}
+	case s.Type == "array" && s.Items != nil && s.Items.RefValue != "":
+		return []string{cleanRef(s.Items.RefValue)}
+	}
+	return nil
+}
+
+// Items 数组元素定义，兼容单个 schema、元组写法（items 为数组）以及 items: false
+type Items struct {
+	Ref
+	Tuple    []Ref
+	Disabled bool // items: false，不允许 prefixItems 之外的元素
+}
+
+func (i *Items) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.SequenceNode:
+		return value.Decode(&i.Tuple)
+	case yaml.ScalarNode:
+		var allowed bool
+		if err := value.Decode(&allowed); err != nil {
+			return err
+		}
+		i.Disabled = !allowed
+		return nil
+	default:
+		return value.Decode(&i.Ref)
+	}
+}
+
+func ParseOpenAPI(data []byte) (*OpenAPI, error) {
+	var api OpenAPI
+	err := yaml.Unmarshal(data, &api)
+	return &api, err
+}
+
+// asProperty 将 schema 视为属性，复用属性的类型推导
+func (s Schema) asProperty() Property {
+	return Property{
+		Type:                 s.Type,
+		Format:               s.Format,
+		Description:          s.Description,
+		Ref:                  s.Ref,
+		AllOf:                s.AllOf,
+		Items:                s.Items,
+		PrefixItems:          s.PrefixItems,
+		AdditionalProperties: s.AdditionalProperties,
+		Enum:                 s.Enum,
+		MoonbeamType:         s.MoonbeamType,
+	}
+}
+
+func cleanRef(ref string) string {
+	return ref[strings.LastIndexByte(ref, '/')+1:]
+}
+
+func (r *run) getModuleName(tags []string) string {
+	if len(tags) > 0 {
+		return r.naming.Dir(tags[0])
+	}
+	return "common"
+}
@@ -0,0 +1,267 @@
+// operationid.go
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// operationNameStrategies 内置的推导方式，返回值再统一转换为合法的函数名
+var operationNameStrategies = map[string]func(op *Operation) string{
+	// Team_GetTeamRole -> GetTeamRole，usersList（tag 为 users）-> List
+	"strip-tag": func(op *Operation) string {
+		id := op.OperationID
+		if i := strings.IndexAny(id, "_."); i > 0 && i < len(id)-1 {
+			return id[i+1:]
+		}
+		for _, tag := range op.Tags {
+			if len(id) > len(tag) && strings.EqualFold(id[:len(tag)], tag) && unicode.IsUpper(rune(id[len(tag)])) {
+				return id[len(tag):]
+			}
+		}
+		return id
+	},
+	// api.v1.UserService/ListUsers -> ListUsers
+	"last": func(op *Operation) string {
+		parts := strings.FieldsFunc(op.OperationID, func(r rune) bool {
+			return strings.ContainsRune("_.:/", r)
+		})
+		if len(parts) == 0 {
+			return ""
+		}
+		return parts[len(parts)-1]
+	},
+	// Team_GetTeamRole -> TeamGetTeamRole
+	"full": func(op *Operation) string {
+		return op.OperationID
+	},
+}
+
+// operationNameData -operation-name 模板的数据
+type operationNameData struct {
+	OperationID string
+	Tag         string // 第一个 tag
+	Method      string
+	Path        string
+}
+
+// parseOperationName 校验 -operation-name，模板形式时返回解析后的模板，内置方式返回 nil
+func parseOperationName(value string, naming NamingConvention) (*template.Template, error) {
+	if strings.Contains(value, "{{") {
+		return template.New("operation-name").Funcs(templateFuncs).Funcs(namingFuncs(naming)).Parse(value)
+	}
+	if _, ok := operationNameStrategies[value]; ok {
+		return nil, nil
+	}
+	var names []string
+	for name := range operationNameStrategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("unsupported -operation-name %q, expected one of %s or a template", value, strings.Join(names, ", "))
+}
+
+// operationBaseName 返回 PascalCase 的操作名称，例如 GetTeamRole，函数名和查询参数请求类型名都由它派生
+// x-moonbeam-name 优先；推导结果不是合法标识符时依次回退到完整的 operationId 和 method + path，-api-versions suffix 时再加上版本后缀
+func (r *run) operationBaseName(path, method string, op *Operation) string {
+	if op.MoonbeamName != "" {
+		if base := pascalIdentifier(strings.TrimSpace(op.MoonbeamName)); base != "" {
+			return base
+		}
+		r.logger.Warn("x-moonbeam-name is not a valid identifier, ignoring it", "operation", method+" "+path, "name", op.MoonbeamName)
+	}
+	var name string
+	if op.OperationID != "" {
+		if r.operationNameTmpl != nil {
+			data := operationNameData{OperationID: op.OperationID, Method: method, Path: path}
+			if len(op.Tags) > 0 {
+				data.Tag = op.Tags[0]
+			}
+			var buf bytes.Buffer
+			if err := r.operationNameTmpl.Execute(&buf, data); err != nil {
+				r.logger.Warn("operation name template failed", "operation", op.OperationID, "err", err)
+			}
+			name = strings.TrimSpace(buf.String())
+		} else {
+			name = operationNameStrategies[r.operationName](op)
+		}
+	}
+
+	base := pascalIdentifier(name)
+	if base == "" && op.OperationID != "" {
+		base = pascalIdentifier(op.OperationID)
+	}
+	if base == "" {
+		base = pathOperationName(method, path)
+	}
+	if name != "" && base != pascalIdentifier(name) {
+		r.logger.Warn("operation name is not a valid identifier, using fallback", "operation", op.OperationID, "name", name, "fallback", base)
+	}
+	return r.versionedName(path, base)
+}
+
+// pascalIdentifier 将名称转换为首字母大写的标识符，保留已有的大小写（GetTeamRole 不变），无法转换时返回空字符串
+func pascalIdentifier(name string) string {
+	id := toCamel(name)
+	if !identifierPattern.MatchString(id) {
+		id = pascalCase(name)
+	}
+	if !identifierPattern.MatchString(id) {
+		return ""
+	}
+	runes := []rune(id)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// pathOperationName 根据请求方法和路径生成操作名称，例如 GET /users/{id} -> GetUsersById
+func pathOperationName(method, path string) string {
+	name := pascalCase(strings.ToLower(method))
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			name += "By" + pascalCase(strings.Trim(segment, "{}"))
+		} else {
+			name += pascalCase(segment)
+		}
+	}
+	return name
+}
+
+// operationKey 接口在名称表中的键，同时用于警告中指明接口在文档中的位置
+func operationKey(method, path string) string {
+	return method + " " + path
+}
+
+// namedOperation 名称表中的一个接口
+type namedOperation struct {
+	method, path, base string
+}
+
+// operationNames 返回每个接口（operationKey）最终使用的 PascalCase 操作名称。
+// 同一模块中推导出相同函数名的接口按路径区分：去掉它们共有的和已出现在名称中的路径段，
+// 余下的路径段作为后缀（POST /auth/email/login -> LoginByEmail，路径参数为 ById），
+// 路径相同时再加上请求方法前缀（GetLogin、PostLogin）。结果只取决于冲突接口的方法和路径，
+// 与它们在文档中的顺序无关；仍然重名时按方法和路径的顺序添加编号
+func (r *run) operationNames(api *OpenAPI) map[string]string {
+	groups := make(map[string][]namedOperation) // 模块 + 函数名 -> 接口
+	modules := make(map[string]string)          // 分组键 -> 模块
+	var paths []string
+	for path := range api.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		item := api.Paths[path]
+		for _, entry := range []struct {
+			op     *Operation
+			method string
+		}{
+			{item.Post, "POST"},
+			{item.Get, "GET"},
+			{item.Put, "PUT"},
+			{item.Delete, "DELETE"},
+		} {
+			if entry.op == nil {
+				continue
+			}
+			base := r.operationBaseName(path, entry.method, entry.op)
+			module := r.operationModule(path, entry.op)
+			key := module + "\x00" + r.functionName(base)
+			groups[key] = append(groups[key], namedOperation{entry.method, path, base})
+			modules[key] = module
+		}
+	}
+
+	names := make(map[string]string)
+	used := make(map[string]map[string]bool) // 模块 -> 已使用的函数名
+	use := func(module, name string) bool {
+		if used[module] == nil {
+			used[module] = make(map[string]bool)
+		}
+		if used[module][r.functionName(name)] {
+			return false
+		}
+		used[module][r.functionName(name)] = true
+		return true
+	}
+	// 先登记没有冲突的名称，冲突接口改名后不会占用它们
+	keys := sortedKeys(groups)
+	for _, key := range keys {
+		if ops := groups[key]; len(ops) == 1 {
+			use(modules[key], ops[0].base)
+			names[operationKey(ops[0].method, ops[0].path)] = ops[0].base
+		}
+	}
+	for _, key := range keys {
+		ops := groups[key]
+		if len(ops) == 1 {
+			continue
+		}
+		var locations []string
+		for i, name := range disambiguate(ops) {
+			original := name
+			for counter := 2; !use(modules[key], name); counter++ {
+				name = fmt.Sprintf("%s%d", original, counter)
+			}
+			names[operationKey(ops[i].method, ops[i].path)] = name
+			locations = append(locations, fmt.Sprintf("%s -> %s", operationKey(ops[i].method, ops[i].path), r.functionName(name)))
+		}
+		r.diagnose("duplicate-name", "duplicate operation name, renamed after the path", "module", modules[key], "name", r.functionName(ops[0].base), "operations", strings.Join(locations, ", "))
+	}
+	return names
+}
+
+// disambiguate 为推导出相同名称的接口生成按路径区分的名称，顺序与 ops 相同
+func disambiguate(ops []namedOperation) []string {
+	// 所有冲突接口共有的路径段不能区分它们
+	count := make(map[string]int)
+	for _, op := range ops {
+		seen := make(map[string]bool)
+		for _, segment := range strings.Split(op.path, "/") {
+			if segment != "" && !seen[segment] {
+				seen[segment] = true
+				count[segment]++
+			}
+		}
+	}
+
+	suffixes := make([]string, len(ops))
+	same := make(map[string]int)
+	for i, op := range ops {
+		var suffix string
+		for _, segment := range strings.Split(op.path, "/") {
+			if segment == "" || count[segment] == len(ops) {
+				continue
+			}
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				suffix += "By" + pascalCase(strings.Trim(segment, "{}"))
+			} else if part := pascalCase(segment); !strings.Contains(op.base, part) {
+				suffix += part
+			}
+		}
+		if suffix != "" && !strings.HasPrefix(suffix, "By") {
+			suffix = "By" + suffix
+		}
+		suffixes[i] = suffix
+		same[suffix]++
+	}
+
+	names := make([]string, len(ops))
+	for i, op := range ops {
+		names[i] = op.base + suffixes[i]
+		if same[suffixes[i]] > 1 {
+			// 路径段无法区分（例如同一路径的不同方法）时加上请求方法
+			names[i] = pascalCase(strings.ToLower(op.method)) + names[i]
+		}
+	}
+	return names
+}
+
+// functionName 由 PascalCase 的操作名称得到函数名
+func (r *run) functionName(base string) string {
+	return r.naming.Function(strings.ToLower(base[:1]) + base[1:])
+}
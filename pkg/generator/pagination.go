@@ -0,0 +1,162 @@
+// pagination.go
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// PaginationMatcher 分页响应识别规则：一个列表字段 + 若干分页元信息字段
+type PaginationMatcher struct {
+	Items *regexp.Regexp
+	Meta  *regexp.Regexp
+}
+
+// paginationField 分页结构中的字段
+type paginationField struct {
+	Name     string
+	TypeName string
+	Refs     []string // 字段类型引用的模型和枚举
+}
+
+// paginationShape 分页响应的结构，相同结构的响应共用一个泛型类型
+type paginationShape struct {
+	ItemsField string
+	Meta       []paginationField
+}
+
+func (s paginationShape) key() string {
+	parts := []string{s.ItemsField}
+	for _, field := range s.Meta {
+		parts = append(parts, field.Name+":"+field.TypeName)
+	}
+	return strings.Join(parts, ",")
+}
+
+// ParsePaginationPattern 解析 "<items 正则>:<meta 正则>" 格式的分页识别规则
+func ParsePaginationPattern(pattern string) (*PaginationMatcher, error) {
+	itemsExpr, metaExpr, ok := strings.Cut(pattern, ":")
+	if !ok || itemsExpr == "" || metaExpr == "" {
+		return nil, fmt.Errorf("invalid pagination pattern %q, expected '<items regex>:<meta regex>'", pattern)
+	}
+	items, err := regexp.Compile("^(?:" + itemsExpr + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid pagination items pattern: %w", err)
+	}
+	meta, err := regexp.Compile("^(?:" + metaExpr + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid pagination meta pattern: %w", err)
+	}
+	return &PaginationMatcher{Items: items, Meta: meta}, nil
+}
+
+// match 判断模型是否为分页响应，返回分页结构和列表元素类型
+func (m *PaginationMatcher) match(model ir.Model) (paginationShape, string, bool) {
+	var shape paginationShape
+	var itemType string
+	if model.Synthetic || len(model.Fields) < 2 {
+		return shape, "", false
+	}
+
+	for _, field := range model.Fields {
+		typeName := tsType(field.Type)
+		switch {
+		case m.Items.MatchString(field.Name) && strings.HasSuffix(typeName, "[]"):
+			if shape.ItemsField != "" {
+				return shape, "", false
+			}
+			shape.ItemsField = field.Name
+			itemType = strings.TrimSuffix(typeName, "[]")
+		case m.Meta.MatchString(field.Name):
+			shape.Meta = append(shape.Meta, paginationField{Name: field.Name, TypeName: typeName, Refs: field.Type.Refs()})
+		default:
+			// 存在无法识别的字段，不视为分页响应
+			return shape, "", false
+		}
+	}
+	if shape.ItemsField == "" || len(shape.Meta) == 0 {
+		return shape, "", false
+	}
+
+	sort.Slice(shape.Meta, func(i, j int) bool {
+		return shape.Meta[i].Name < shape.Meta[j].Name
+	})
+	return shape, itemType, true
+}
+
+// applyPagination 将最常见结构的分页响应替换为 Paginated<T> 别名，并生成泛型类型和访问函数；
+// refs 记录替换后的类型定义引用的模型和枚举
+func applyPagination(matcher *PaginationMatcher, models []ir.Model, interfaces map[string]string, refs map[string][]string) {
+	type matched struct {
+		model    ir.Model
+		itemType string
+	}
+	shapes := make(map[string]paginationShape)
+	matches := make(map[string][]matched)
+	for _, model := range models {
+		shape, itemType, ok := matcher.match(model)
+		if !ok {
+			continue
+		}
+		key := shape.key()
+		shapes[key] = shape
+		matches[key] = append(matches[key], matched{model: model, itemType: itemType})
+	}
+	if len(matches) == 0 {
+		return
+	}
+
+	// 选择出现次数最多的结构，次数相同时按结构排序保证输出稳定
+	var bestKey string
+	for key, items := range matches {
+		if bestKey == "" || len(items) > len(matches[bestKey]) ||
+			(len(items) == len(matches[bestKey]) && key < bestKey) {
+			bestKey = key
+		}
+	}
+
+	shape := shapes[bestKey]
+	for _, m := range matches[bestKey] {
+		interfaces[m.model.Name] = fmt.Sprintf("\n/**\n * %s\n */\nexport type %s = Paginated<%s>", m.model.Name, m.model.TypeName, m.itemType)
+		for _, field := range m.model.Fields {
+			if field.Name == shape.ItemsField {
+				refs[m.model.Name] = append(field.Type.Refs(), "Paginated")
+			}
+		}
+	}
+	interfaces["Paginated"] = renderPaginatedType(shape)
+	refs["Paginated"] = nil
+	for _, field := range shape.Meta {
+		refs["Paginated"] = append(refs["Paginated"], field.Refs...)
+	}
+}
+
+// renderPaginatedType 生成 Paginated<T> 泛型接口及其访问函数
+func renderPaginatedType(shape paginationShape) string {
+	var b strings.Builder
+	b.WriteString("\n/**\n * Paginated 通用分页响应\n */\n")
+	b.WriteString("export interface Paginated<T> {\n")
+	fmt.Fprintf(&b, "  %s?: T[]\n", shape.ItemsField)
+	for _, field := range shape.Meta {
+		fmt.Fprintf(&b, "  %s?: %s\n", field.Name, field.TypeName)
+	}
+	b.WriteString("}\n")
+
+	b.WriteString("\n/**\n * pageItems 获取分页数据列表\n */\n")
+	b.WriteString("export function pageItems<T>(page: Paginated<T>): T[] {\n")
+	fmt.Fprintf(&b, "  return page.%s ?? []\n", shape.ItemsField)
+	b.WriteString("}\n")
+
+	for _, field := range shape.Meta {
+		fnName := "page" + strings.ToUpper(field.Name[:1]) + field.Name[1:]
+		fmt.Fprintf(&b, "\n/**\n * %s 获取分页字段 %s\n */\n", fnName, field.Name)
+		fmt.Fprintf(&b, "export function %s<T>(page: Paginated<T>): Paginated<T>['%s'] {\n", fnName, field.Name)
+		fmt.Fprintf(&b, "  return page.%s\n", field.Name)
+		b.WriteString("}\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
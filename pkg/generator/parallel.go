@@ -0,0 +1,39 @@
+// parallel.go
+package generator
+
+import (
+	"sync"
+)
+
+// renderParallel 用最多 renderWorkers 个 goroutine 对 items 逐个调用 render，结果按 items 的顺序返回，与顺序执行的结果相同；
+// render 只能读取 r，不能调用 writeFile 或记录日志，写入和日志在调用方按顺序合并结果时进行。每完成一项按 stage 报告进度
+func renderParallel[T, R any](r *run, stage string, items []T, render func(T) R) []R {
+	results := make([]R, len(items))
+	tracker := r.startProgress(stage, len(items))
+	workers := min(r.renderWorkers, len(items))
+	if workers <= 1 {
+		for i, item := range items {
+			results[i] = render(item)
+			tracker.step()
+		}
+		return results
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = render(items[i])
+				tracker.step()
+			}
+		}()
+	}
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
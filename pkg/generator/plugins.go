@@ -0,0 +1,173 @@
+// plugins.go
+package generator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// pluginPrefix 插件可执行文件的名称前缀，-plugin routes 查找 PATH 中的 moonbeam-plugin-routes
+const pluginPrefix = "moonbeam-plugin-"
+
+// defaultPluginTimeout 没有设置 -plugin-timeout 时单个插件的运行时间上限
+const defaultPluginTimeout = time.Minute
+
+// PluginRequest 通过标准输入以 JSON 传给插件的数据
+type PluginRequest struct {
+	Version   string   `json:"version"`             // moonbeam 版本
+	Commit    string   `json:"commit,omitempty"`    // 构建 moonbeam 的提交，见 Commit
+	Source    string   `json:"source,omitempty"`    // 文档来源
+	Parameter string   `json:"parameter,omitempty"` // -plugin name:parameter 中冒号之后的部分
+	Files     []string `json:"files"`               // 内置输出的文件，已排序，插件可以引用但不能覆盖
+	API       *ir.API  `json:"api"`
+}
+
+// PluginResponse 插件通过标准输出返回的 JSON
+type PluginResponse struct {
+	Files []PluginFile `json:"files"`
+	Error string       `json:"error,omitempty"` // 非空时生成失败
+}
+
+// PluginFile 插件生成的文件，Name 为相对于输出目录、以 / 分隔的路径
+type PluginFile struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// pluginName 插件名称只允许字母、数字、- 和 _
+var pluginName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// parsePlugin 解析 -plugin 取值 name[:parameter]；name 含路径分隔符时视为可执行文件路径
+func parsePlugin(value string) (name, parameter string, err error) {
+	name, parameter, _ = strings.Cut(value, ":")
+	if name == "" {
+		return "", "", fmt.Errorf("invalid plugin %q, expected name[:parameter]", value)
+	}
+	if !strings.ContainsAny(name, `/\`) && !pluginName.MatchString(name) {
+		return "", "", fmt.Errorf("invalid plugin name %q, use letters, digits, - and _ or a path to the executable", name)
+	}
+	return name, parameter, nil
+}
+
+// findPlugin 返回插件可执行文件，路径形式直接使用，否则在 PATH 中查找 moonbeam-plugin-<name>
+func findPlugin(name string) (string, error) {
+	if strings.ContainsAny(name, `/\`) {
+		return name, nil
+	}
+	executable, err := exec.LookPath(pluginPrefix + name)
+	if err != nil {
+		return "", fmt.Errorf("not found, install %s%s on PATH", pluginPrefix, name)
+	}
+	return executable, nil
+}
+
+// runPlugins 依次运行插件，将生成的文件加入 files；插件之间以及与内置输出的文件不能重名
+func (r *run) runPlugins(plugins []string, api *ir.API, files Files) error {
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	owners := make(map[string]string) // 文件 -> 生成它的插件
+	for _, value := range plugins {
+		name, parameter, err := parsePlugin(value)
+		if err != nil {
+			return err
+		}
+		generated, err := r.runPlugin(name, PluginRequest{
+			Version:   Version,
+			Commit:    Commit,
+			Source:    bannerSource(r.bannerSpec),
+			Parameter: parameter,
+			Files:     names,
+			API:       api,
+		})
+		if err != nil {
+			return fmt.Errorf("plugin %s: %w", name, err)
+		}
+		for _, file := range generated {
+			filename, err := pluginFilename(file.Name)
+			if err != nil {
+				return fmt.Errorf("plugin %s: %w", name, err)
+			}
+			if owner, exists := owners[filename]; exists {
+				return fmt.Errorf("plugin %s: %s is also generated by plugin %s", name, filename, owner)
+			}
+			if _, exists := files[filename]; exists {
+				return fmt.Errorf("plugin %s: %s is a built-in output", name, filename)
+			}
+			owners[filename] = name
+			files[filename] = []byte(file.Content)
+			r.logger.Debug("generate plugin file", "plugin", name, "file", filename)
+		}
+	}
+	return nil
+}
+
+// runPlugin 运行单个插件，标准错误输出记录到日志；超过 -plugin-timeout 时结束插件并返回错误
+func (r *run) runPlugin(name string, request PluginRequest) ([]PluginFile, error) {
+	executable, err := findPlugin(name)
+	if err != nil {
+		return nil, err
+	}
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := r.opts.PluginTimeout
+	if timeout == 0 {
+		timeout = defaultPluginTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, executable)
+	// 插件启动的子进程可能继续持有输出管道，结束插件后不再等待它们
+	cmd.WaitDelay = time.Second
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+	if output := strings.TrimSpace(stderr.String()); output != "" {
+		r.logger.Info("plugin output", "plugin", name, "output", output)
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("timed out after %s, raise -plugin-timeout if it needs longer", timeout)
+	}
+	if runErr != nil {
+		return nil, fmt.Errorf("run %s: %w", executable, runErr)
+	}
+
+	var response PluginResponse
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("invalid response, expected JSON {\"files\": [...]} on stdout: %w", err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("%s", response.Error)
+	}
+	return response.Files, nil
+}
+
+// pluginFilename 校验插件返回的文件路径，必须是输出目录内的相对路径
+func pluginFilename(name string) (string, error) {
+	filename := path.Clean(filepath.ToSlash(name))
+	if name == "" || path.IsAbs(filename) || filepath.IsAbs(name) || filename == "." ||
+		filename == ".." || strings.HasPrefix(filename, "../") {
+		return "", fmt.Errorf("invalid file name %q, expected a path inside the output directory", name)
+	}
+	return filename, nil
+}
@@ -0,0 +1,41 @@
+// plugins_test.go
+package generator
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestPluginTimeout 超过 PluginTimeout 的插件被结束，错误中包含插件名称；插件启动的子进程持有输出管道时同样及时返回
+func TestPluginTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin script needs sh")
+	}
+	spec, err := os.ReadFile(filepath.Join("testdata", "crud.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plugin := filepath.Join(t.TempDir(), "slow")
+	writeTestFile(t, plugin, "#!/bin/sh\nsleep 30\n")
+	if err := os.Chmod(plugin, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	_, err = New(Options{
+		Source:        "crud.yaml",
+		Plugins:       []string{plugin},
+		PluginTimeout: 100 * time.Millisecond,
+		Logger:        slog.New(slog.DiscardHandler),
+	}).Generate(spec)
+	if err == nil || !strings.Contains(err.Error(), "plugin "+plugin+": timed out after 100ms") {
+		t.Fatalf("err = %v, want plugin %s timed out", err, plugin)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("generate returned after %s", elapsed)
+	}
+}
@@ -0,0 +1,551 @@
+// postman.go
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Postman 集合没有类型定义，请求体、查询参数和响应的类型都从示例值推断：
+// 文件夹作为 tag，请求名称作为 operationId，示例中的对象提取为组件 schema
+
+// postmanSchemaURL v2.1 集合 info.schema 的前缀
+const postmanSchemaURL = "schema.getpostman.com/json/collection/v2"
+
+// postmanCollection Postman v2.1 集合中生成客户端需要的字段
+type postmanCollection struct {
+	Info struct {
+		Name        string          `json:"name"`
+		PostmanID   string          `json:"_postman_id"`
+		Schema      string          `json:"schema"`
+		Description json.RawMessage `json:"description"`
+	} `json:"info"`
+	Item []postmanItem `json:"item"`
+}
+
+// postmanItem 文件夹（有 Item）或请求（有 Request）
+type postmanItem struct {
+	Name     string            `json:"name"`
+	Item     []postmanItem     `json:"item"`
+	Request  json.RawMessage   `json:"request"`
+	Response []postmanResponse `json:"response"`
+}
+
+type postmanRequest struct {
+	Method      string          `json:"method"`
+	URL         json.RawMessage `json:"url"`
+	Body        *postmanBody    `json:"body"`
+	Description json.RawMessage `json:"description"`
+}
+
+type postmanURL struct {
+	Raw      string            `json:"raw"`
+	Path     json.RawMessage   `json:"path"` // 字符串或字符串数组
+	Query    []postmanKeyValue `json:"query"`
+	Variable []postmanKeyValue `json:"variable"`
+}
+
+type postmanKeyValue struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Type     string `json:"type"`
+	Disabled bool   `json:"disabled"`
+}
+
+type postmanBody struct {
+	Mode       string            `json:"mode"`
+	Raw        string            `json:"raw"`
+	URLEncoded []postmanKeyValue `json:"urlencoded"`
+	FormData   []postmanKeyValue `json:"formdata"`
+}
+
+type postmanResponse struct {
+	Name string `json:"name"`
+	Code int    `json:"code"`
+	Body string `json:"body"`
+}
+
+// postmanVariable 路径中的 :name 和 {{name}} 变量
+var postmanVariable = regexp.MustCompile(`^(?::(\w+)|\{\{(\w+)\}\})$`)
+
+// isPostmanCollection 按 info.schema 或 info._postman_id 判断 JSON 文档是否为 Postman 集合
+func isPostmanCollection(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return false
+	}
+	var collection postmanCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return false
+	}
+	return strings.Contains(collection.Info.Schema, postmanSchemaURL) || collection.Info.PostmanID != ""
+}
+
+// postmanToOpenAPI 把 Postman v2.1 集合转换为 OpenAPI 3 文档
+func (r *run) postmanToOpenAPI(data []byte) ([]byte, error) {
+	var collection postmanCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, fmt.Errorf("decode collection: %w", err)
+	}
+	if collection.Info.Schema != "" && !strings.Contains(collection.Info.Schema, postmanSchemaURL) {
+		return nil, fmt.Errorf("unsupported collection schema %q, export the collection as v2.1", collection.Info.Schema)
+	}
+	c := &postmanConverter{run: r,
+
+		paths:      make(map[string]map[string]interface{}),
+		schemas:    make(map[string]interface{}),
+		shapes:     make(map[string]string),
+		operations: make(uniqueNames),
+	}
+	c.items(collection.Item, "")
+	if len(c.paths) == 0 {
+		return nil, fmt.Errorf("no requests found in the collection")
+	}
+	info := map[string]interface{}{"title": collection.Info.Name, "version": "1.0.0"}
+	if collection.Info.Name == "" {
+		info["title"] = "Postman collection"
+	}
+	if description := postmanDescription(collection.Info.Description); description != "" {
+		info["description"] = description
+	}
+	return json.MarshalIndent(map[string]interface{}{
+		"openapi":    "3.0.3",
+		"info":       info,
+		"paths":      c.paths,
+		"components": map[string]interface{}{"schemas": c.schemas},
+	}, "", "  ")
+}
+
+// postmanConverter 转换过程的状态，结构相同的推断 schema 共用一个组件
+type postmanConverter struct {
+	*run
+	paths      map[string]map[string]interface{}
+	schemas    map[string]interface{}
+	shapes     map[string]string // schema 的 JSON -> 组件名称
+	operations uniqueNames       // 已使用的 operationId
+}
+
+// items 递归转换文件夹中的请求，顶层文件夹名称作为 tag
+func (c *postmanConverter) items(items []postmanItem, tag string) {
+	for _, item := range items {
+		if item.Request == nil {
+			folderTag := tag
+			if folderTag == "" {
+				folderTag = item.Name
+			}
+			c.items(item.Item, folderTag)
+			continue
+		}
+		if err := c.request(item, tag); err != nil {
+			c.logger.Warn("skip postman request", "request", item.Name, "err", err)
+		}
+	}
+}
+
+// request 转换一个请求，同一路径和方法的后续请求被跳过
+func (c *postmanConverter) request(item postmanItem, tag string) error {
+	var request postmanRequest
+	if bytes.HasPrefix(item.Request, []byte(`"`)) {
+		request.URL = item.Request // 只有 URL 字符串的 GET 请求
+	} else if err := json.Unmarshal(item.Request, &request); err != nil {
+		return fmt.Errorf("decode request: %w", err)
+	}
+	method := strings.ToUpper(request.Method)
+	if method == "" {
+		method = "GET"
+	}
+	if !protoHTTPMethods[method] {
+		return fmt.Errorf("unsupported HTTP method %s", method)
+	}
+	requestURL, err := parsePostmanURL(request.URL)
+	if err != nil {
+		return err
+	}
+	httpPath, pathParams := postmanPath(requestURL)
+	if c.paths[httpPath][strings.ToLower(method)] != nil {
+		return fmt.Errorf("%s %s is already defined by another request", method, httpPath)
+	}
+
+	name := pascalCase(item.Name)
+	if name == "" {
+		name = pascalCase(strings.ToLower(method) + " " + httpPath)
+	}
+	name = c.operations.unique(name)
+	operation := map[string]interface{}{"operationId": camelCase(name)}
+	if tag != "" {
+		operation["tags"] = []string{tag}
+	}
+	if item.Name != "" {
+		operation["summary"] = item.Name
+	}
+	if description := postmanDescription(request.Description); description != "" {
+		operation["description"] = description
+	}
+
+	var parameters []interface{}
+	for _, param := range pathParams {
+		parameters = append(parameters, map[string]interface{}{
+			"name": param.Key, "in": "path", "required": true, "schema": postmanValueSchema(param.Value),
+		})
+	}
+	for _, param := range requestURL.Query {
+		if param.Disabled || param.Key == "" {
+			continue
+		}
+		parameters = append(parameters, map[string]interface{}{
+			"name": param.Key, "in": "query", "schema": postmanValueSchema(param.Value),
+		})
+	}
+	if parameters != nil {
+		operation["parameters"] = parameters
+	}
+
+	if schema := c.bodySchema(request.Body, name+"Request"); schema != nil {
+		operation["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}},
+		}
+	}
+	response := map[string]interface{}{"description": "OK"}
+	if schema := c.responseSchema(item.Response, name+"Reply"); schema != nil {
+		response["content"] = map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}}
+	}
+	operation["responses"] = map[string]interface{}{"200": response}
+
+	if c.paths[httpPath] == nil {
+		c.paths[httpPath] = make(map[string]interface{})
+	}
+	c.paths[httpPath][strings.ToLower(method)] = operation
+	return nil
+}
+
+// parsePostmanURL url 可以是对象或原始字符串
+func parsePostmanURL(raw json.RawMessage) (postmanURL, error) {
+	var u postmanURL
+	if len(raw) == 0 {
+		return u, fmt.Errorf("request has no url")
+	}
+	if raw[0] == '"' {
+		if err := json.Unmarshal(raw, &u.Raw); err != nil {
+			return u, fmt.Errorf("decode url: %w", err)
+		}
+		// 字符串 URL 的查询参数只在原文中
+		if i := strings.Index(u.Raw, "?"); i >= 0 {
+			for _, pair := range strings.Split(strings.SplitN(u.Raw[i+1:], "#", 2)[0], "&") {
+				key, value, _ := strings.Cut(pair, "=")
+				u.Query = append(u.Query, postmanKeyValue{Key: key, Value: value})
+			}
+		}
+		return u, nil
+	}
+	if err := json.Unmarshal(raw, &u); err != nil {
+		return u, fmt.Errorf("decode url: %w", err)
+	}
+	return u, nil
+}
+
+// postmanPath 返回 OpenAPI 路径和路径参数；:id 和 {{id}} 段转换为 {id}，主机部分（包括 {{baseUrl}}）被去掉
+func postmanPath(u postmanURL) (string, []postmanKeyValue) {
+	var segments []string
+	var pathString string
+	switch {
+	case json.Unmarshal(u.Path, &segments) == nil:
+	case json.Unmarshal(u.Path, &pathString) == nil:
+		segments = strings.Split(pathString, "/")
+	default:
+		raw := u.Raw
+		if i := strings.IndexAny(raw, "?#"); i >= 0 {
+			raw = raw[:i]
+		}
+		if i := strings.Index(raw, "://"); i >= 0 {
+			raw = raw[i+3:]
+		}
+		// 第一段是主机或 {{baseUrl}} 这样的变量
+		if i := strings.Index(raw, "/"); i >= 0 {
+			raw = raw[i:]
+		} else {
+			raw = ""
+		}
+		segments = strings.Split(raw, "/")
+	}
+
+	values := make(map[string]string)
+	for _, variable := range u.Variable {
+		values[variable.Key] = variable.Value
+	}
+	var parts []string
+	var params []postmanKeyValue
+	for _, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		if m := postmanVariable.FindStringSubmatch(segment); m != nil {
+			name := m[1] + m[2]
+			params = append(params, postmanKeyValue{Key: name, Value: values[name]})
+			segment = "{" + name + "}"
+		} else {
+			segment = url.PathEscape(segment)
+		}
+		parts = append(parts, segment)
+	}
+	return "/" + strings.Join(parts, "/"), params
+}
+
+// postmanDescription description 可以是字符串或 {content, type} 对象
+func postmanDescription(raw json.RawMessage) string {
+	var description string
+	if json.Unmarshal(raw, &description) == nil {
+		return strings.TrimSpace(description)
+	}
+	var object struct {
+		Content string `json:"content"`
+	}
+	if json.Unmarshal(raw, &object) == nil {
+		return strings.TrimSpace(object.Content)
+	}
+	return ""
+}
+
+// postmanValueSchema 从查询参数或路径变量的示例值推断类型
+func postmanValueSchema(value string) map[string]interface{} {
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return map[string]interface{}{"type": "integer"}
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return map[string]interface{}{"type": "number"}
+	}
+	if value == "true" || value == "false" {
+		return map[string]interface{}{"type": "boolean"}
+	}
+	return map[string]interface{}{"type": "string"}
+}
+
+// bodySchema 请求体的 schema：raw 模式解析 JSON 示例，urlencoded 和 formdata 的字段都是字符串
+func (c *postmanConverter) bodySchema(body *postmanBody, name string) map[string]interface{} {
+	if body == nil {
+		return nil
+	}
+	switch body.Mode {
+	case "raw":
+		value, ok := decodeExample(body.Raw)
+		if !ok {
+			return nil
+		}
+		return c.component(inferSchema([]interface{}{value}), name, "")
+	case "urlencoded", "formdata":
+		properties := make(map[string]interface{})
+		for _, field := range append(body.URLEncoded, body.FormData...) {
+			if field.Disabled || field.Key == "" {
+				continue
+			}
+			schema := map[string]interface{}{"type": "string"}
+			if field.Type == "file" {
+				schema["format"] = "binary"
+			}
+			properties[field.Key] = schema
+		}
+		if len(properties) == 0 {
+			return nil
+		}
+		return c.component(map[string]interface{}{"type": "object", "properties": properties}, name, "")
+	}
+	return nil
+}
+
+// responseSchema 合并所有 2xx 示例响应推断响应类型，没有状态码的示例也算作成功
+func (c *postmanConverter) responseSchema(responses []postmanResponse, name string) map[string]interface{} {
+	var samples []interface{}
+	for _, response := range responses {
+		if response.Code != 0 && (response.Code < 200 || response.Code > 299) {
+			continue
+		}
+		if value, ok := decodeExample(response.Body); ok {
+			samples = append(samples, value)
+		}
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+	return c.component(inferSchema(samples), name, "")
+}
+
+// decodeExample 解析 JSON 示例，数字保留原文以区分整数和小数
+func decodeExample(body string) (interface{}, bool) {
+	if strings.TrimSpace(body) == "" {
+		return nil, false
+	}
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// inferSchema 从一组示例值推断 schema：在所有示例中都出现且不为 null 的字段是必填的，
+// 类型不一致的值推断为任意类型，整数和小数合并为 number
+func inferSchema(samples []interface{}) map[string]interface{} {
+	kind := ""
+	nullable := false
+	for _, sample := range samples {
+		k := exampleKind(sample)
+		switch {
+		case k == "null":
+			nullable = true
+			continue
+		case kind == "" || kind == k:
+			kind = k
+		case (kind == "integer" && k == "number") || (kind == "number" && k == "integer"):
+			kind = "number"
+		default:
+			kind = "mixed"
+		}
+	}
+	schema := make(map[string]interface{})
+	if nullable {
+		schema["nullable"] = true
+	}
+	switch kind {
+	case "", "mixed":
+		return schema
+	case "object":
+		var objects []map[string]interface{}
+		for _, sample := range samples {
+			if object, ok := sample.(map[string]interface{}); ok {
+				objects = append(objects, object)
+			}
+		}
+		properties := make(map[string]interface{})
+		var required []string
+		for _, key := range exampleKeys(objects) {
+			var values []interface{}
+			present := true
+			for _, object := range objects {
+				value, ok := object[key]
+				if ok {
+					values = append(values, value)
+				}
+				present = present && ok && value != nil
+			}
+			properties[key] = inferSchema(values)
+			if present {
+				required = append(required, key)
+			}
+		}
+		schema["type"] = "object"
+		schema["properties"] = properties
+		if required != nil {
+			schema["required"] = required
+		}
+	case "array":
+		var items []interface{}
+		for _, sample := range samples {
+			if array, ok := sample.([]interface{}); ok {
+				items = append(items, array...)
+			}
+		}
+		schema["type"] = "array"
+		schema["items"] = inferSchema(items)
+	case "string":
+		schema["type"] = "string"
+		dateTime := true
+		for _, sample := range samples {
+			if s, ok := sample.(string); ok {
+				if _, err := time.Parse(time.RFC3339, s); err != nil {
+					dateTime = false
+				}
+			}
+		}
+		if dateTime {
+			schema["format"] = "date-time"
+		}
+	default:
+		schema["type"] = kind
+	}
+	return schema
+}
+
+// exampleKind 示例值的 JSON 类型
+func exampleKind(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			return "number"
+		}
+		return "integer"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "mixed"
+}
+
+// exampleKeys 所有示例对象中出现过的字段，按名称排序
+func exampleKeys(objects []map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, object := range objects {
+		for key := range object {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// component 把推断出的对象（包括嵌套对象和数组元素）提取为组件 schema，返回引用；结构相同的对象共用先注册的组件。
+// 嵌套对象优先以单数形式的字段名称命名，例如 users -> User，名称已被占用时使用 fallback（父名称加字段名称）
+func (c *postmanConverter) component(schema map[string]interface{}, name, fallback string) map[string]interface{} {
+	switch schema["type"] {
+	case "array":
+		itemFallback := fallback
+		if itemFallback == "" {
+			itemFallback = name
+		}
+		schema["items"] = c.component(schema["items"].(map[string]interface{}), name, itemFallback+"Item")
+		return schema
+	case "object":
+	default:
+		return schema
+	}
+	properties := schema["properties"].(map[string]interface{})
+	parent := fallback
+	if parent == "" {
+		parent = name
+	}
+	// 按字段名称顺序提取，单数名称相同的嵌套对象由排在前面的字段占用该名称
+	for _, key := range sortedKeys(properties) {
+		properties[key] = c.component(properties[key].(map[string]interface{}), pascalCase(singular(key)), parent+pascalCase(key))
+	}
+	shape, _ := json.Marshal(schema)
+	component, ok := c.shapes[string(shape)]
+	if !ok {
+		component = name
+		if component == "" || c.schemas[component] != nil {
+			component = parent
+		}
+		for i, base := 2, component; c.schemas[component] != nil; i++ {
+			component = base + strconv.Itoa(i)
+		}
+		c.shapes[string(shape)] = component
+		c.schemas[component] = schema
+	}
+	return map[string]interface{}{"$ref": "#/components/schemas/" + component}
+}
@@ -0,0 +1,60 @@
+// problems.go
+package generator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+)
+
+// problemCounts 一次生成记录的警告和错误数量；并行渲染时多个 goroutine 同时记录日志
+type problemCounts struct {
+	warnings, errors atomic.Int64
+	diagnostics      atomic.Int64 // errors 中 error 级别的诊断，见 diagnose
+}
+
+// countingHandler 统计警告和错误，日志照常交给原来的 Handler；-quiet 等过滤级别不影响统计
+type countingHandler struct {
+	slog.Handler
+	counts *problemCounts
+}
+
+func (h *countingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= slog.LevelWarn || h.Handler.Enabled(ctx, level)
+}
+
+func (h *countingHandler) Handle(ctx context.Context, r slog.Record) error {
+	switch {
+	case r.Level >= slog.LevelError:
+		h.counts.errors.Add(1)
+	case r.Level >= slog.LevelWarn:
+		h.counts.warnings.Add(1)
+	}
+	if !h.Handler.Enabled(ctx, r.Level) {
+		return nil
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *countingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &countingHandler{Handler: h.Handler.WithAttrs(attrs), counts: h.counts}
+}
+
+func (h *countingHandler) WithGroup(name string) slog.Handler {
+	return &countingHandler{Handler: h.Handler.WithGroup(name), counts: h.counts}
+}
+
+// checkProblems 生成过程中记录过错误时返回错误，避免缺少文件的输出被当作成功；error 级别的诊断和 -warnings-as-errors 时的警告同样返回错误
+func (r *run) checkProblems() error {
+	errors, warnings, diagnostics := r.problems.errors.Load(), r.problems.warnings.Load(), r.problems.diagnostics.Load()
+	switch {
+	case errors > diagnostics:
+		return fmt.Errorf("%d errors during generation, the output is incomplete", errors-diagnostics)
+	case diagnostics > 0:
+		return fmt.Errorf("%d diagnostics with level error, see -diagnostic", diagnostics)
+	case r.warningsAsErrors && warnings > 0:
+		return fmt.Errorf("%d warnings during generation, failing because of -warnings-as-errors", warnings)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// progress.go
+package generator
+
+import "sync"
+
+// 生成阶段，见 Progress
+const (
+	StageParse      = "parse"      // 解析和校验文档
+	StageModels     = "models"     // 渲染模型（TypeScript 接口）
+	StageOperations = "operations" // 渲染接口函数或服务方法
+	StageModules    = "modules"    // 渲染模块文件
+	StageFinish     = "finish"     // 检查循环导入和不支持的部分、运行插件、添加头部注释
+)
+
+// Progress 生成进度：阶段开始时 Done 为 0，之后每完成一项报告一次
+type Progress struct {
+	Stage string
+	Done  int
+	Total int
+}
+
+// progressTracker 一个阶段的进度；并行渲染时多个 goroutine 共用，回调按顺序调用，不会同时进行
+type progressTracker struct {
+	*run
+	mu    sync.Mutex
+	stage string
+	done  int
+	total int
+}
+
+// startProgress 开始一个阶段并报告 0/total，没有设置回调时返回 nil，nil 的 step 不做任何事
+func (r *run) startProgress(stage string, total int) *progressTracker {
+	if r.progress == nil {
+		return nil
+	}
+	r.progress(Progress{Stage: stage, Total: total})
+	return &progressTracker{run: r, stage: stage, total: total}
+}
+
+// step 完成一项
+func (t *progressTracker) step() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.done++
+	t.progress(Progress{Stage: t.stage, Done: t.done, Total: t.total})
+}
@@ -0,0 +1,699 @@
+// proto.go
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// 输入文档格式
+const (
+	InputOpenAPI       = "openapi"        // OpenAPI 3.x（YAML 或 JSON）
+	InputProto         = "proto"          // .proto 源文件
+	InputDescriptorSet = "descriptor-set" // protoc --descriptor_set_out 或 buf build 生成的二进制文件
+	InputPostman       = "postman"        // Postman v2.1 集合（JSON）
+)
+
+// descriptorSetExts 按扩展名识别为 FileDescriptorSet 的文件
+var descriptorSetExts = map[string]bool{".pb": true, ".binpb": true, ".desc": true, ".protoset": true, ".bin": true}
+
+// inputFormat 返回文档格式，format 为空时按 source（文件路径或 URL）的扩展名判断
+func inputFormat(format, source string) string {
+	if format != "" {
+		return format
+	}
+	if u, err := url.Parse(source); err == nil && u.Scheme != "" && u.Host != "" {
+		source = u.Path
+	}
+	source = strings.ToLower(strings.ReplaceAll(source, `\`, "/"))
+	ext := path.Ext(source)
+	switch {
+	case strings.HasSuffix(source, ".postman_collection.json"):
+		return InputPostman
+	case ext == ".proto":
+		return InputProto
+	case descriptorSetExts[ext]:
+		return InputDescriptorSet
+	}
+	return InputOpenAPI
+}
+
+// openAPI 返回生成使用的 OpenAPI 文档，proto 和 Postman 输入先转换，BundleRefs 时合并其他文件；
+// 文本输入先经过 normalizeText，未指定格式的 JSON 文档按内容识别 Postman 集合
+func (r *run) openAPI(spec []byte) ([]byte, error) {
+	format := inputFormat(r.opts.InputFormat, r.opts.Source)
+	var err error
+	if format == InputOpenAPI || format == InputPostman {
+		if spec, err = normalizeText(spec); err != nil {
+			return nil, err
+		}
+	}
+	if format == InputOpenAPI && r.opts.InputFormat == "" && isPostmanCollection(spec) {
+		format = InputPostman
+	}
+	r.specConverted = format != InputOpenAPI
+	var files []*protoFile
+	switch format {
+	case InputPostman:
+		converted, err := r.postmanToOpenAPI(spec)
+		if err != nil {
+			return nil, fmt.Errorf("convert postman collection: %w", err)
+		}
+		return converted, nil
+	case InputProto:
+		name := path.Base(filepath.ToSlash(r.opts.Source))
+		if r.opts.Source == "" {
+			name = "input.proto"
+		}
+		files, err = r.loadProtoFiles(name, spec, r.opts.ProtoPaths)
+	case InputDescriptorSet:
+		files, err = r.parseDescriptorSet(spec)
+	default:
+		if err := r.checkSingleDocument(spec); err != nil {
+			return nil, err
+		}
+		if r.opts.BundleRefs && r.opts.Source != "" {
+			bundled, ok, err := r.bundleSpecFiles(spec, r.opts.Source)
+			if err != nil {
+				return nil, err
+			}
+			// 合并后的文档重新序列化，行号不再对应原来的文件
+			r.specConverted = ok
+			return bundled, nil
+		}
+		return spec, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse proto: %w", err)
+	}
+	spec, err = r.protoToOpenAPI(files)
+	if err != nil {
+		return nil, fmt.Errorf("convert proto: %w", err)
+	}
+	return spec, nil
+}
+
+// protoFile 一个 .proto 文件中生成 HTTP 客户端需要的定义，消息、枚举和服务的名称都是不带前导点的完整名称
+type protoFile struct {
+	Name     string
+	Package  string
+	Imports  []string
+	Messages []*protoMessage // 包含嵌套消息
+	Enums    []*protoEnum    // 包含嵌套枚举
+	Services []*protoService
+	Import   bool // buf image 中作为依赖引入的文件，不生成其中的服务
+}
+
+type protoMessage struct {
+	Name     string
+	Package  string
+	Comment  string
+	Fields   []protoField
+	MapEntry bool // 描述文件中 map 字段对应的 XxxEntry 消息
+}
+
+type protoField struct {
+	Name     string
+	JSONName string
+	Comment  string
+	Type     string // 标量类型名称，或消息、枚举的完整名称
+	MapKey   string // 非空时为 map 字段，值类型为 Type
+	Repeated bool
+	Required bool // proto2 required 或 (google.api.field_behavior) = REQUIRED
+
+	scope string // .proto 源文件中字段所在的作用域，解析类型名称后清空
+}
+
+type protoEnum struct {
+	Name    string
+	Package string
+	Comment string
+	Values  []string
+}
+
+type protoService struct {
+	Name    string
+	Comment string
+	Methods []protoMethod
+}
+
+type protoMethod struct {
+	Name      string
+	Comment   string
+	Input     string
+	Output    string
+	Streaming bool
+	HTTP      *httpRule // option (google.api.http)，为空表示没有 HTTP 映射
+
+	scope string
+}
+
+// httpRule google.api.HttpRule 中生成客户端需要的部分，additional_bindings 不生成
+type httpRule struct {
+	Method       string // 大写
+	Path         string
+	Body         string
+	ResponseBody string
+}
+
+// protoScalars proto 标量类型对应的 OpenAPI 类型，64 位整数在 proto3 JSON 中编码为字符串
+var protoScalars = map[string][2]string{
+	"double":   {"number", "double"},
+	"float":    {"number", "float"},
+	"int32":    {"integer", "int32"},
+	"sint32":   {"integer", "int32"},
+	"sfixed32": {"integer", "int32"},
+	"uint32":   {"integer", "int64"},
+	"fixed32":  {"integer", "int64"},
+	"int64":    {"string", "int64"},
+	"sint64":   {"string", "int64"},
+	"sfixed64": {"string", "int64"},
+	"uint64":   {"string", "uint64"},
+	"fixed64":  {"string", "uint64"},
+	"bool":     {"boolean", ""},
+	"string":   {"string", ""},
+	"bytes":    {"string", "byte"},
+}
+
+// protoWellKnown google.protobuf 中的常用类型按 proto3 JSON 编码映射为内联 schema
+var protoWellKnown = map[string]map[string]interface{}{
+	"google.protobuf.Timestamp":   {"type": "string", "format": "date-time"},
+	"google.protobuf.Duration":    {"type": "string"},
+	"google.protobuf.FieldMask":   {"type": "string"},
+	"google.protobuf.Empty":       {"type": "object"},
+	"google.protobuf.Struct":      {"type": "object"},
+	"google.protobuf.Any":         {"type": "object"},
+	"google.protobuf.Value":       {},
+	"google.protobuf.ListValue":   {"type": "array", "items": map[string]interface{}{}},
+	"google.protobuf.NullValue":   {},
+	"google.protobuf.DoubleValue": {"type": "number", "format": "double"},
+	"google.protobuf.FloatValue":  {"type": "number", "format": "float"},
+	"google.protobuf.Int64Value":  {"type": "string", "format": "int64"},
+	"google.protobuf.UInt64Value": {"type": "string", "format": "uint64"},
+	"google.protobuf.Int32Value":  {"type": "integer", "format": "int32"},
+	"google.protobuf.UInt32Value": {"type": "integer", "format": "int64"},
+	"google.protobuf.BoolValue":   {"type": "boolean"},
+	"google.protobuf.StringValue": {"type": "string"},
+	"google.protobuf.BytesValue":  {"type": "string", "format": "byte"},
+}
+
+// protoEmpty 请求或响应为 Empty 时不生成参数或响应体
+const protoEmpty = "google.protobuf.Empty"
+
+// pathTemplateVariable 匹配 HTTP 路径模板中的 {name=projects/*} 变量
+var pathTemplateVariable = regexp.MustCompile(`\{([^}=]+)(=[^}]*)?\}`)
+
+// protoToOpenAPI 将 proto 定义转换为 OpenAPI 文档（JSON）：带 (google.api.http) 注解的方法生成接口，
+// 请求参数为整个请求消息（路径参数从中替换），被引用的消息和枚举生成 components/schemas
+func (r *run) protoToOpenAPI(files []*protoFile) ([]byte, error) {
+	c := &protoConverter{run: r,
+
+		messages: make(map[string]*protoMessage),
+		enums:    make(map[string]*protoEnum),
+		names:    make(map[string]string),
+	}
+	for _, file := range files {
+		for _, message := range file.Messages {
+			c.messages[message.Name] = message
+		}
+		for _, enum := range file.Enums {
+			c.enums[enum.Name] = enum
+		}
+	}
+
+	var methods []protoHTTPMethod
+	routes := make(map[string]string) // METHOD path -> 方法完整名称
+	for _, file := range files {
+		if file.Import {
+			continue
+		}
+		for _, service := range file.Services {
+			for _, method := range service.Methods {
+				fullName := service.Name + "." + method.Name
+				switch {
+				case method.HTTP == nil:
+					r.logger.Debug("skip rpc without google.api.http annotation", "rpc", fullName)
+					continue
+				case method.Streaming:
+					r.logger.Warn("skip streaming rpc, only unary methods are supported", "rpc", fullName)
+					continue
+				case !protoHTTPMethods[method.HTTP.Method]:
+					r.logger.Warn("skip rpc with unsupported HTTP method", "rpc", fullName, "method", method.HTTP.Method)
+					continue
+				}
+				httpPath, variables := c.pathVariables(method, pathTemplateVariable.ReplaceAllString(method.HTTP.Path, "{$1}"))
+				route := method.HTTP.Method + " " + httpPath
+				if other, exists := routes[route]; exists {
+					r.logger.Warn("skip rpc mapped to the same route as another rpc", "rpc", fullName, "route", route, "other", other)
+					continue
+				}
+				routes[route] = fullName
+				methods = append(methods, protoHTTPMethod{service: service, method: method, path: httpPath, variables: variables})
+			}
+		}
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("no rpc with a google.api.http annotation found")
+	}
+
+	// 先收集被引用的类型再命名，只有名称冲突的类型才带上包名
+	for _, m := range methods {
+		for _, name := range []string{m.method.Input, m.method.Output} {
+			if err := c.collect(name); err != nil {
+				return nil, fmt.Errorf("rpc %s.%s: %w", m.service.Name, m.method.Name, err)
+			}
+		}
+	}
+	c.assignNames()
+
+	paths := make(map[string]map[string]interface{})
+	for _, m := range methods {
+		operation, err := c.operation(m)
+		if err != nil {
+			return nil, fmt.Errorf("rpc %s.%s: %w", m.service.Name, m.method.Name, err)
+		}
+		if paths[m.path] == nil {
+			paths[m.path] = make(map[string]interface{})
+		}
+		paths[m.path][strings.ToLower(m.method.HTTP.Method)] = operation
+	}
+
+	schemas := make(map[string]interface{})
+	for fullName, name := range c.names {
+		if enum, ok := c.enums[fullName]; ok {
+			schemas[name] = c.enumSchema(enum)
+		} else {
+			schemas[name] = c.messageSchema(c.messages[fullName])
+		}
+	}
+	return json.MarshalIndent(map[string]interface{}{
+		"openapi":    "3.0.3",
+		"info":       map[string]interface{}{"title": files[0].Package, "version": "1.0.0"},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": schemas},
+	}, "", "  ")
+}
+
+// protoHTTPMethods 生成的客户端支持的 HTTP 方法
+var protoHTTPMethods = map[string]bool{"GET": true, "POST": true, "PUT": true, "DELETE": true}
+
+// protoHTTPMethod 生成接口的 rpc
+type protoHTTPMethod struct {
+	service   *protoService
+	method    protoMethod
+	path      string            // 变量已简化为 {name}，并按 pathVariables 改名
+	variables map[string]string // 路径中的变量名称 -> 请求消息中的字段路径，例如 id -> user.id
+}
+
+// protoConverter 记录被引用的消息和枚举及其 schema 名称
+type protoConverter struct {
+	*run
+	messages map[string]*protoMessage
+	enums    map[string]*protoEnum
+	names    map[string]string // 被引用类型的完整名称 -> schema 名称
+}
+
+// collect 记录类型及其字段引用的所有类型
+func (c *protoConverter) collect(name string) error {
+	if _, seen := c.names[name]; seen || protoWellKnown[name] != nil || protoScalars[name] != [2]string{} {
+		return nil
+	}
+	if _, ok := c.enums[name]; ok {
+		c.names[name] = ""
+		return nil
+	}
+	message, ok := c.messages[name]
+	if !ok {
+		return fmt.Errorf("unknown type %s", name)
+	}
+	if message.MapEntry {
+		return nil
+	}
+	c.names[name] = ""
+	for _, field := range message.Fields {
+		if entry := c.mapEntry(field); entry != nil {
+			if err := c.collect(entry.Fields[1].Type); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := c.collect(field.Type); err != nil {
+			return fmt.Errorf("field %s.%s: %w", message.Name, field.Name, err)
+		}
+	}
+	return nil
+}
+
+// mapEntry 返回描述文件中 map 字段对应的 XxxEntry 消息，不是 map 字段时返回 nil
+func (c *protoConverter) mapEntry(field protoField) *protoMessage {
+	if !field.Repeated {
+		return nil
+	}
+	if entry, ok := c.messages[field.Type]; ok && entry.MapEntry && len(entry.Fields) == 2 {
+		return entry
+	}
+	return nil
+}
+
+// assignNames schema 名称去掉包名、嵌套类型直接拼接（Outer.Inner -> OuterInner），
+// 不同包中同名的类型加上包名前缀（api.user.v1.User -> ApiUserV1User）
+func (c *protoConverter) assignNames() {
+	short := func(fullName, pkg string) string {
+		name := fullName
+		if pkg != "" {
+			name = strings.TrimPrefix(fullName, pkg+".")
+		}
+		return strings.ReplaceAll(name, ".", "")
+	}
+	packages := make(map[string]string)
+	count := make(map[string]int)
+	for fullName := range c.names {
+		pkg := ""
+		if message, ok := c.messages[fullName]; ok {
+			pkg = message.Package
+		} else {
+			pkg = c.enums[fullName].Package
+		}
+		packages[fullName] = pkg
+		count[short(fullName, pkg)]++
+	}
+	for fullName := range c.names {
+		pkg := packages[fullName]
+		name := short(fullName, pkg)
+		if count[name] > 1 && pkg != "" {
+			var prefix string
+			for _, part := range strings.Split(pkg, ".") {
+				prefix += capitalize(part)
+			}
+			name = prefix + name
+		}
+		c.names[fullName] = name
+	}
+}
+
+// operation 生成 rpc 对应的 OpenAPI operation
+func (c *protoConverter) operation(m protoHTTPMethod) (map[string]interface{}, error) {
+	serviceName := m.service.Name[strings.LastIndex(m.service.Name, ".")+1:]
+	operation := map[string]interface{}{
+		"operationId": serviceName + "_" + m.method.Name,
+		"tags":        []string{serviceName},
+	}
+	if summary := firstLine(m.method.Comment); summary != "" {
+		operation["summary"] = summary
+	}
+	if parameters := c.pathParameters(m); len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+	if m.method.Input != protoEmpty {
+		schema := c.typeSchema(m.method.Input)
+		// body: "field" 时请求体只是该字段，路径变量不在其中
+		if field := m.method.HTTP.Body; field != "" && field != "*" {
+			f, ok := c.field(m.method.Input, field)
+			if !ok {
+				return nil, fmt.Errorf("body field %q not found in %s", field, m.method.Input)
+			}
+			schema = c.fieldSchema(f)
+			delete(schema, "description")
+		}
+		operation["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}},
+		}
+	}
+
+	response := map[string]interface{}{"description": "OK"}
+	if m.method.Output != protoEmpty {
+		schema := c.typeSchema(m.method.Output)
+		if field := m.method.HTTP.ResponseBody; field != "" {
+			f, ok := c.field(m.method.Output, field)
+			if !ok {
+				return nil, fmt.Errorf("response_body field %q not found in %s", field, m.method.Output)
+			}
+			schema = c.fieldSchema(f)
+			delete(schema, "description")
+		}
+		response["content"] = map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}}
+	}
+	operation["responses"] = map[string]interface{}{"200": response}
+	return operation, nil
+}
+
+// pathVariables 把路径变量改名为 params 中读取它的字段，返回改名后的路径和变量对应的字段路径：
+// body 为某个字段时 {user.id} 读取请求体中的 id，改为 {id}；其余的单级变量改为 JSON 名称，例如 {user_id} -> {userId}；
+// 多级变量保持原名，生成的客户端把它作为 params 之外的路径参数
+func (c *protoConverter) pathVariables(method protoMethod, path string) (string, map[string]string) {
+	variables := make(map[string]string)
+	path = pathTemplatePattern.ReplaceAllStringFunc(path, func(match string) string {
+		fieldPath := match[1 : len(match)-1]
+		message, parts := method.Input, strings.Split(fieldPath, ".")
+		if body := method.HTTP.Body; len(parts) > 1 && parts[0] == body {
+			if f, ok := c.field(method.Input, body); ok {
+				message, parts = f.Type, parts[1:]
+			}
+		}
+		name := fieldPath
+		if f, ok := c.field(message, parts[0]); ok && len(parts) == 1 {
+			name = f.JSONName
+			if name == "" {
+				name = protoJSONName(f.Name)
+			}
+		}
+		if other, exists := variables[name]; exists && other != fieldPath {
+			name = fieldPath
+		}
+		variables[name] = fieldPath
+		return "{" + name + "}"
+	})
+	return path, variables
+}
+
+// pathParameters 路径模板中的变量对应的 path 参数，类型取自请求消息中读取它的字段（见 pathVariables），
+// 嵌套字段例如 {team.id} 逐级查找；找不到字段或字段不是标量时为 string
+func (c *protoConverter) pathParameters(m protoHTTPMethod) []interface{} {
+	var parameters []interface{}
+	seen := make(map[string]bool)
+	for _, match := range pathTemplatePattern.FindAllStringSubmatch(m.path, -1) {
+		name := match[1]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		parameter := map[string]interface{}{"name": name, "in": "path", "required": true, "schema": map[string]interface{}{"type": "string"}}
+		message := m.method.Input
+		parts := strings.Split(m.variables[name], ".")
+		for i, part := range parts {
+			f, ok := c.field(message, part)
+			if !ok {
+				break
+			}
+			if i < len(parts)-1 {
+				message = f.Type
+				continue
+			}
+			if schema := c.typeSchema(f.Type); schema["$ref"] == nil && !f.Repeated && f.MapKey == "" && c.mapEntry(f) == nil {
+				parameter["schema"] = schema
+			}
+			if f.Comment != "" {
+				parameter["description"] = f.Comment
+			}
+		}
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
+
+// field 按 proto 字段名称查找消息中的字段
+func (c *protoConverter) field(message, name string) (protoField, bool) {
+	if m, ok := c.messages[message]; ok {
+		for _, field := range m.Fields {
+			if field.Name == name {
+				return field, true
+			}
+		}
+	}
+	return protoField{}, false
+}
+
+// messageSchema 消息对应的 object schema，字段使用 proto3 JSON 名称
+func (c *protoConverter) messageSchema(message *protoMessage) map[string]interface{} {
+	properties := make(map[string]interface{})
+	var required []string
+	for _, field := range message.Fields {
+		name := field.JSONName
+		if name == "" {
+			name = protoJSONName(field.Name)
+		}
+		properties[name] = c.fieldSchema(field)
+		if field.Required {
+			required = append(required, name)
+		}
+	}
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	if message.Comment != "" {
+		schema["description"] = message.Comment
+	}
+	return schema
+}
+
+// enumSchema 枚举在 proto3 JSON 中编码为取值名称
+func (c *protoConverter) enumSchema(enum *protoEnum) map[string]interface{} {
+	schema := map[string]interface{}{"type": "string", "enum": enum.Values}
+	if enum.Comment != "" {
+		schema["description"] = enum.Comment
+	}
+	return schema
+}
+
+// fieldSchema 字段的 schema，map 的值只保留类型
+func (c *protoConverter) fieldSchema(field protoField) map[string]interface{} {
+	var schema map[string]interface{}
+	switch entry := c.mapEntry(field); {
+	case entry != nil:
+		schema = map[string]interface{}{"type": "object", "additionalProperties": c.valueType(entry.Fields[1].Type)}
+	case field.MapKey != "":
+		schema = map[string]interface{}{"type": "object", "additionalProperties": c.valueType(field.Type)}
+	case field.Repeated:
+		schema = map[string]interface{}{"type": "array", "items": c.typeSchema(field.Type)}
+	default:
+		schema = c.typeSchema(field.Type)
+	}
+	if field.Comment != "" {
+		schema["description"] = field.Comment
+	}
+	return schema
+}
+
+// valueType map 值的类型，additionalProperties 只支持类型名称
+func (c *protoConverter) valueType(name string) map[string]interface{} {
+	schema := c.typeSchema(name)
+	if _, isRef := schema["$ref"]; isRef {
+		if _, isEnum := c.enums[name]; isEnum {
+			return map[string]interface{}{"type": "string"}
+		}
+		return map[string]interface{}{"type": "object"}
+	}
+	if schema["type"] == nil {
+		return map[string]interface{}{"type": "object"}
+	}
+	return map[string]interface{}{"type": schema["type"]}
+}
+
+// typeSchema 标量和常用类型生成内联 schema，其他消息和枚举生成 $ref
+func (c *protoConverter) typeSchema(name string) map[string]interface{} {
+	if scalar, ok := protoScalars[name]; ok {
+		schema := map[string]interface{}{"type": scalar[0]}
+		if scalar[1] != "" {
+			schema["format"] = scalar[1]
+		}
+		return schema
+	}
+	if known, ok := protoWellKnown[name]; ok {
+		schema := make(map[string]interface{}, len(known))
+		for key, value := range known {
+			schema[key] = value
+		}
+		return schema
+	}
+	return map[string]interface{}{"$ref": "#/components/schemas/" + c.names[name]}
+}
+
+// protoJSONName 与 protoc 相同的 json_name 规则：去掉下划线，下划线后的字母大写
+func protoJSONName(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper && r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		upper = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// firstLine 注释的第一行作为接口摘要
+func firstLine(comment string) string {
+	line, _, _ := strings.Cut(comment, "\n")
+	return strings.TrimSpace(line)
+}
+
+// resolveProtoTypes 按 protobuf 的作用域规则将 .proto 源文件中的类型名称解析为完整名称：
+// 从字段所在的作用域开始逐级向外查找，以 . 开头的名称为完整名称
+func resolveProtoTypes(files []*protoFile) error {
+	defined := make(map[string]bool)
+	for _, file := range files {
+		for _, message := range file.Messages {
+			defined[message.Name] = true
+		}
+		for _, enum := range file.Enums {
+			defined[enum.Name] = true
+		}
+	}
+	resolve := func(name, scope string) (string, error) {
+		if _, ok := protoScalars[name]; ok {
+			return name, nil
+		}
+		if strings.HasPrefix(name, ".") {
+			name = name[1:]
+			if defined[name] || protoWellKnown[name] != nil {
+				return name, nil
+			}
+			return "", fmt.Errorf("unknown type .%s", name)
+		}
+		for {
+			candidate := name
+			if scope != "" {
+				candidate = scope + "." + name
+			}
+			if defined[candidate] || protoWellKnown[candidate] != nil {
+				return candidate, nil
+			}
+			if scope == "" {
+				return "", fmt.Errorf("unknown type %s", name)
+			}
+			if i := strings.LastIndex(scope, "."); i >= 0 {
+				scope = scope[:i]
+			} else {
+				scope = ""
+			}
+		}
+	}
+
+	for _, file := range files {
+		for _, message := range file.Messages {
+			for i := range message.Fields {
+				field := &message.Fields[i]
+				name, err := resolve(field.Type, field.scope)
+				if err != nil {
+					return fmt.Errorf("%s: field %s.%s: %w", file.Name, message.Name, field.Name, err)
+				}
+				field.Type, field.scope = name, ""
+			}
+		}
+		for _, service := range file.Services {
+			for i := range service.Methods {
+				method := &service.Methods[i]
+				for _, name := range []*string{&method.Input, &method.Output} {
+					resolved, err := resolve(*name, method.scope)
+					if err != nil {
+						return fmt.Errorf("%s: rpc %s.%s: %w", file.Name, service.Name, method.Name, err)
+					}
+					*name = resolved
+				}
+				method.scope = ""
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,379 @@
+// protodesc.go
+package generator
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FileDescriptorSet（protoc --descriptor_set_out、buf build -o image.binpb）使用 protobuf 二进制编码，
+// 这里只解码生成客户端需要的字段，字段编号见 google/protobuf/descriptor.proto 和 google/api/http.proto
+
+// protoFieldTypes FieldDescriptorProto.Type 对应的标量类型名称，消息和枚举使用 type_name
+var protoFieldTypes = map[uint64]string{
+	1: "double", 2: "float", 3: "int64", 4: "uint64", 5: "int32", 6: "fixed64", 7: "fixed32", 8: "bool",
+	9: "string", 12: "bytes", 13: "uint32", 15: "sfixed32", 16: "sfixed64", 17: "sint32", 18: "sint64",
+}
+
+// 扩展字段编号
+const (
+	httpRuleExtension      = 72295728 // MethodOptions 中的 (google.api.http)
+	fieldBehaviorExtension = 1052     // FieldOptions 中的 (google.api.field_behavior)
+	fieldBehaviorRequired  = 2
+	bufImageFileExtension  = 8042 // buf image 中 ImageFile 的 buf_extension
+)
+
+// wireField 一个已解码的字段，varint 和定长字段的值在 num 中，长度分隔字段的内容在 bytes 中
+type wireField struct {
+	number int
+	num    uint64
+	bytes  []byte
+	wire   int
+}
+
+// decodeWire 依次解码消息中的字段
+func decodeWire(data []byte, fn func(f wireField) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return fmt.Errorf("invalid field key")
+		}
+		data = data[n:]
+		f := wireField{number: int(key >> 3), wire: int(key & 7)}
+		switch f.wire {
+		case 0:
+			f.num, n = binary.Uvarint(data)
+			if n <= 0 {
+				return fmt.Errorf("invalid varint in field %d", f.number)
+			}
+			data = data[n:]
+		case 1:
+			if len(data) < 8 {
+				return fmt.Errorf("truncated field %d", f.number)
+			}
+			f.num, data = binary.LittleEndian.Uint64(data), data[8:]
+		case 2:
+			size, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < size {
+				return fmt.Errorf("truncated field %d", f.number)
+			}
+			f.bytes, data = data[n:n+int(size)], data[n+int(size):]
+		case 5:
+			if len(data) < 4 {
+				return fmt.Errorf("truncated field %d", f.number)
+			}
+			f.num, data = uint64(binary.LittleEndian.Uint32(data)), data[4:]
+		default:
+			return fmt.Errorf("unsupported wire type %d in field %d", f.wire, f.number)
+		}
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseDescriptorSet 解码 FileDescriptorSet，buf image 与其二进制兼容
+func (r *run) parseDescriptorSet(data []byte) ([]*protoFile, error) {
+	var files []*protoFile
+	err := decodeWire(data, func(f wireField) error {
+		if f.number != 1 || f.wire != 2 {
+			return nil
+		}
+		file, err := r.decodeFileDescriptor(f.bytes)
+		if err != nil {
+			return fmt.Errorf("file %d: %w", len(files)+1, err)
+		}
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("decode FileDescriptorSet: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("decode FileDescriptorSet: no files")
+	}
+	return files, nil
+}
+
+// descriptorDecoder 解码一个 FileDescriptorProto，comments 为 SourceCodeInfo 中按路径索引的注释
+type descriptorDecoder struct {
+	*run
+	file     *protoFile
+	comments map[string]string
+}
+
+func (r *run) decodeFileDescriptor(data []byte) (*protoFile, error) {
+	d := &descriptorDecoder{run: r, file: &protoFile{}, comments: make(map[string]string)}
+	// 第一遍读取包名和注释，消息名称和注释都依赖它们
+	err := decodeWire(data, func(f wireField) error {
+		switch f.number {
+		case 1:
+			d.file.Name = string(f.bytes)
+		case 2:
+			d.file.Package = string(f.bytes)
+		case 9:
+			return d.sourceCodeInfo(f.bytes)
+		case bufImageFileExtension:
+			return decodeWire(f.bytes, func(f wireField) error {
+				if f.number == 1 && f.wire == 0 {
+					d.file.Import = f.num != 0
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var messages, enums, services int
+	err = decodeWire(data, func(f wireField) error {
+		switch f.number {
+		case 3:
+			d.file.Imports = append(d.file.Imports, string(f.bytes))
+		case 4:
+			messages++
+			return d.message(f.bytes, d.file.Package, []int{4, messages - 1})
+		case 5:
+			enums++
+			return d.enum(f.bytes, d.file.Package, []int{5, enums - 1})
+		case 6:
+			services++
+			return d.service(f.bytes, []int{6, services - 1})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", d.file.Name, err)
+	}
+	return d.file, nil
+}
+
+// sourceCodeInfo 读取 SourceCodeInfo.Location 中的前置注释
+func (d *descriptorDecoder) sourceCodeInfo(data []byte) error {
+	return decodeWire(data, func(f wireField) error {
+		if f.number != 1 {
+			return nil
+		}
+		var path []int
+		var comment string
+		err := decodeWire(f.bytes, func(f wireField) error {
+			switch {
+			case f.number == 1 && f.wire == 2:
+				for packed := f.bytes; len(packed) > 0; {
+					value, n := binary.Uvarint(packed)
+					if n <= 0 {
+						return fmt.Errorf("invalid source location path")
+					}
+					path, packed = append(path, int(value)), packed[n:]
+				}
+			case f.number == 1 && f.wire == 0:
+				path = append(path, int(f.num))
+			case f.number == 3:
+				comment = string(f.bytes)
+			}
+			return nil
+		})
+		if comment != "" {
+			d.comments[pathKey(path)] = cleanComment(comment)
+		}
+		return err
+	})
+}
+
+// message 解码 DescriptorProto，path 为它在 SourceCodeInfo 中的路径
+func (d *descriptorDecoder) message(data []byte, scope string, path []int) error {
+	message := &protoMessage{Package: d.file.Package, Comment: d.comments[pathKey(path)]}
+	d.file.Messages = append(d.file.Messages, message)
+	var fields, nested, enums int
+	return decodeWire(data, func(f wireField) error {
+		switch f.number {
+		case 1:
+			message.Name = scopedName(scope, string(f.bytes))
+		case 2:
+			fields++
+			field, err := d.field(f.bytes, d.comments[pathKey(append(path, 2, fields-1))])
+			if err != nil {
+				return err
+			}
+			message.Fields = append(message.Fields, field)
+		case 3:
+			nested++
+			return d.message(f.bytes, message.Name, append(path[:len(path):len(path)], 3, nested-1))
+		case 4:
+			enums++
+			return d.enum(f.bytes, message.Name, append(path[:len(path):len(path)], 4, enums-1))
+		case 7:
+			return decodeWire(f.bytes, func(f wireField) error {
+				if f.number == 7 && f.wire == 0 {
+					message.MapEntry = f.num != 0
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+}
+
+// field 解码 FieldDescriptorProto
+func (d *descriptorDecoder) field(data []byte, comment string) (protoField, error) {
+	field := protoField{Comment: comment}
+	err := decodeWire(data, func(f wireField) error {
+		switch f.number {
+		case 1:
+			field.Name = string(f.bytes)
+		case 4:
+			field.Repeated = f.num == 3
+			field.Required = f.num == 2
+		case 5:
+			if name, ok := protoFieldTypes[f.num]; ok {
+				field.Type = name
+			} else if f.num == 10 {
+				return fmt.Errorf("field %s: proto2 groups are not supported", field.Name)
+			}
+		case 6:
+			field.Type = strings.TrimPrefix(string(f.bytes), ".")
+		case 10:
+			field.JSONName = string(f.bytes)
+		case 8:
+			return decodeWire(f.bytes, func(f wireField) error {
+				if f.number != fieldBehaviorExtension {
+					return nil
+				}
+				if f.wire == 0 {
+					field.Required = field.Required || f.num == fieldBehaviorRequired
+					return nil
+				}
+				// packed repeated enum
+				for packed := f.bytes; len(packed) > 0; {
+					value, n := binary.Uvarint(packed)
+					if n <= 0 {
+						return fmt.Errorf("invalid field_behavior")
+					}
+					field.Required = field.Required || value == fieldBehaviorRequired
+					packed = packed[n:]
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	return field, err
+}
+
+// enum 解码 EnumDescriptorProto
+func (d *descriptorDecoder) enum(data []byte, scope string, path []int) error {
+	enum := &protoEnum{Package: d.file.Package, Comment: d.comments[pathKey(path)]}
+	d.file.Enums = append(d.file.Enums, enum)
+	return decodeWire(data, func(f wireField) error {
+		switch f.number {
+		case 1:
+			enum.Name = scopedName(scope, string(f.bytes))
+		case 2:
+			return decodeWire(f.bytes, func(f wireField) error {
+				if f.number == 1 {
+					enum.Values = append(enum.Values, string(f.bytes))
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+}
+
+// service 解码 ServiceDescriptorProto
+func (d *descriptorDecoder) service(data []byte, path []int) error {
+	service := &protoService{Comment: d.comments[pathKey(path)]}
+	d.file.Services = append(d.file.Services, service)
+	return decodeWire(data, func(f wireField) error {
+		switch f.number {
+		case 1:
+			service.Name = scopedName(d.file.Package, string(f.bytes))
+		case 2:
+			method, err := d.method(f.bytes, d.comments[pathKey(append(path, 2, len(service.Methods)))])
+			if err != nil {
+				return err
+			}
+			service.Methods = append(service.Methods, method)
+		}
+		return nil
+	})
+}
+
+// method 解码 MethodDescriptorProto 及其 (google.api.http) 选项
+func (d *descriptorDecoder) method(data []byte, comment string) (protoMethod, error) {
+	method := protoMethod{Comment: comment}
+	err := decodeWire(data, func(f wireField) error {
+		switch f.number {
+		case 1:
+			method.Name = string(f.bytes)
+		case 2:
+			method.Input = strings.TrimPrefix(string(f.bytes), ".")
+		case 3:
+			method.Output = strings.TrimPrefix(string(f.bytes), ".")
+		case 4:
+			return decodeWire(f.bytes, func(f wireField) error {
+				if f.number != httpRuleExtension {
+					return nil
+				}
+				rule, err := d.decodeHTTPRule(f.bytes)
+				method.HTTP = rule
+				return err
+			})
+		case 5, 6:
+			method.Streaming = method.Streaming || f.num != 0
+		}
+		return nil
+	})
+	return method, err
+}
+
+// decodeHTTPRule 解码 google.api.HttpRule
+func (r *run) decodeHTTPRule(data []byte) (*httpRule, error) {
+	methods := map[int]string{2: "GET", 3: "PUT", 4: "POST", 5: "DELETE", 6: "PATCH"}
+	rule := &httpRule{}
+	err := decodeWire(data, func(f wireField) error {
+		switch f.number {
+		case 2, 3, 4, 5, 6:
+			rule.Method, rule.Path = methods[f.number], string(f.bytes)
+		case 7:
+			rule.Body = string(f.bytes)
+		case 8:
+			return decodeWire(f.bytes, func(f wireField) error {
+				switch f.number {
+				case 1:
+					rule.Method = strings.ToUpper(string(f.bytes))
+				case 2:
+					rule.Path = string(f.bytes)
+				}
+				return nil
+			})
+		case 11:
+			r.logger.Debug("google.api.http additional_bindings are not generated")
+		case 12:
+			rule.ResponseBody = string(f.bytes)
+		}
+		return nil
+	})
+	return rule, err
+}
+
+func pathKey(path []int) string {
+	parts := make([]string, len(path))
+	for i, p := range path {
+		parts[i] = strconv.Itoa(p)
+	}
+	return strings.Join(parts, ".")
+}
+
+// cleanComment 去掉 SourceCodeInfo 注释每行开头的空格
+func cleanComment(comment string) string {
+	lines := strings.Split(strings.TrimSpace(comment), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.Join(lines, "\n")
+}
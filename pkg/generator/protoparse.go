@@ -0,0 +1,607 @@
+// protoparse.go
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// protoToken .proto 源文件的词法单元
+type protoToken struct {
+	text    string
+	kind    byte // i 标识符（可以带 .），s 字符串（已去掉引号和转义），n 数字，p 符号，0 文件结束
+	line    int
+	comment string // 紧挨在前面的注释，同一行末尾的注释和空行隔开的注释不算
+}
+
+// tokenizeProto 拆分 .proto 源文件
+func tokenizeProto(src string) ([]protoToken, error) {
+	var tokens []protoToken
+	var comments []string
+	line, lastLine := 1, 0
+	blank := 0 // 上一段注释之后的换行数，空行之后的注释不再属于下一个 token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			blank++
+			if blank > 1 {
+				comments = nil
+			}
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v':
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+			text := strings.TrimPrefix(src[i+2:i+end], " ")
+			if line != lastLine {
+				comments = append(comments, strings.TrimRight(text, " \t\r"))
+			}
+			blank = 0
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			text := src[i+2 : i+2+end]
+			if line != lastLine {
+				for _, l := range strings.Split(text, "\n") {
+					l = strings.TrimSpace(l)
+					l = strings.TrimSpace(strings.TrimPrefix(l, "*"))
+					comments = append(comments, l)
+				}
+			}
+			line += strings.Count(text, "\n")
+			blank = 0
+			i += end + 4
+		default:
+			token := protoToken{line: line, comment: strings.TrimSpace(strings.Join(comments, "\n"))}
+			start := i
+			switch {
+			case isProtoIdentStart(c) || (c == '.' && i+1 < len(src) && isProtoIdentStart(src[i+1])):
+				for i < len(src) && (isProtoIdentStart(src[i]) || src[i] == '.' || (src[i] >= '0' && src[i] <= '9')) {
+					i++
+				}
+				token.kind, token.text = 'i', src[start:i]
+			case c >= '0' && c <= '9':
+				for i < len(src) && (isProtoIdentStart(src[i]) || src[i] == '.' || (src[i] >= '0' && src[i] <= '9') ||
+					((src[i] == '-' || src[i] == '+') && (src[i-1] == 'e' || src[i-1] == 'E'))) {
+					i++
+				}
+				token.kind, token.text = 'n', src[start:i]
+			case c == '"' || c == '\'':
+				i++
+				for i < len(src) && src[i] != c {
+					if src[i] == '\\' {
+						i++
+					}
+					if i < len(src) && src[i] == '\n' {
+						return nil, fmt.Errorf("line %d: unterminated string", line)
+					}
+					i++
+				}
+				if i >= len(src) {
+					return nil, fmt.Errorf("line %d: unterminated string", line)
+				}
+				raw := src[start+1 : i]
+				i++
+				if c == '\'' {
+					raw = strings.ReplaceAll(strings.ReplaceAll(raw, `\'`, `'`), `"`, `\"`)
+				}
+				text, err := strconv.Unquote(`"` + raw + `"`)
+				if err != nil {
+					text = raw
+				}
+				token.kind, token.text = 's', text
+			default:
+				i++
+				token.kind, token.text = 'p', string(c)
+			}
+			tokens = append(tokens, token)
+			comments, blank, lastLine = nil, 0, line
+		}
+	}
+	return append(tokens, protoToken{line: line}), nil
+}
+
+func isProtoIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// protoParser 解析 .proto 源文件中的消息、枚举和服务，其他声明和选项被跳过
+type protoParser struct {
+	*run
+	tokens []protoToken
+	pos    int
+	file   *protoFile
+	err    error
+}
+
+// parseProtoFile 解析单个 .proto 源文件，类型名称尚未解析
+func (r *run) parseProtoFile(name string, data []byte) (*protoFile, error) {
+	tokens, err := tokenizeProto(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	p := &protoParser{run: r, tokens: tokens, file: &protoFile{Name: name}}
+	for p.err == nil && !p.done() {
+		switch token := p.next(); token.text {
+		case "syntax", "edition", "option":
+			p.skipStatement()
+		case "package":
+			p.file.Package = p.ident()
+			p.expect(";")
+		case "import":
+			if next := p.peek().text; next == "public" || next == "weak" {
+				p.next()
+			}
+			p.file.Imports = append(p.file.Imports, p.str())
+			p.expect(";")
+		case "message":
+			p.message(p.file.Package, token.comment)
+		case "enum":
+			p.enum(p.file.Package, token.comment)
+		case "service":
+			p.service(token.comment)
+		case "extend":
+			p.ident()
+			p.skipBlock()
+		case ";":
+		default:
+			p.fail("unexpected %q", token.text)
+		}
+	}
+	if p.err != nil {
+		return nil, fmt.Errorf("%s: %w", name, p.err)
+	}
+	return p.file, nil
+}
+
+func (p *protoParser) done() bool {
+	return p.tokens[p.pos].kind == 0
+}
+
+func (p *protoParser) peek() protoToken {
+	return p.tokens[p.pos]
+}
+
+func (p *protoParser) next() protoToken {
+	token := p.tokens[p.pos]
+	if token.kind == 0 {
+		p.fail("unexpected end of file")
+	} else {
+		p.pos++
+	}
+	return token
+}
+
+// fail 记录第一个错误，之后 done 返回 true 让解析结束
+func (p *protoParser) fail(format string, args ...interface{}) {
+	if p.err == nil {
+		p.err = fmt.Errorf("line %d: %s", p.tokens[p.pos].line, fmt.Sprintf(format, args...))
+		p.pos = len(p.tokens) - 1
+	}
+}
+
+// accept 下一个 token 为 text 时消费它
+func (p *protoParser) accept(text string) bool {
+	if token := p.peek(); token.kind != 's' && token.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *protoParser) expect(text string) {
+	if !p.accept(text) {
+		p.fail("expected %q, found %q", text, p.peek().text)
+	}
+}
+
+func (p *protoParser) ident() string {
+	token := p.next()
+	if token.kind != 'i' {
+		p.fail("expected identifier, found %q", token.text)
+	}
+	return token.text
+}
+
+// str 字符串常量，相邻的字符串拼接
+func (p *protoParser) str() string {
+	token := p.next()
+	if token.kind != 's' {
+		p.fail("expected string, found %q", token.text)
+	}
+	text := token.text
+	for p.peek().kind == 's' {
+		text += p.next().text
+	}
+	return text
+}
+
+// skipStatement 跳过到分号为止的语句，语句中可以包含 {} 消息常量
+func (p *protoParser) skipStatement() {
+	depth := 0
+	for !p.done() {
+		switch p.next().text {
+		case "{":
+			depth++
+		case "}":
+			depth--
+		case ";":
+			if depth <= 0 {
+				return
+			}
+		}
+	}
+}
+
+// skipBlock 跳过 { ... } 代码块
+func (p *protoParser) skipBlock() {
+	p.expect("{")
+	for depth := 1; depth > 0 && !p.done(); {
+		switch p.next().text {
+		case "{":
+			depth++
+		case "}":
+			depth--
+		}
+	}
+}
+
+// message 解析消息及其嵌套的消息和枚举
+func (p *protoParser) message(scope, comment string) {
+	name := scopedName(scope, p.ident())
+	message := &protoMessage{Name: name, Package: p.file.Package, Comment: comment}
+	p.file.Messages = append(p.file.Messages, message)
+	p.expect("{")
+	for p.err == nil && !p.accept("}") {
+		switch token := p.peek(); token.text {
+		case "message":
+			p.next()
+			p.message(name, token.comment)
+		case "enum":
+			p.next()
+			p.enum(name, token.comment)
+		case "option", "reserved", "extensions":
+			p.skipStatement()
+		case "extend":
+			p.next()
+			p.ident()
+			p.skipBlock()
+		case "oneof":
+			p.next()
+			p.ident()
+			p.expect("{")
+			for p.err == nil && !p.accept("}") {
+				if p.peek().text == "option" {
+					p.skipStatement()
+				} else {
+					p.field(message, name)
+				}
+			}
+		case ";":
+			p.next()
+		default:
+			p.field(message, name)
+		}
+	}
+}
+
+// field 解析字段，包括 map<K, V> 字段
+func (p *protoParser) field(message *protoMessage, scope string) {
+	field := protoField{Comment: p.peek().comment, scope: scope}
+	switch p.peek().text {
+	case "repeated":
+		field.Repeated = true
+		p.next()
+	case "required":
+		field.Required = true
+		p.next()
+	case "optional":
+		p.next()
+	}
+	if p.peek().text == "map" && p.tokens[p.pos+1].text == "<" {
+		p.pos += 2
+		field.MapKey = p.ident()
+		p.expect(",")
+		field.Type = p.ident()
+		p.expect(">")
+	} else {
+		field.Type = p.ident()
+		if field.Type == "group" {
+			p.fail("proto2 groups are not supported")
+			return
+		}
+	}
+	field.Name = p.ident()
+	p.expect("=")
+	p.next()
+	if p.accept("[") {
+		for p.err == nil {
+			name := p.optionName()
+			p.expect("=")
+			value := p.optionValue()
+			switch name {
+			case "json_name":
+				field.JSONName = value
+			case "(google.api.field_behavior)":
+				if value == "REQUIRED" {
+					field.Required = true
+				}
+			}
+			if !p.accept(",") {
+				break
+			}
+		}
+		p.expect("]")
+	}
+	p.expect(";")
+	message.Fields = append(message.Fields, field)
+}
+
+// enum 解析枚举，取值保持定义顺序
+func (p *protoParser) enum(scope, comment string) {
+	enum := &protoEnum{Name: scopedName(scope, p.ident()), Package: p.file.Package, Comment: comment}
+	p.file.Enums = append(p.file.Enums, enum)
+	p.expect("{")
+	for p.err == nil && !p.accept("}") {
+		switch p.peek().text {
+		case "option", "reserved":
+			p.skipStatement()
+		case ";":
+			p.next()
+		default:
+			enum.Values = append(enum.Values, p.ident())
+			p.skipStatement()
+		}
+	}
+}
+
+// service 解析服务中的 rpc 及其 (google.api.http) 选项
+func (p *protoParser) service(comment string) {
+	service := &protoService{Name: scopedName(p.file.Package, p.ident()), Comment: comment}
+	p.file.Services = append(p.file.Services, service)
+	p.expect("{")
+	for p.err == nil && !p.accept("}") {
+		switch token := p.next(); token.text {
+		case "option":
+			p.skipStatement()
+		case "rpc":
+			service.Methods = append(service.Methods, p.rpc(token.comment))
+		case ";":
+		default:
+			p.fail("unexpected %q in service", token.text)
+		}
+	}
+}
+
+func (p *protoParser) rpc(comment string) protoMethod {
+	method := protoMethod{Name: p.ident(), Comment: comment, scope: p.file.Package}
+	for _, typ := range []*string{&method.Input, &method.Output} {
+		if typ == &method.Output {
+			p.expect("returns")
+		}
+		p.expect("(")
+		if p.peek().text == "stream" && p.tokens[p.pos+1].text != ")" {
+			p.next()
+			method.Streaming = true
+		}
+		*typ = p.ident()
+		p.expect(")")
+	}
+	if p.accept(";") || !p.accept("{") {
+		return method
+	}
+	for p.err == nil && !p.accept("}") {
+		if p.accept(";") {
+			continue
+		}
+		p.expect("option")
+		name := p.optionName()
+		if name != "(google.api.http)" && !strings.HasPrefix(name, "(google.api.http).") {
+			p.skipStatement()
+			continue
+		}
+		p.expect("=")
+		rule := make(map[string]interface{})
+		if field := strings.TrimPrefix(name, "(google.api.http)."); field != name {
+			rule[field] = p.optionValue()
+		} else if p.peek().text == "{" {
+			rule = p.literal()
+		} else {
+			p.fail("expected message literal for google.api.http")
+		}
+		p.expect(";")
+		method.HTTP = p.newHTTPRule(rule)
+	}
+	return method
+}
+
+// optionName 选项名称，例如 json_name、(google.api.http)、(foo.bar).baz
+func (p *protoParser) optionName() string {
+	var name string
+	if p.accept("(") {
+		name = "(" + p.ident() + ")"
+		p.expect(")")
+	} else {
+		name = p.ident()
+	}
+	for p.peek().kind == 'i' && strings.HasPrefix(p.peek().text, ".") {
+		name += p.next().text
+	}
+	return name
+}
+
+// optionValue 选项取值，消息常量和列表被跳过并返回空字符串
+func (p *protoParser) optionValue() string {
+	switch token := p.peek(); {
+	case token.text == "{":
+		p.literal()
+		return ""
+	case token.kind == 's':
+		return p.str()
+	case token.text == "-":
+		p.next()
+		return "-" + p.next().text
+	default:
+		return p.next().text
+	}
+}
+
+// literal 解析 protobuf 文本格式的消息常量，字段值为字符串、嵌套的 map 或列表
+func (p *protoParser) literal() map[string]interface{} {
+	end := "}"
+	if p.accept("<") {
+		end = ">"
+	} else {
+		p.expect("{")
+	}
+	fields := make(map[string]interface{})
+	for p.err == nil && !p.accept(end) {
+		var name string
+		if p.accept("[") {
+			name = "[" + p.ident() + "]"
+			p.expect("]")
+		} else {
+			name = p.ident()
+		}
+		p.accept(":")
+		var value interface{}
+		switch p.peek().text {
+		case "{", "<":
+			value = p.literal()
+		case "[":
+			p.next()
+			var list []interface{}
+			for p.err == nil && !p.accept("]") {
+				if text := p.peek().text; text == "{" || text == "<" {
+					list = append(list, p.literal())
+				} else {
+					list = append(list, p.optionValue())
+				}
+				p.accept(",")
+			}
+			value = list
+		default:
+			value = p.optionValue()
+		}
+		// 重复的字段（例如多个 additional_bindings）只保留第一个
+		if _, exists := fields[name]; !exists {
+			fields[name] = value
+		}
+		if !p.accept(",") {
+			p.accept(";")
+		}
+	}
+	return fields
+}
+
+// newHTTPRule 从 google.api.HttpRule 消息常量中取出方法、路径和 body
+func (r *run) newHTTPRule(fields map[string]interface{}) *httpRule {
+	rule := &httpRule{}
+	for _, method := range []string{"get", "put", "post", "delete", "patch"} {
+		if value, ok := fields[method].(string); ok {
+			rule.Method, rule.Path = strings.ToUpper(method), value
+		}
+	}
+	if custom, ok := fields["custom"].(map[string]interface{}); ok {
+		kind, _ := custom["kind"].(string)
+		rule.Method = strings.ToUpper(kind)
+		rule.Path, _ = custom["path"].(string)
+	}
+	rule.Body, _ = fields["body"].(string)
+	rule.ResponseBody, _ = fields["response_body"].(string)
+	if _, ok := fields["additional_bindings"]; ok {
+		r.logger.Debug("google.api.http additional_bindings are not generated", "path", rule.Path)
+	}
+	return rule
+}
+
+func scopedName(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// protoSkippedImports 找不到时忽略的 import：注解和常用类型已内置
+var protoSkippedImports = []string{"google/api/", "google/protobuf/"}
+
+// loadProtoFiles 解析 .proto 源文件及其 import 的文件，import 在 importPaths 中查找
+func (r *run) loadProtoFiles(name string, data []byte, importPaths []string) ([]*protoFile, error) {
+	var files []*protoFile
+	loaded := make(map[string]bool)
+	var load func(name string, data []byte) error
+	load = func(name string, data []byte) error {
+		loaded[name] = true
+		data, err := normalizeText(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		file, err := r.parseProtoFile(name, data)
+		if err != nil {
+			return err
+		}
+		files = append(files, file)
+		for _, imported := range file.Imports {
+			if loaded[imported] {
+				continue
+			}
+			data, err := readProtoImport(imported, importPaths)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			if data == nil {
+				loaded[imported] = true
+				continue
+			}
+			if err := load(imported, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := load(name, data); err != nil {
+		return nil, err
+	}
+	// import 的文件只提供类型定义
+	for _, file := range files[1:] {
+		file.Import = true
+	}
+	if err := resolveProtoTypes(files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// readProtoImport 在 importPaths 中查找 import 的文件，google/api 和 google/protobuf 找不到时返回 nil
+func readProtoImport(name string, importPaths []string) ([]byte, error) {
+	if clean := path.Clean(name); path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return nil, fmt.Errorf("import %q must be relative to a proto path", name)
+	}
+	for _, dir := range importPaths {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err == nil {
+			return data, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	for _, prefix := range protoSkippedImports {
+		if strings.HasPrefix(name, prefix) {
+			return nil, nil
+		}
+	}
+	return nil, fmt.Errorf("import %q not found in proto paths %q", name, importPaths)
+}
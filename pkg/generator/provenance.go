@@ -0,0 +1,88 @@
+// provenance.go
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// sourceLines 文档中操作和 schema 所在的行号
+type sourceLines struct {
+	operations map[string]int        // operationKey -> 方法所在行
+	schemas    map[string]schemaLine // schema 名称（包括按命名规则改名后的名称）-> 文档中的名称和所在行
+}
+
+// schemaLine schema 在文档中的名称和行号
+type schemaLine struct {
+	name string
+	line int
+}
+
+// collectSourceLines 从已解析的文档中读取每个操作和 schema 的行号，只遍历一次 paths 和 components/schemas
+func (r *run) collectSourceLines(data []byte) (*sourceLines, error) {
+	lines := &sourceLines{operations: make(map[string]int), schemas: make(map[string]schemaLine)}
+	doc, err := r.parseDocument(data)
+	if err != nil || len(doc.Content) == 0 {
+		return lines, err
+	}
+	root := doc.Content[0]
+	paths := r.field(root, "paths")
+	if paths != nil && paths.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(paths.Content); i += 2 {
+			item := resolveAlias(paths.Content[i+1])
+			if item == nil || item.Kind != yaml.MappingNode {
+				continue
+			}
+			for j := 0; j+1 < len(item.Content); j += 2 {
+				method := item.Content[j]
+				lines.operations[operationKey(strings.ToUpper(method.Value), paths.Content[i].Value)] = method.Line
+			}
+		}
+	}
+	schemas := r.field(r.field(root, "components"), "schemas")
+	if schemas != nil && schemas.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(schemas.Content); i += 2 {
+			key := schemas.Content[i]
+			location := schemaLine{name: key.Value, line: key.Line}
+			// renameSchemas 按命名规则改名后的名称同样可以找到，改名冲突时生成已经失败
+			// 是否是枚举在这里不确定，两种名称都记录
+			for _, renamed := range []string{r.naming.Namespace(key.Value), r.naming.Type(r.naming.Namespace(key.Value))} {
+				if renamed != key.Value {
+					lines.schemas[renamed] = location
+				}
+			}
+			lines.schemas[key.Value] = location
+		}
+	}
+	return lines, nil
+}
+
+// operationSource 操作在文档中的位置，例如 GET /users/{id} (openapi.yaml:42)；未开启 -provenance 时为空
+func (r *run) operationSource(method, path string) string {
+	if !r.provenance {
+		return ""
+	}
+	return r.withSourceLine(method+" "+path, r.specLines.operations[operationKey(method, path)])
+}
+
+// schemaSource schema 在文档中的位置，例如 #/components/schemas/User (openapi.yaml:80)；未开启 -provenance 时为空
+func (r *run) schemaSource(name string) string {
+	if !r.provenance {
+		return ""
+	}
+	location, ok := r.specLines.schemas[name]
+	if !ok {
+		location.name = name
+	}
+	return r.withSourceLine("#/components/schemas/"+escapePointer(location.name), location.line)
+}
+
+// withSourceLine 在位置之后加上文档名和行号；转换而来的文档只有位置
+func (r *run) withSourceLine(location string, line int) string {
+	if r.specConverted || line == 0 {
+		return location
+	}
+	return fmt.Sprintf("%s (%s:%d)", location, bannerSource(r.bannerSpec), line)
+}
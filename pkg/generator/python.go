@@ -0,0 +1,444 @@
+// python.go
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// pyKeywords Python 关键字，字段和方法遇到时加下划线后缀
+var pyKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true, "async": true,
+	"await": true, "break": true, "class": true, "continue": true, "def": true, "del": true, "elif": true,
+	"else": true, "except": true, "finally": true, "for": true, "from": true, "global": true, "if": true,
+	"import": true, "in": true, "is": true, "lambda": true, "nonlocal": true, "not": true, "or": true,
+	"pass": true, "raise": true, "return": true, "try": true, "while": true, "with": true, "yield": true,
+}
+
+// pyModelAttrs pydantic BaseModel 已有的属性和字段注解中用到的类型名称，字段遇到时加下划线后缀
+var pyModelAttrs = map[string]bool{
+	"bool": true, "bytes": true, "datetime": true, "float": true, "int": true, "str": true, "construct": true, "copy": true, "dict": true, "fields": true, "from_orm": true, "json": true,
+	"parse_file": true, "parse_obj": true, "parse_raw": true, "schema": true, "schema_json": true,
+	"update_forward_refs": true, "validate": true,
+}
+
+// pyReserved 包中占用的名称，模型和枚举遇到时加上 Model 后缀
+var pyReserved = map[string]bool{
+	"Client": true, "APIError": true, "BaseModel": true, "ConfigDict": true, "Field": true, "Enum": true,
+	"Any": true, "Dict": true, "List": true, "Literal": true, "Optional": true, "datetime": true,
+}
+
+// pyIdent 转换为 snake_case 的 Python 标识符，例如 userId -> user_id，2fa -> n_2fa
+func pyIdent(s string) string {
+	name := snakeCase(s)
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "n_" + name
+	}
+	if pyKeywords[name] || strings.HasPrefix(name, "model_") || pyModelAttrs[name] {
+		name += "_"
+	}
+	return name
+}
+
+// pyConst 枚举成员名称，例如 in-progress -> IN_PROGRESS
+func pyConst(s string) string {
+	name := strings.ToUpper(snakeCase(s))
+	switch {
+	case name == "":
+		return "EMPTY"
+	case name[0] >= '0' && name[0] <= '9':
+		return "V_" + name
+	}
+	return name
+}
+
+// pyModel pydantic 模型类；Type 非空时生成类型别名 Name = Type
+type pyModel struct {
+	Name   string
+	Doc    string
+	Bases  []string
+	Type   string
+	Fields []pyField
+}
+
+type pyField struct {
+	Name     string
+	Type     string
+	Alias    string // JSON 中的字段名称
+	Required bool
+	Doc      []string // 字段上方的注释
+}
+
+// pyEnum 字符串枚举类
+type pyEnum struct {
+	Name    string
+	Doc     string
+	Members []pyConstant
+}
+
+type pyConstant struct {
+	Name  string
+	Value string
+}
+
+// pyService 一个模块的接口类，作为 Client 的属性，例如 client.user.get(params)
+type pyService struct {
+	Module  string
+	File    string // 模块文件名称，不含 .py
+	Attr    string // Client 中的属性名称
+	Class   string
+	Imports []string // 引用的模型和枚举
+	Methods []pyMethod
+}
+
+// pyMethod 服务类上的接口方法
+type pyMethod struct {
+	Name       string
+	Doc        []string
+	Method     string
+	Path       string
+	Args       []pyArg // 请求体之外的路径参数，位于 params 之前
+	PathParams string  // 传给 request 的 path_params，例如 {"id": id}
+	Params     string  // 参数类型，为空表示没有参数
+	Body       bool    // params 是请求体，GET/DELETE 之外原样发送
+	Result     string  // 返回值类型，为空表示没有响应体
+}
+
+// pyArg 方法参数
+type pyArg struct {
+	Name string
+	Type string
+}
+
+// pyRenderer 记录 IR 中模型和枚举的 Python 类型名称
+type pyRenderer struct {
+	api     *ir.API
+	types   map[string]string // 原始名称 -> Python 类型名称
+	classes map[string]bool   // 生成为 pydantic 类的模型
+}
+
+// generatePython 根据中间表示生成 Python 包：pydantic 模型（models.py）、基于 httpx 的运行时（client.py）
+// 和每个模块一个 <module>_service.py，__init__.py 导出 Client 和所有模型
+func (r *run) generatePython(data []byte) (*ir.API, error) {
+	spec, err := r.parseSpec(data)
+	if err != nil {
+		return nil, err
+	}
+	api := r.buildIR(spec, newSchemaResolver(spec.Components.Schemas, r.logger))
+	renderer := &pyRenderer{api: api, types: make(map[string]string), classes: make(map[string]bool)}
+
+	names := make(uniqueNames)
+	for name := range pyReserved {
+		names[name] = true
+	}
+	modules := make(map[string][]ir.Operation)
+	for _, op := range api.Operations {
+		modules[op.Module] = append(modules[op.Module], op)
+	}
+	attrs := uniqueNames{"close": true, "request": true}
+	var services []pyService
+	for _, module := range sortedKeys(modules) {
+		attr := attrs.unique(pyIdent(module))
+		services = append(services, pyService{Module: module, File: attr + "_service", Attr: attr, Class: names.unique(goName(module) + "Service")})
+	}
+	typeName := func(name string) string {
+		if pyReserved[name] {
+			name += "Model"
+		}
+		return names.unique(name)
+	}
+	for _, enum := range api.Enums {
+		renderer.types[enum.Name] = typeName(goName(enum.TypeName))
+	}
+	for _, model := range api.Models {
+		renderer.types[model.Name] = typeName(goName(model.TypeName))
+	}
+	// 有字段的模型以及继承了这类模型的模型生成类，其余生成类型别名，Python 类不能继承别名
+	for changed := true; changed; {
+		changed = false
+		for _, model := range api.Models {
+			if renderer.classes[model.Name] || model.Alias != nil {
+				continue
+			}
+			isClass := len(model.Fields) > 0
+			for _, base := range model.Extends {
+				isClass = isClass || renderer.classes[base]
+			}
+			if isClass {
+				renderer.classes[model.Name], changed = true, true
+			}
+		}
+	}
+
+	var enums []pyEnum
+	for _, enum := range api.Enums {
+		enums = append(enums, renderer.enum(enum))
+	}
+	classes, aliases := renderer.models()
+	var exports []string
+	for _, name := range renderer.types {
+		exports = append(exports, name)
+	}
+	sort.Strings(exports)
+
+	tracker := r.startProgress(StageOperations, len(api.Operations))
+	for i := range services {
+		methodNames := uniqueNames{"client": true}
+		refs := make(map[string]bool)
+		for _, op := range modules[services[i].Module] {
+			services[i].Methods = append(services[i].Methods, renderer.method(op, methodNames.unique(pyIdent(op.Name))))
+			tracker.step()
+			var types []ir.Type
+			if op.Request != nil {
+				types = append(types, *op.Request)
+			}
+			for _, field := range op.PathParams {
+				types = append(types, field.Type)
+			}
+			if op.Response != nil {
+				types = append(types, *op.Response)
+			}
+			for _, t := range types {
+				for _, ref := range t.Refs() {
+					if name, ok := renderer.types[ref]; ok {
+						refs[name] = true
+					}
+				}
+			}
+		}
+		services[i].Imports = sortedKeys(refs)
+		sort.Slice(services[i].Methods, func(a, b int) bool { return services[i].Methods[a].Name < services[i].Methods[b].Name })
+	}
+
+	files := map[string]struct {
+		template string
+		data     interface{}
+	}{
+		"__init__.py": {"templates/py-init.tmpl", map[string]interface{}{"Exports": exports}},
+		"client.py":   {"templates/py-client.tmpl", map[string]interface{}{"Services": services}},
+		"models.py":   {"templates/py-models.tmpl", map[string]interface{}{"Exports": exports, "Enums": enums, "Classes": classes, "Aliases": aliases}},
+	}
+	for _, filename := range sortedKeys(files) {
+		tmpl, err := r.parseTemplate(files[filename].template)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s template: %w", strings.TrimSuffix(strings.TrimPrefix(files[filename].template, "templates/"), ".tmpl"), err)
+		}
+		if err := r.renderSourceFile(tmpl, filename, files[filename].data); err != nil {
+			return nil, err
+		}
+	}
+	serviceTmpl, err := r.parseTemplate("templates/py-service.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parse py-service template: %w", err)
+	}
+	for _, service := range services {
+		if err := r.renderSourceFile(serviceTmpl, service.File+".py", service); err != nil {
+			return nil, err
+		}
+	}
+	return api, nil
+}
+
+// renderSourceFile 渲染不需要格式化的源文件，开头的空行把头部注释与代码隔开
+func (r *run) renderSourceFile(tmpl *template.Template, filename string, data interface{}) error {
+	var buf bytes.Buffer
+	buf.WriteString("\n")
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("render %s: %w", filename, err)
+	}
+	r.logger.Debug("generate source file", "file", filename)
+	r.writeFile(filename, buf.Bytes())
+	return nil
+}
+
+// enum 枚举成员名称为大写的取值，例如 IN_PROGRESS
+func (r *pyRenderer) enum(enum ir.Enum) pyEnum {
+	result := pyEnum{Name: r.types[enum.Name], Doc: pyDocstring(enum.Description)}
+	names := make(uniqueNames)
+	for _, member := range enum.Members {
+		result.Members = append(result.Members, pyConstant{Name: names.unique(pyConst(member.Value)), Value: strconv.Quote(member.Value)})
+	}
+	return result
+}
+
+// models 返回模型类和类型别名；类按继承关系排序，基类在前，别名按引用关系排序，
+// 被引用的别名在前，别名中的类型在模块加载时求值
+func (r *pyRenderer) models() (classes, aliases []pyModel) {
+	byName := make(map[string]ir.Model)
+	for _, model := range r.api.Models {
+		byName[model.Name] = model
+	}
+	visited := make(map[string]bool)
+	var visit func(model ir.Model)
+	visit = func(model ir.Model) {
+		if visited[model.Name] {
+			return
+		}
+		visited[model.Name] = true
+		var deps []string
+		if model.Alias != nil {
+			deps = model.Alias.Refs()
+		} else {
+			deps = model.Extends
+		}
+		for _, dep := range deps {
+			if base, ok := byName[dep]; ok && r.classes[dep] == r.classes[model.Name] {
+				visit(base)
+			}
+		}
+		if result := r.model(model); result.Type != "" {
+			aliases = append(aliases, result)
+		} else {
+			classes = append(classes, result)
+		}
+	}
+	for _, model := range r.api.Models {
+		visit(model)
+	}
+	return classes, aliases
+}
+
+// model 有字段或继承类的模型生成 pydantic 类，字段名称转换为 snake_case 并用 alias 对应 JSON 名称；
+// 非 object 的模型生成类型别名，没有字段的对象生成 Dict[str, Any]
+func (r *pyRenderer) model(model ir.Model) pyModel {
+	result := pyModel{Name: r.types[model.Name], Doc: pyDocstring(model.Description)}
+	switch {
+	case model.Alias != nil:
+		result.Type = r.typeName(*model.Alias)
+		return result
+	case !r.classes[model.Name]:
+		result.Type = "Dict[str, Any]"
+		return result
+	}
+	for _, base := range model.Extends {
+		if r.classes[base] {
+			result.Bases = append(result.Bases, r.types[base])
+		}
+	}
+	if len(result.Bases) == 0 {
+		result.Bases = []string{"BaseModel"}
+	}
+
+	names := make(uniqueNames)
+	for _, field := range model.Fields {
+		result.Fields = append(result.Fields, pyField{
+			Name:     names.unique(pyIdent(field.Name)),
+			Type:     r.typeName(field.Type),
+			Alias:    strconv.Quote(field.Name),
+			Required: field.Required,
+			Doc:      docLines(field.Description),
+		})
+	}
+	return result
+}
+
+// method 生成接口方法
+func (r *pyRenderer) method(op ir.Operation, name string) pyMethod {
+	summary := op.Summary
+	if summary == "" {
+		summary = "调用 " + op.Method + " " + op.Path
+	}
+	method := pyMethod{
+		Name:   name,
+		Doc:    append(strings.Split(pyDocstring(summary), "\n"), "", op.Method+" "+op.Path),
+		Method: strconv.Quote(op.Method),
+		Path:   strconv.Quote(op.Path),
+		Body:   op.Body,
+	}
+	if op.Request != nil {
+		method.Params = r.typeName(*op.Request)
+	}
+	if op.Response != nil {
+		method.Result = r.typeName(*op.Response)
+	}
+	// 请求体之外的路径参数作为方法参数，合成的请求类型中的路径参数是必填字段
+	names := uniqueNames{"self": true, "params": true}
+	var values []string
+	for _, field := range op.PathParams {
+		arg := pyArg{Name: names.unique(pyIdent(field.Name)), Type: r.typeName(field.Type)}
+		method.Args = append(method.Args, arg)
+		values = append(values, strconv.Quote(field.Name)+": "+arg.Name)
+	}
+	if len(values) > 0 {
+		method.PathParams = "{" + strings.Join(values, ", ") + "}"
+	}
+	return method
+}
+
+// typeName 类型表达式对应的 Python 类型注解
+func (r *pyRenderer) typeName(t ir.Type) string {
+	switch t.Kind {
+	case ir.String:
+		switch t.Format {
+		case "date-time":
+			return "datetime"
+		case "byte", "binary":
+			return "bytes"
+		}
+		return "str"
+	case ir.Integer:
+		return "int"
+	case ir.Number:
+		return "float"
+	case ir.Boolean:
+		return "bool"
+	case ir.Object:
+		return "Dict[str, Any]"
+	case ir.Ref:
+		if name, ok := r.types[t.Ref]; ok {
+			return name
+		}
+		return "Any"
+	case ir.EnumRef:
+		if name, ok := r.types[t.Ref]; ok {
+			return name
+		}
+		if len(t.Values) == 0 {
+			return "str"
+		}
+		values := make([]string, len(t.Values))
+		for i, value := range t.Values {
+			values[i] = strconv.Quote(value)
+		}
+		return "Literal[" + strings.Join(values, ", ") + "]"
+	case ir.Array:
+		if t.Items == nil {
+			return "List[Any]"
+		}
+		return "List[" + r.typeName(*t.Items) + "]"
+	case ir.Map:
+		if t.Items == nil {
+			return "Dict[str, Any]"
+		}
+		return "Dict[str, " + r.typeName(*t.Items) + "]"
+	case ir.Tuple:
+		return "List[Any]"
+	}
+	return "Any"
+}
+
+// docLines 描述按行拆分，去掉首尾空白，用于注释和文档字符串
+func docLines(description string) []string {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return nil
+	}
+	lines := strings.Split(description, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return lines
+}
+
+// pyDocstring 文档字符串的内容，转义反斜杠和三引号
+func pyDocstring(description string) string {
+	description = strings.Join(docLines(description), "\n")
+	description = strings.ReplaceAll(description, `\`, `\\`)
+	return strings.ReplaceAll(description, `"""`, `\"\"\"`)
+}
@@ -0,0 +1,126 @@
+// refs.go
+package generator
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// brokenRef 文档中无法解析的 $ref
+type brokenRef struct {
+	Ref      string // $ref 的值
+	Location string // $ref 所在位置的 JSON Pointer，例如 #/paths/~1users/get/responses/200
+	Line     int    // $ref 所在行号，文档经过格式转换时为转换后文档中的行号
+	Reason   string
+}
+
+func (r brokenRef) String() string {
+	return fmt.Sprintf("line %d: %s at %s (%s)", r.Line, r.Ref, r.Location, r.Reason)
+}
+
+// unresolvedRefs 返回文档中所有无法解析的 $ref，按出现顺序；只支持文档内部的引用（#/...）
+func (r *run) unresolvedRefs(data []byte) ([]brokenRef, error) {
+	doc, err := r.parseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	root := doc.Content[0]
+
+	var broken []brokenRef
+	var walk func(node *yaml.Node, pointer string)
+	walk = func(node *yaml.Node, pointer string) {
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if key.Value == "$ref" && value.Kind == yaml.ScalarNode {
+					if _, reason := r.resolveRef(root, value.Value); reason != "" {
+						broken = append(broken, brokenRef{Ref: value.Value, Location: "#" + pointer, Line: value.Line, Reason: reason})
+					}
+					continue
+				}
+				walk(value, pointer+"/"+escapePointer(key.Value))
+			}
+		case yaml.SequenceNode:
+			for i, item := range node.Content {
+				walk(item, pointer+"/"+strconv.Itoa(i))
+			}
+		}
+	}
+	walk(root, "")
+	return broken, nil
+}
+
+// resolveRef 在文档中查找 ref 指向的节点，找不到时返回 nil 和原因
+func (r *run) resolveRef(root *yaml.Node, ref string) (*yaml.Node, string) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, "external references are not supported"
+	}
+	pointer, err := url.PathUnescape(strings.TrimPrefix(ref, "#"))
+	if err != nil || (pointer != "" && !strings.HasPrefix(pointer, "/")) {
+		return nil, "invalid JSON pointer"
+	}
+	node := resolveAlias(root)
+	if pointer == "" {
+		return node, ""
+	}
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = pointerUnescaper.Replace(token)
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			next = r.field(node, token)
+		case yaml.SequenceNode:
+			if i, err := strconv.Atoi(token); err == nil && i >= 0 && i < len(node.Content) {
+				next = resolveAlias(node.Content[i])
+			}
+		}
+		if next == nil {
+			return nil, "no " + token + " in the document"
+		}
+		node = next
+	}
+	return node, ""
+}
+
+// pointerEscaper、pointerUnescaper 转义和还原 JSON Pointer 中的一段；Replacer 的构建开销较大，只创建一次
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// escapePointer 转义 JSON Pointer 中的一段
+func escapePointer(token string) string {
+	return pointerEscaper.Replace(token)
+}
+
+// checkRefs 检查文档中的 $ref：unresolved-ref 为 error 级别（-strict 时 warn 同样视为 error）时列出全部无法解析的引用并返回错误，
+// 否则按级别逐个记录
+func (r *run) checkRefs(data []byte) error {
+	broken, err := r.unresolvedRefs(data)
+	if err != nil || len(broken) == 0 {
+		return err
+	}
+	level := r.diagnosticLevel("unresolved-ref")
+	if r.strict && level == levelWarn {
+		level = levelError
+	}
+	if level == levelError {
+		var lines []string
+		for _, ref := range broken {
+			lines = append(lines, ref.String())
+		}
+		return fmt.Errorf("%d unresolved $ref: %s", len(broken), strings.Join(lines, "; "))
+	}
+	for _, ref := range broken {
+		r.logDiagnostic(level, "unresolved-ref", "unresolved $ref", "ref", ref.Ref, "at", ref.Location, "line", ref.Line, "reason", ref.Reason)
+	}
+	return nil
+}
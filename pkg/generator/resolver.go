@@ -0,0 +1,198 @@
+// resolver.go
+package generator
+
+import (
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+// SchemaResolver 解析组件 schema 之间的引用关系（$ref 别名、allOf 继承），并检测循环引用
+type SchemaResolver struct {
+	logger  *slog.Logger
+	schemas map[string]Schema
+	// bases 打破循环后的 allOf 基类，key 为 schema 原始名称
+	bases map[string][]string
+	// cyclicAliases 形成纯别名循环（A = B, B = A）的 schema
+	cyclicAliases map[string]bool
+	// refs $ref 值的解析结果，同一个引用在文档中出现多少次都只解析一次
+	refs map[string]refTarget
+}
+
+// refTarget $ref 指向的组件 schema
+type refTarget struct {
+	Name     string // schema 原始名称，例如 user.v1.User
+	TypeName string // 去除命名空间前缀后的接口名称，例如 User
+	Enum     bool   // schema 是枚举
+}
+
+// NewSchemaResolver 构建 schema 依赖关系并打破其中的循环，打破循环的警告写入 slog.Default()
+func NewSchemaResolver(schemas map[string]Schema) *SchemaResolver {
+	return newSchemaResolver(schemas, slog.Default())
+}
+
+// newSchemaResolver 与 NewSchemaResolver 相同，警告写入 logger
+func newSchemaResolver(schemas map[string]Schema, logger *slog.Logger) *SchemaResolver {
+	r := &SchemaResolver{
+		logger:        logger,
+		schemas:       schemas,
+		bases:         make(map[string][]string),
+		cyclicAliases: make(map[string]bool),
+		refs:          make(map[string]refTarget),
+	}
+
+	var names []string
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	r.resolveBases(names)
+	r.resolveAliases(names)
+	return r
+}
+
+// resolveBases 深度优先遍历 allOf 继承图，丢弃构成环的继承边
+func (r *SchemaResolver) resolveBases(names []string) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		for _, base := range r.schemas[name].AllOf {
+			if base.RefValue == "" {
+				continue
+			}
+			baseName := cleanRef(base.RefValue)
+			if _, exists := r.schemas[baseName]; !exists {
+				continue
+			}
+			switch state[baseName] {
+			case visiting:
+				r.logger.Warn("circular allOf reference, inheritance dropped", "schema", name, "base", baseName)
+				continue
+			case unvisited:
+				visit(baseName)
+			}
+			r.bases[name] = append(r.bases[name], baseName)
+		}
+		state[name] = done
+	}
+
+	for _, name := range names {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+}
+
+// resolveAliases 沿 $ref 别名链查找，标记回到自身的别名循环
+func (r *SchemaResolver) resolveAliases(names []string) {
+	for _, name := range names {
+		seen := map[string]bool{name: true}
+		current := r.schemas[name]
+		for current.Ref != "" {
+			target := cleanRef(current.Ref)
+			if seen[target] {
+				if target == name {
+					r.logger.Warn("circular $ref alias, generated as unknown", "schema", name)
+					r.cyclicAliases[name] = true
+				}
+				break
+			}
+			seen[target] = true
+			next, exists := r.schemas[target]
+			if !exists {
+				break
+			}
+			current = next
+		}
+	}
+}
+
+// target 解析 $ref 指向的 schema，结果按引用缓存；只在构建中间表示时使用，不能并发调用
+func (r *SchemaResolver) target(ref string) refTarget {
+	if target, ok := r.refs[ref]; ok {
+		return target
+	}
+	name := cleanRef(ref)
+	target := refTarget{Name: name, TypeName: interfaceName(name), Enum: len(r.schemas[name].Enum) > 0}
+	r.refs[ref] = target
+	return target
+}
+
+// Bases 返回 schema 的 allOf 基类（已打破循环）
+func (r *SchemaResolver) Bases(name string) []string {
+	return r.bases[name]
+}
+
+// IsCyclicAlias 判断 schema 是否为循环别名
+func (r *SchemaResolver) IsCyclicAlias(name string) bool {
+	return r.cyclicAliases[name]
+}
+
+// Closure 返回 roots 及其直接或间接引用的所有 schema
+func (r *SchemaResolver) Closure(roots []string) map[string]bool {
+	result := make(map[string]bool)
+	var visit func(name string)
+	visit = func(name string) {
+		if result[name] {
+			return
+		}
+		if _, exists := r.schemas[name]; !exists {
+			return
+		}
+		result[name] = true
+		for _, dep := range schemaDependencies(r.schemas[name]) {
+			visit(dep)
+		}
+	}
+	for _, root := range roots {
+		visit(root)
+	}
+	return result
+}
+
+// schemaDependencies 返回 schema 直接引用的其他 schema 名称
+func schemaDependencies(schema Schema) []string {
+	var deps []string
+	addRef := func(ref string) {
+		if ref != "" {
+			deps = append(deps, cleanRef(ref))
+		}
+	}
+	addRefs := func(refs []Ref) {
+		for _, ref := range refs {
+			addRef(ref.RefValue)
+		}
+	}
+	addItems := func(items *Items) {
+		if items != nil {
+			addRef(items.RefValue)
+			addRefs(items.Tuple)
+		}
+	}
+
+	addRef(schema.Ref)
+	addRefs(schema.AllOf)
+	addRefs(schema.PrefixItems)
+	addItems(schema.Items)
+	for _, prop := range schema.Properties {
+		addRef(prop.Ref)
+		addRefs(prop.AllOf)
+		addRefs(prop.PrefixItems)
+		addItems(prop.Items)
+	}
+	return deps
+}
+
+// interfaceName 去除命名空间前缀后的接口名称；渲染时每个属性都会调用，不分配内存
+func interfaceName(schemaName string) string {
+	typeName := cleanRef(schemaName)
+	return typeName[strings.LastIndexByte(typeName, '.')+1:]
+}
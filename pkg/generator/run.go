@@ -0,0 +1,153 @@
+// run.go
+package generator
+
+import (
+	"log/slog"
+	"runtime"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// run 一次 Generate（或 IR、OpenAPI 等）调用的全部状态：选项、日志、诊断计数、已解析的文档和输出的文件。
+// Generator 每次调用时创建新的 run 并沿调用链传递，多次生成可以同时进行，彼此不共享状态
+type run struct {
+	opts Options
+
+	// logger 生成过程的日志，写入 Options.Logger，同时统计警告和错误
+	logger *slog.Logger
+	// problems 渲染中的错误只记录日志并继续生成其余文件，生成结束时据此返回错误，见 checkProblems
+	problems *problemCounts
+	// progress 进度回调，为空时不报告进度
+	progress func(Progress)
+	// output 输出的文件
+	output Files
+	// recorded 不为 nil 时 writeFile 同时记录写入的文件，用于保存模块生成的文件
+	recorded Files
+	// renderCache Options.Cache，不使用时为 nil
+	renderCache *Cache
+	// renderWorkers 并行渲染使用的 goroutine 数量，默认与 GOMAXPROCS 相同
+	renderWorkers int
+
+	// specDocument 已解析的文档：校验、$ref 检查、解码、JSON Schema 和不支持特性的检查都基于 YAML 节点树，大型文档只解析一次
+	specDocument struct {
+		data  []byte
+		doc   *yaml.Node
+		index map[*yaml.Node]map[string]*yaml.Node // 大型映射节点的键索引，见 mappingIndex
+	}
+	// specConverted 文档由 proto 或 Postman 集合转换而来或合并了其他文件，行号没有意义，由 openAPI 设置
+	specConverted bool
+	// specLines 操作和 schema 在文档中的行号，-provenance 时由 generate 在渲染之前设置，渲染时只读
+	specLines *sourceLines
+	// namespacedSchemas 按 -namespaces 改名的带命名空间的 schema，新名称 -> 文档中的名称，由 renameSchemas 设置
+	namespacedSchemas map[string]string
+	// schemaNames 重命名后的 schema 名称 -> 文档中的名称，包括没有改名的 schema，由 renameSchemas 设置
+	schemaNames map[string]string
+	// parsedSpec parseSpec 返回的文档，reportUnsupported 据此只检查生成的部分，不再重新解码和过滤
+	parsedSpec *OpenAPI
+
+	lang                                         string
+	client, hooks, validators, forms, jsonSchema string
+	contract, unitTests, validate, pagination    string
+	classes, mocks                               bool
+	operationFilter                              *OperationFilter
+	// operationName -operation-name 从 operationId 推导函数名的方式，包含 {{ 时视为模板
+	operationName string
+	// operationNameTmpl -operation-name 为模板时解析后的模板
+	operationNameTmpl *template.Template
+	// groupBy -group-by 接口函数的分组方式
+	groupBy string
+	// groupTypes、groupEnums -group-types、-group-enums 只被一个模块使用的类型、枚举生成到该模块中
+	groupTypes, groupEnums bool
+	// groupTypesBy -group-types-by 类型和枚举的分组方式
+	groupTypesBy string
+	// naming 命名规则
+	naming NamingConvention
+	// layout -layout 生成文件的目录结构
+	layout string
+	// apiVersions -api-versions 路径中带版本号的接口如何区分版本
+	apiVersions string
+	// singleFile -single-file 指定的文件名，非空时所有代码合并到这一个文件
+	singleFile string
+	// outputExt -ext 生成文件的扩展名
+	outputExt string
+	// emitJS -emit-js 用 tsc 编译为 .js + .d.ts
+	emitJS bool
+	// tscPath -tsc TypeScript 编译器路径，为空时依次查找 node_modules/.bin/tsc 和 PATH
+	tscPath string
+	// importStyle -import-style 生成文件中相对导入的写法
+	importStyle string
+	// importAliases -import-alias 生成文件之间跨目录导入使用的路径别名，顶层目录 -> 别名，空字符串对应整个输出目录
+	importAliases map[string]string
+	// typeMappings -type-mapping 按 type 和 format 替换生成的 TypeScript 类型，key 为 string/uuid 或 string
+	typeMappings map[string]typeMapping
+	// goPackage -lang go 生成的包名
+	goPackage string
+	// packageName、packageVersion -package-name、-package-version 额外生成的 npm 包
+	packageName, packageVersion string
+	// workspace -workspace 每个模块（标签）生成一个 npm workspace 包，共用一个公共包
+	workspace bool
+	// templateDir -templates 指定的用户模板目录，与内置模板同名的文件覆盖内置模板，其余模板仍使用内置版本
+	templateDir string
+	// header 每个生成文件开头的版权或许可声明
+	header string
+	// bannerSpec 生成的文档来源，写入头部注释
+	bannerSpec string
+	// provenance -provenance 在生成的函数和接口的 JSDoc 中标注它们在文档中的位置
+	provenance bool
+	// strict -strict 时无法解析的 $ref 默认使生成失败，否则只记录警告
+	strict bool
+	// warningsAsErrors 生成过程中有警告时同样返回错误
+	warningsAsErrors bool
+	// diagnosticLevels -diagnostic 设置的规则级别；没有设置的规则使用 diagnosticRules 中的默认级别
+	diagnosticLevels map[string]string
+}
+
+// newRun 校验选项并创建一次生成的状态
+func (g *Generator) newRun() (*run, error) {
+	o := g.opts
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	r := &run{opts: o, problems: &problemCounts{}, output: make(Files)}
+	r.logger = slog.New(&countingHandler{Handler: o.Logger.Handler(), counts: r.problems})
+	r.bannerSpec = o.Source
+	r.client, r.hooks, r.classes = o.Client, o.Hooks, o.Classes
+	r.validators, r.forms, r.jsonSchema, r.mocks = o.Validators, o.Forms, o.JSONSchema, o.Mocks
+	r.contract, r.unitTests, r.validate, r.pagination = o.ContractTests, o.UnitTests, o.ValidateResponses, o.Pagination
+	r.operationName, r.groupBy, r.groupTypes, r.groupEnums, r.naming = o.OperationName, o.GroupBy, o.GroupTypes, o.GroupEnums, o.Naming
+	r.groupTypesBy = o.GroupTypesBy
+	r.layout, r.apiVersions = o.Layout, o.APIVersions
+	r.singleFile, r.outputExt, r.emitJS, r.tscPath, r.importStyle = o.SingleFile, o.Ext, o.EmitJS, o.TSC, o.ImportStyle
+	r.lang, r.goPackage = o.Lang, o.GoPackage
+	r.packageName, r.packageVersion, r.workspace = o.PackageName, o.PackageVersion, o.Workspace
+	r.templateDir, r.header, r.provenance = o.TemplateDir, o.Header, o.Provenance
+	r.strict, r.warningsAsErrors = o.Strict, o.WarningsAsErrors
+	r.progress = o.Progress
+	r.renderWorkers = o.Jobs
+	if r.renderWorkers == 0 {
+		r.renderWorkers = runtime.GOMAXPROCS(0)
+	}
+	// -provenance 标注的行号不在中间表示中，文档的任何修改都可能改变它们
+	if o.Cache != nil && !o.Provenance {
+		r.renderCache = o.Cache
+	}
+
+	var err error
+	if r.operationNameTmpl, err = parseOperationName(r.operationName, r.naming); err != nil {
+		return nil, err
+	}
+	if r.typeMappings, err = parseTypeMappings(o.TypeMappings); err != nil {
+		return nil, err
+	}
+	if r.importAliases, err = parseImportAliases(o.ImportAliases); err != nil {
+		return nil, err
+	}
+	if r.diagnosticLevels, err = parseDiagnostics(o.Diagnostics); err != nil {
+		return nil, err
+	}
+	if r.operationFilter, err = NewOperationFilter(o.IncludeTags, o.ExcludeTags, o.IncludePaths, o.ExcludePaths, o.IncludeOperations, o.ExcludeOperations); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,93 @@
+// servers.go
+package generator
+
+import (
+	"regexp"
+	"strings"
+)
+
+// serverVariablePattern 服务器地址中的变量，例如 https://{region}.example.com 中的 {region}
+var serverVariablePattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// serverSpec 生成 buildBaseUrl 需要的文档信息，Generate 使用的 OpenAPI 类型不包含这些字段
+type serverSpec struct {
+	Servers []struct {
+		URL       string                    `yaml:"url"`
+		Variables map[string]serverVariable `yaml:"variables"`
+	} `yaml:"servers"`
+}
+
+// serverVariable servers[].variables 中的一个变量
+type serverVariable struct {
+	Enum        []string `yaml:"enum"`
+	Default     *string  `yaml:"default"`
+	Description string   `yaml:"description"`
+}
+
+// ServerData config.ts 中 buildBaseUrl 的模板数据
+type ServerData struct {
+	URL       string // 转义后可直接放入单引号的地址模板
+	Variables []ServerVariableData
+	Defaults  []string // 变量的默认值，例如 region: 'us'
+	Optional  bool     // 所有变量都有默认值，buildBaseUrl 可以不传参数
+}
+
+// ServerVariableData 一个服务器变量，Key 不是合法标识符时加引号，Type 为 enum 取值（按文档中的顺序）的联合类型或 string，
+// Default 为单引号字符串，没有默认值时为空
+type ServerVariableData struct {
+	Key         string
+	Type        string
+	Default     string
+	Description string
+}
+
+// serverData 读取文档中第一个服务器的地址和变量；没有服务器或地址中没有变量时返回 nil
+func (r *run) serverData(data []byte) *ServerData {
+	var doc serverSpec
+	if err := r.decodeDocument(data, &doc); err != nil {
+		r.logger.Warn("read servers failed, buildBaseUrl is not generated", "err", err)
+		return nil
+	}
+	if len(doc.Servers) == 0 {
+		return nil
+	}
+	server := doc.Servers[0]
+	used := make(map[string]bool)
+	for _, m := range serverVariablePattern.FindAllStringSubmatch(server.URL, -1) {
+		used[m[1]] = true
+		if _, ok := server.Variables[m[1]]; !ok {
+			r.logger.Warn("server url uses an undeclared variable, buildBaseUrl keeps it as is", "url", server.URL, "variable", m[1])
+		}
+	}
+	if len(server.Variables) == 0 {
+		return nil
+	}
+
+	result := &ServerData{URL: singleQuoteEscaper.Replace(server.URL), Optional: true}
+	for _, name := range sortedKeys(server.Variables) {
+		variable := server.Variables[name]
+		if !used[name] {
+			r.logger.Warn("server variable is not used in the server url", "url", server.URL, "variable", name)
+		}
+		data := ServerVariableData{Key: objectKey(name), Type: "string", Description: strings.Join(strings.Fields(variable.Description), " ")}
+		if len(variable.Enum) > 0 {
+			var values []string
+			for _, value := range variable.Enum {
+				values = append(values, "'"+singleQuoteEscaper.Replace(value)+"'")
+			}
+			data.Type = strings.Join(values, " | ")
+		}
+		if variable.Default != nil {
+			if len(variable.Enum) > 0 && !contains(variable.Enum, *variable.Default) {
+				r.logger.Warn("server variable default is not one of its enum values", "variable", name, "default", *variable.Default)
+			}
+			data.Default = "'" + singleQuoteEscaper.Replace(*variable.Default) + "'"
+			result.Defaults = append(result.Defaults, data.Key+": "+data.Default)
+		} else {
+			// OpenAPI 要求每个变量都有默认值，缺少时调用方必须传入
+			result.Optional = false
+		}
+		result.Variables = append(result.Variables, data)
+	}
+	return result
+}
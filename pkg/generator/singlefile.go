@@ -0,0 +1,170 @@
+// singlefile.go
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// singleFileHeads 按顺序合并的公共文件，枚举需要先于使用它的校验 schema 定义
+var singleFileHeads = []string{
+	"types/enum.ts",
+	"types/shared.ts",
+	"types/index.ts",
+	"types/namespaces.ts",
+	"types/schemas.ts",
+	"config.ts",
+	"runtime.ts",
+	"http.ts",
+	"index.ts",
+}
+
+// bundleFiles 将生成的公共文件和各模块的 index.ts 合并为一个文件：
+// 文件之间的相对导入被移除，外部依赖的导入去重后放到文件开头，
+// 每个模块的函数放在与模块同名的 namespace 中，调用方式与 import * as user 一致
+func bundleFiles(files Files, name string) ([]byte, error) {
+	sources := make(map[string]string)
+	for filename, data := range files {
+		sources[filename] = string(data)
+	}
+
+	// 模块函数文件 <模块>/index.ts，以及 -group-types 生成的模块类型文件 <模块>/types/index.ts 和 -group-enums 生成的模块枚举文件 <模块>/enum.ts
+	var moduleFiles, typeFiles, enumFiles []string
+	for rel := range sources {
+		dir, base := path.Split(rel)
+		switch {
+		case base == "enum.ts" && dir != "types/" && strings.Count(dir, "/") == 1:
+			enumFiles = append(enumFiles, rel)
+		case base != "index.ts" || dir == "" || dir == "types/":
+		case strings.Count(dir, "/") == 1:
+			moduleFiles = append(moduleFiles, rel)
+		case strings.HasSuffix(dir, "/types/") && strings.Count(dir, "/") == 2:
+			typeFiles = append(typeFiles, rel)
+		}
+	}
+	sort.Strings(moduleFiles)
+	sort.Strings(typeFiles)
+	sort.Strings(enumFiles)
+	order := append(enumFiles, singleFileHeads[:2]...)
+	order = append(order, typeFiles...)
+	order = append(order, singleFileHeads[2:]...)
+	order = append(order, moduleFiles...)
+
+	var directives, imports []string
+	seenImports := make(map[string]bool)
+	var body strings.Builder
+	for _, rel := range order {
+		source, ok := sources[rel]
+		if !ok {
+			continue
+		}
+		code, fileImports, fileDirectives := stripImports(source, rel, path.Dir(filepath.ToSlash(name)), sources)
+		for _, imp := range fileImports {
+			if !seenImports[imp] {
+				seenImports[imp] = true
+				imports = append(imports, imp)
+			}
+		}
+		for _, directive := range fileDirectives {
+			if !contains(directives, directive) {
+				directives = append(directives, directive)
+			}
+		}
+
+		code = strings.TrimSpace(code)
+		if code == "" {
+			continue
+		}
+		body.WriteString("\n")
+		if module := path.Dir(rel); contains(moduleFiles, rel) {
+			fmt.Fprintf(&body, "export namespace %s {\n%s\n}\n", sanitizeIdentifier(camelCase(module)), indent(2, code))
+		} else {
+			body.WriteString(code + "\n")
+		}
+	}
+
+	var out strings.Builder
+	for _, directive := range directives {
+		out.WriteString(directive + "\n")
+	}
+	for _, imp := range imports {
+		out.WriteString(imp + "\n")
+	}
+	out.WriteString(body.String())
+	return []byte(out.String()), nil
+}
+
+// stripImports 移除 source 中指向已合并文件的 import/export ... from 语句，
+// 返回剩余代码、需要保留的外部导入（相对路径改为相对于合并文件所在目录 dir）以及 eslint 等文件级注释
+func stripImports(source, rel, dir string, sources map[string]string) (string, []string, []string) {
+	var code, imports, directives []string
+	// 行号 -> 从这一行开始的静态导入语句；export { request } 之类的本地导出没有模块路径，按普通代码保留
+	type statement struct {
+		source  string
+		endLine int
+	}
+	statements := make(map[int]statement)
+	row, offset := 0, 0
+	for _, imp := range scanImports([]byte(source)) {
+		if imp.Statement < 0 {
+			continue
+		}
+		row += strings.Count(source[offset:imp.Statement], "\n")
+		offset = imp.Statement
+		statements[row] = statement{source: imp.Specifier, endLine: row + strings.Count(source[imp.Statement:imp.End], "\n")}
+	}
+	lines := strings.Split(source, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "/* eslint-disable") {
+			directives = append(directives, trimmed)
+			continue
+		}
+		stmt, ok := statements[i]
+		if !ok {
+			code = append(code, line)
+			continue
+		}
+		text := strings.Join(lines[i:stmt.endLine+1], "\n")
+		i = stmt.endLine
+		source := stmt.source
+		if !strings.HasPrefix(source, ".") {
+			imports = append(imports, text)
+			continue
+		}
+		target := path.Join(path.Dir(rel), source)
+		_, bundled := sources[target]
+		if _, ok := sources[target+".ts"]; ok {
+			bundled = true
+		}
+		if bundled {
+			// 紧挨着的说明注释随语句一起移除
+			for len(code) > 0 && strings.HasPrefix(strings.TrimSpace(code[len(code)-1]), "//") {
+				code = code[:len(code)-1]
+			}
+			continue
+		}
+		// 未合并的相对路径（例如外部提供的 request.ts）改为相对于合并文件
+		if relTarget, err := filepath.Rel(dir, target); err == nil {
+			target = filepath.ToSlash(relTarget)
+		}
+		if !strings.HasPrefix(target, ".") {
+			target = "./" + target
+		}
+		imports = append(imports, strings.Replace(text, "'"+source+"'", "'"+target+"'", 1))
+	}
+	return strings.Join(code, "\n"), imports, directives
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+// synthetic.go
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// SyntheticSize 内置的一种代表性文档
+type SyntheticSize struct {
+	Name       string
+	Operations int
+	Schemas    int
+}
+
+// SyntheticSizes 基准测试使用的代表性文档：操作数和 schema 数
+var SyntheticSizes = []SyntheticSize{
+	{"small", 20, 20},
+	{"medium", 200, 200},
+	{"large", 2000, 2000},
+}
+
+// BenchmarkSpec 以 b.N 次 Generate 为一次基准，BenchmarkGenerate 和 moonbeam bench 共用；
+// 计时之前先生成一次，文档无法生成时基准失败
+func BenchmarkSpec(b *testing.B, g *Generator, spec []byte) {
+	if _, err := g.Generate(spec); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := g.Generate(spec); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// SyntheticSpec 生成一个 OpenAPI 文档，用于基准测试：每个资源有列表、创建、查询、删除四个操作，分布在 20 个标签中；
+// 每个 schema 包含常见的标量、格式、数组、枚举和相互引用，内容只由参数决定
+func SyntheticSpec(operations, schemas int) []byte {
+	const tags, enums = 20, 10
+	components := make(map[string]interface{})
+	for i := 0; i < enums; i++ {
+		components[fmt.Sprintf("Status%d", i)] = map[string]interface{}{
+			"type": "string",
+			"enum": []string{"active", "disabled", "deleted"},
+		}
+	}
+	ref := func(name string) map[string]interface{} {
+		return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	}
+	model := func(i int) string { return fmt.Sprintf("Model%d", i%schemas) }
+	for i := 0; i < schemas; i++ {
+		components[model(i)] = map[string]interface{}{
+			"type":        "object",
+			"description": fmt.Sprintf("Model %d", i),
+			"required":    []string{"id", "name"},
+			"properties": map[string]interface{}{
+				"id":        map[string]interface{}{"type": "string", "format": "uuid"},
+				"name":      map[string]interface{}{"type": "string", "minLength": 1, "maxLength": 64},
+				"count":     map[string]interface{}{"type": "integer", "format": "int32", "minimum": 0},
+				"price":     map[string]interface{}{"type": "number", "format": "double"},
+				"active":    map[string]interface{}{"type": "boolean"},
+				"tags":      map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+				"createdAt": map[string]interface{}{"type": "string", "format": "date-time"},
+				"status":    ref(fmt.Sprintf("Status%d", i%enums)),
+				"parent":    ref(model(i + 1)),
+				"children":  map[string]interface{}{"type": "array", "items": ref(model(i + 2))},
+			},
+		}
+	}
+
+	body := func(schema interface{}) map[string]interface{} {
+		return map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}}
+	}
+	ok := func(schema interface{}) map[string]interface{} {
+		return map[string]interface{}{"200": map[string]interface{}{"description": "OK", "content": body(schema)}}
+	}
+	idParam := []interface{}{map[string]interface{}{"name": "id", "in": "path", "required": true, "schema": map[string]interface{}{"type": "string"}}}
+	paths := make(map[string]interface{})
+	for r := 0; r*4 < operations; r++ {
+		tag := []string{fmt.Sprintf("tag%d", r%tags)}
+		collection := map[string]interface{}{
+			"get": map[string]interface{}{
+				"operationId": fmt.Sprintf("listR%d", r), "summary": fmt.Sprintf("List resource %d", r), "tags": tag,
+				"parameters": []interface{}{
+					map[string]interface{}{"name": "limit", "in": "query", "schema": map[string]interface{}{"type": "integer"}},
+					map[string]interface{}{"name": "q", "in": "query", "schema": map[string]interface{}{"type": "string"}},
+				},
+				"responses": ok(map[string]interface{}{"type": "array", "items": ref(model(r))}),
+			},
+		}
+		item := map[string]interface{}{
+			"get": map[string]interface{}{
+				"operationId": fmt.Sprintf("getR%d", r), "summary": fmt.Sprintf("Get resource %d", r), "tags": tag,
+				"parameters": idParam, "responses": ok(ref(model(r))),
+			},
+		}
+		if r*4+1 < operations {
+			collection["post"] = map[string]interface{}{
+				"operationId": fmt.Sprintf("createR%d", r), "summary": fmt.Sprintf("Create resource %d", r), "tags": tag,
+				"requestBody": map[string]interface{}{"required": true, "content": body(ref(model(r)))},
+				"responses":   ok(ref(model(r))),
+			}
+		}
+		paths[fmt.Sprintf("/r%d", r)] = collection
+		if r*4+2 < operations {
+			paths[fmt.Sprintf("/r%d/{id}", r)] = item
+		}
+		if r*4+3 < operations {
+			item["delete"] = map[string]interface{}{
+				"operationId": fmt.Sprintf("deleteR%d", r), "summary": fmt.Sprintf("Delete resource %d", r), "tags": tag,
+				"parameters": idParam, "responses": ok(ref(model(r))),
+			}
+		}
+	}
+
+	data, _ := json.MarshalIndent(map[string]interface{}{
+		"openapi":    "3.0.3",
+		"info":       map[string]interface{}{"title": "Synthetic", "version": "1.0.0"},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": components},
+	}, "", "  ")
+	return data
+}
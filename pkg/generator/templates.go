@@ -0,0 +1,116 @@
+// templates.go
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// parseTemplate 解析模板，name 为内置模板路径，例如 templates/function.tmpl
+func (r *run) parseTemplate(name string) (*template.Template, error) {
+	tmpl := template.New(path.Base(name)).Funcs(templateFuncs).Funcs(namingFuncs(r.naming))
+	if file := r.overlayTemplate(name); file != "" {
+		return tmpl.ParseFiles(file)
+	}
+	return tmpl.ParseFS(templateFS, name)
+}
+
+// overlayTemplate 返回 templateDir 中覆盖 name 的模板文件，不存在时返回空字符串
+func (r *run) overlayTemplate(name string) string {
+	if r.templateDir == "" {
+		return ""
+	}
+	file := filepath.Join(r.templateDir, path.Base(name))
+	if _, err := os.Stat(file); err != nil {
+		return ""
+	}
+	return file
+}
+
+// CheckTemplateDir 校验模板目录，返回其中覆盖内置模板的文件，以及与内置模板不同名、不会被使用的 .tmpl 文件
+func CheckTemplateDir(dir string) (overrides, ignored []string, err error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !info.IsDir() {
+		return nil, nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".tmpl") {
+			continue
+		}
+		file := filepath.Join(dir, entry.Name())
+		if _, err := fs.Stat(templateFS, "templates/"+entry.Name()); err != nil {
+			ignored = append(ignored, file)
+			continue
+		}
+		overrides = append(overrides, file)
+	}
+	return overrides, ignored, nil
+}
+
+// templateFuncs 所有模板（包括 -templates 覆盖的模板）可用的辅助函数，参数顺序与 sprig 一致，便于管道调用
+var templateFuncs = template.FuncMap{
+	"toCamel":            camelCase,
+	"toPascal":           pascalCase,
+	"toKebab":            kebabCase,
+	"toSnake":            snakeCase,
+	"lower":              strings.ToLower,
+	"upper":              strings.ToUpper,
+	"pluralize":          pluralize,
+	"sanitizeIdentifier": sanitizeIdentifier,
+	"quote":              strconv.Quote,
+	"indent":             indent,
+	"join":               join,
+	"replace":            func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"trimPrefix":         func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix":         func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"hasPrefix":          func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":          func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+}
+
+// namingFuncs 取决于 -naming 的模板函数，与 templateFuncs 一起提供给所有模板
+func namingFuncs(naming NamingConvention) template.FuncMap {
+	return template.FuncMap{"functionName": naming.Function}
+}
+
+// indent 为每个非空行添加 spaces 个空格
+func indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = pad + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// join 使用 sep 连接列表，列表元素可以是任意类型
+func join(sep string, list interface{}) string {
+	if strs, ok := list.([]string); ok {
+		return strings.Join(strs, sep)
+	}
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Sprint(list)
+	}
+	parts := make([]string, v.Len())
+	for i := range parts {
+		parts[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,133 @@
+// typemapping.go
+package generator
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// typeMapping 替换后的类型
+type typeMapping struct {
+	Type   string // TypeScript 类型表达式
+	Module string // 导入 Type 的模块，为空时 Type 是全局类型；以 . 开头时相对于输出目录
+}
+
+// typeMappingPattern -type-mapping 的取值：<type>[/<format>]=<TypeScript 类型>[ from <模块>]
+var typeMappingPattern = regexp.MustCompile(`^\s*(string|integer|number|boolean)(?:/([^\s=]+))?\s*=\s*(.*?)(?:\s+from\s+(\S+))?\s*$`)
+
+// parseTypeMappings 解析 -type-mapping，同一个 type/format 出现多次时后面的优先，与命令行参数覆盖配置文件一致
+func parseTypeMappings(values []string) (map[string]typeMapping, error) {
+	mappings := make(map[string]typeMapping)
+	for _, value := range values {
+		m := typeMappingPattern.FindStringSubmatch(value)
+		if m == nil || m[3] == "" || strings.ContainsAny(m[3], "\r\n") {
+			return nil, fmt.Errorf("invalid -type-mapping %q, expected '<type>[/<format>]=<TypeScript type>[ from <module>]', e.g. 'string/decimal=Big from big.js'", value)
+		}
+		if m[4] != "" && !identifierPattern.MatchString(m[3]) {
+			return nil, fmt.Errorf("invalid -type-mapping %q, a type imported from a module must be a plain name such as Big", value)
+		}
+		key := m[1]
+		if m[2] != "" {
+			key += "/" + m[2]
+		}
+		mappings[key] = typeMapping{Type: m[3], Module: strings.Trim(m[4], `'"`)}
+	}
+	return mappings, nil
+}
+
+// mapType 按 -type-mapping 替换基础类型，format 为文档中的格式：先找 type/format，再找只有 type 的映射；
+// x-moonbeam-type 优先，其他种类的类型不替换
+func (r *run) mapType(t ir.Type, format string) ir.Type {
+	if t.TSType != "" || len(r.typeMappings) == 0 {
+		return t
+	}
+	mapping, ok := r.typeMappings[string(t.Kind)+"/"+format]
+	if !ok {
+		mapping, ok = r.typeMappings[string(t.Kind)]
+	}
+	if ok {
+		t.TSType, t.TSImport = mapping.Type, mapping.Module
+	}
+	return t
+}
+
+// typeImport 一条 import type 语句
+type typeImport struct {
+	Names []string
+	From  string
+}
+
+// modelTypeImports 模型中映射类型需要导入的名称，模块 -> 名称；不需要导入时返回 nil
+func modelTypeImports(model ir.Model) map[string]map[string]bool {
+	var imports map[string]map[string]bool
+	var visit func(t ir.Type)
+	visit = func(t ir.Type) {
+		if t.TSImport != "" {
+			if imports == nil {
+				imports = make(map[string]map[string]bool)
+			}
+			if imports[t.TSImport] == nil {
+				imports[t.TSImport] = make(map[string]bool)
+			}
+			imports[t.TSImport][t.TSType] = true
+		}
+		if t.Items != nil {
+			visit(*t.Items)
+		}
+		for _, element := range t.Elements {
+			visit(element)
+		}
+		if t.Rest != nil {
+			visit(*t.Rest)
+		}
+	}
+	if model.Alias != nil {
+		visit(*model.Alias)
+	}
+	for _, field := range model.Fields {
+		visit(field.Type)
+	}
+	return imports
+}
+
+// typeImportsFor file 中的接口需要的 import type 语句，按模块排序
+func typeImportsFor(file string, names []string, imports map[string]map[string]map[string]bool) []typeImport {
+	merged := make(map[string]map[string]bool)
+	for _, name := range names {
+		for module, types := range imports[name] {
+			if merged[module] == nil {
+				merged[module] = make(map[string]bool)
+			}
+			for typeName := range types {
+				merged[module][typeName] = true
+			}
+		}
+	}
+	var result []typeImport
+	for _, module := range sortedKeys(merged) {
+		var typeNames []string
+		for typeName := range merged[module] {
+			typeNames = append(typeNames, typeName)
+		}
+		sort.Strings(typeNames)
+		result = append(result, typeImport{Names: typeNames, From: typeImportFrom(module, file)})
+	}
+	return result
+}
+
+// typeImportFrom 导入映射类型的模块路径：以 . 开头的路径相对于输出目录，按 file 所在的目录改写
+func typeImportFrom(module, file string) string {
+	if !strings.HasPrefix(module, ".") {
+		return module
+	}
+	rel := path.Join(strings.Repeat("../", strings.Count(file, "/")), module)
+	if !strings.HasPrefix(rel, ".") {
+		rel = "./" + rel
+	}
+	return rel
+}
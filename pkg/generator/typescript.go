@@ -0,0 +1,137 @@
+// typescript.go
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// tsType 将 IR 类型转换为 TypeScript 类型；枚举保持完整名称，模型去除命名空间前缀
+func tsType(t ir.Type) string {
+	if t.TSType != "" {
+		return t.TSType
+	}
+	switch t.Kind {
+	case ir.Ref:
+		return interfaceName(t.Ref)
+	case ir.EnumRef:
+		if t.Ref != "" {
+			return t.Ref
+		}
+		return "string" // 内联枚举在 TypeScript 中表示为 string
+	case ir.Tuple:
+		parts := make([]string, 0, len(t.Elements)+1)
+		for _, element := range t.Elements {
+			parts = append(parts, tsElementType(element))
+		}
+		if t.Rest != nil {
+			parts = append(parts, "..."+tsElementType(*t.Rest)+"[]")
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case ir.Array:
+		if t.Items == nil {
+			return "any[]"
+		}
+		return tsElementType(*t.Items) + "[]"
+	case ir.Map:
+		return "{ [key: string]: " + tsType(*t.Items) + " }"
+	case ir.Unknown:
+		return "unknown"
+	default:
+		return tsPrimitive(t.Kind)
+	}
+}
+
+// tsElementType 数组和元组元素的类型，引用去除命名空间前缀，对象视为 any
+func tsElementType(t ir.Type) string {
+	if t.TSType != "" {
+		return t.TSType
+	}
+	if t.Ref != "" {
+		return interfaceName(t.Ref)
+	}
+	if t.Kind == ir.Object {
+		return "any"
+	}
+	return tsPrimitive(t.Kind)
+}
+
+func tsPrimitive(kind ir.Kind) string {
+	switch kind {
+	case ir.String:
+		return "string"
+	case ir.Integer, ir.Number:
+		return "number"
+	case ir.Boolean:
+		return "boolean"
+	case ir.Object:
+		return "object"
+	default:
+		return "any"
+	}
+}
+
+// typeConstraints 类型的校验约束，没有约束时返回零值
+func typeConstraints(t ir.Type) ir.Constraints {
+	if t.Constraints == nil {
+		return ir.Constraints{}
+	}
+	return *t.Constraints
+}
+
+// jsDocTags 将校验约束转换为 JSDoc 标签，例如 @minLength 1
+func jsDocTags(t ir.Type) []string {
+	c := typeConstraints(t)
+	var tags []string
+	if c.MinLength != nil {
+		tags = append(tags, fmt.Sprintf("@minLength %d", *c.MinLength))
+	}
+	if c.MaxLength != nil {
+		tags = append(tags, fmt.Sprintf("@maxLength %d", *c.MaxLength))
+	}
+	if c.Pattern != "" {
+		// 避免正则中的 */ 提前结束注释
+		tags = append(tags, "@pattern "+strings.ReplaceAll(c.Pattern, "*/", "*\\/"))
+	}
+	if c.Minimum != nil {
+		tags = append(tags, boundTag("minimum", *c.Minimum, c.ExclusiveMinimum))
+	}
+	if c.Maximum != nil {
+		tags = append(tags, boundTag("maximum", *c.Maximum, c.ExclusiveMaximum))
+	}
+	if c.MultipleOf != nil {
+		tags = append(tags, "@multipleOf "+formatNumber(*c.MultipleOf))
+	}
+	if c.MinItems != nil {
+		tags = append(tags, fmt.Sprintf("@minItems %d", *c.MinItems))
+	}
+	if c.MaxItems != nil {
+		tags = append(tags, fmt.Sprintf("@maxItems %d", *c.MaxItems))
+	}
+	if c.UniqueItems {
+		tags = append(tags, "@uniqueItems")
+	}
+	return tags
+}
+
+// boundTag 生成 @minimum 或 @exclusiveMinimum 等标签
+func boundTag(name string, bound float64, exclusive bool) string {
+	if exclusive {
+		name = "exclusive" + strings.ToUpper(name[:1]) + name[1:]
+	}
+	return fmt.Sprintf("@%s %s", name, formatNumber(bound))
+}
+
+// modelRefs 模型定义中引用的其他模型和枚举原始名称：类型别名或基类和字段类型
+func modelRefs(model ir.Model) []string {
+	if model.Alias != nil {
+		return model.Alias.Refs()
+	}
+	refs := append([]string(nil), model.Extends...)
+	for _, field := range model.Fields {
+		refs = append(refs, field.Type.Refs()...)
+	}
+	return refs
+}
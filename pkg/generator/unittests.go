@@ -0,0 +1,58 @@
+// unittests.go
+package generator
+
+import (
+	"path/filepath"
+	"sort"
+	"text/template"
+)
+
+// unitTestMocks 各测试框架中 mock/fn/mocked 所在的对象，与 describe/it 从同一个来源导入
+var unitTestMocks = map[string]string{
+	"vitest": "vi",
+	"jest":   "jest",
+}
+
+// UnitTestFileData 单元测试文件模板数据
+type UnitTestFileData struct {
+	ModuleName string
+	Framework  string   // 测试框架导入来源
+	Mock       string   // vi 或 jest
+	Types      []string // 参数和响应引用的类型，从 ../index.ts 导入
+	Mocks      []string // -mocks 时从 <模块>/mocks.ts 导入的模拟响应
+	Operations []FunctionData
+}
+
+// renderUnitTests 在 __tests__ 目录下为每个模块生成单元测试脚手架：
+// 模拟 ../index.ts 中的 request 实例，断言每个函数请求的方法、路径和参数，并原样返回响应
+func (r *run) renderUnitTests(framework string, tmpl *template.Template, modules map[string]*ModuleData) {
+	for _, name := range sortedKeys(modules) {
+		mod := modules[name]
+		if len(mod.Operations) == 0 {
+			continue
+		}
+		data := UnitTestFileData{
+			ModuleName: mod.Name,
+			Framework:  contractFrameworks[framework],
+			Mock:       unitTestMocks[framework],
+			Operations: mod.Operations,
+		}
+		types := make(map[string]bool)
+		for _, op := range mod.Operations {
+			for _, ref := range op.ParamRefs {
+				types[ref] = true
+			}
+			for _, ref := range op.ResponseRefs {
+				types[ref] = true
+			}
+			// 与 renderMockHandlers 一致，空响应没有模拟响应
+			if r.mocks && !op.EmptyResponse {
+				data.Mocks = append(data.Mocks, r.naming.Function(op.Name+"Mock"))
+			}
+		}
+		data.Types = sortedKeys(types)
+		sort.Strings(data.Mocks)
+		filename := filepath.Join("__tests__", mod.Name+".spec.ts")
+		r.writeModuleFile(tmpl, filename, "unit test", mod.Name, data)
+	}
+}
@@ -0,0 +1,357 @@
+// unsupported.go
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Unsupported 文档中没有生成、或生成为 any / object 的部分，用于告诉后端需要修改的位置
+type Unsupported struct {
+	Feature  string `json:"feature"`  // 功能分类，例如 oneOf、inline-request-body、format
+	Location string `json:"location"` // JSON Pointer，例如 #/components/schemas/Pet/oneOf
+	Line     int    `json:"line"`     // 行号，文档经过格式转换时为转换后文档中的行号
+	Message  string `json:"message"`
+	Rule     string `json:"rule"`     // 诊断规则：生成为 any、object 或没有类型时为 any-type，其他为 unsupported
+	Severity string `json:"severity"` // 按 -diagnostic 设置的级别：ignore、warn 或 error
+}
+
+// anyTypeFeatures 生成为 any、object 或没有类型的功能分类，属于 any-type 规则
+var anyTypeFeatures = map[string]bool{
+	"untyped": true, "inline-object": true, "inline-items": true, "additional-properties": true, "parameter-items": true,
+	"request-body-ref": true, "inline-request-body": true, "response-ref": true, "inline-response": true,
+}
+
+// knownFormats 生成时会识别的 format，其余 format 按基础类型生成；password 只是界面提示，不影响类型
+var knownFormats = map[string]bool{
+	"date-time": true, "date": true, "byte": true, "binary": true, "password": true,
+	"int32": true, "int64": true, "float": true, "double": true,
+	"email": true, "uuid": true, "uri": true, "url": true,
+}
+
+// elementTypes 数组元素、元组元素和 allOf 成员中能直接生成的内联类型，见 irBuilder.elementType
+var elementTypes = map[string]bool{"string": true, "integer": true, "number": true, "boolean": true}
+
+// unsupportedFinder 查找文档中不支持的部分，只检查过滤后仍会生成的接口和 schema
+type unsupportedFinder struct {
+	*run
+	root        *yaml.Node
+	api         *OpenAPI        // parseSpec 返回的文档，路径不会被改名
+	keptSchemas map[string]bool // 仍会生成的 schema 在文档中的名称
+	features    []Unsupported
+}
+
+// unsupportedFeatures 返回文档中不支持的部分，按行号排序；api 为 parseSpec 返回的文档，只检查其中保留的接口和 schema
+func (r *run) unsupportedFeatures(data []byte, api *OpenAPI) ([]Unsupported, error) {
+	doc, err := r.parseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	schemas := make(map[string]bool, len(api.Components.Schemas))
+	for name := range api.Components.Schemas {
+		if original, ok := r.schemaNames[name]; ok {
+			name = original
+		}
+		schemas[name] = true
+	}
+	f := &unsupportedFinder{run: r, root: doc.Content[0], api: api, keptSchemas: schemas}
+	f.paths()
+	f.schemas()
+	sort.SliceStable(f.features, func(i, j int) bool { return f.features[i].Line < f.features[j].Line })
+	return f.features, nil
+}
+
+// reportUnsupported 将不支持的部分按规则的级别逐条记录
+func (r *run) reportUnsupported(data []byte, api *OpenAPI) error {
+	features, err := r.unsupportedFeatures(data, api)
+	if err != nil {
+		return err
+	}
+	for _, feature := range features {
+		r.logDiagnostic(feature.Severity, feature.Rule, feature.Message, "feature", feature.Feature, "at", feature.Location, "line", feature.Line)
+	}
+	return nil
+}
+
+func (f *unsupportedFinder) add(feature string, node *yaml.Node, pointer, format string, args ...interface{}) {
+	rule := "unsupported"
+	if anyTypeFeatures[feature] {
+		rule = "any-type"
+	}
+	f.features = append(f.features, Unsupported{Feature: feature, Location: "#" + pointer, Line: node.Line, Message: fmt.Sprintf(format, args...),
+		Rule: rule, Severity: f.diagnosticLevel(rule)})
+}
+
+// deref 展开 $ref，无法解析时返回 nil（由 checkRefs 报告）
+func (f *unsupportedFinder) deref(node *yaml.Node) *yaml.Node {
+	for i := 0; node != nil && i < 10; i++ {
+		ref := f.field(node, "$ref")
+		if ref == nil {
+			return node
+		}
+		node, _ = f.resolveRef(f.root, ref.Value)
+	}
+	return nil
+}
+
+// kept 过滤后是否仍生成该接口
+func (f *unsupportedFinder) kept(path, method string) bool {
+	item, ok := f.api.Paths[path]
+	if !ok {
+		return false
+	}
+	switch method {
+	case "get":
+		return item.Get != nil
+	case "post":
+		return item.Post != nil
+	case "put":
+		return item.Put != nil
+	case "delete":
+		return item.Delete != nil
+	}
+	return false
+}
+
+func (f *unsupportedFinder) paths() {
+	paths := f.field(f.root, "paths")
+	if paths == nil || paths.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		path := paths.Content[i].Value
+		pointer := "/paths/" + escapePointer(path)
+		item := f.deref(paths.Content[i+1])
+		if item == nil {
+			continue
+		}
+		for _, method := range httpMethods {
+			op := f.field(item, method)
+			if op == nil {
+				continue
+			}
+			switch {
+			case method != "get" && method != "post" && method != "put" && method != "delete":
+				if _, ok := f.api.Paths[path]; ok {
+					f.add("method", op, pointer+"/"+method, "%s operations are not generated", strings.ToUpper(method))
+				}
+			case f.kept(path, method):
+				f.operation(op, pointer+"/"+method)
+			}
+		}
+	}
+}
+
+func (f *unsupportedFinder) operation(op *yaml.Node, pointer string) {
+	body := f.field(op, "requestBody")
+	if params := f.field(op, "parameters"); params != nil && params.Kind == yaml.SequenceNode {
+		for i, param := range params.Content {
+			f.parameter(resolveAlias(param), pointer+"/parameters/"+strconv.Itoa(i), body != nil)
+		}
+	}
+
+	if body != nil {
+		if f.field(body, "$ref") != nil {
+			f.add("request-body-ref", body, pointer+"/requestBody", "requestBody $ref is not supported, the request body is untyped")
+		} else {
+			f.content(f.field(body, "content"), pointer+"/requestBody", "inline-request-body", "request body")
+		}
+	}
+
+	responses := f.field(op, "responses")
+	if responses == nil || responses.Kind != yaml.MappingNode {
+		return
+	}
+	if response := f.field(responses, "200"); response != nil {
+		if f.field(response, "$ref") != nil {
+			f.add("response-ref", response, pointer+"/responses/200", "response $ref is not supported, the response is untyped")
+		} else {
+			f.content(f.field(response, "content"), pointer+"/responses/200", "inline-response", "response")
+		}
+		return
+	}
+	for i := 0; i+1 < len(responses.Content); i += 2 {
+		code := responses.Content[i].Value
+		if strings.HasPrefix(code, "2") && f.field(f.deref(responses.Content[i+1]), "content") != nil {
+			f.add("response-status", responses.Content[i], pointer+"/responses/"+code, "only 200 responses are typed, the %s response is ignored", code)
+		}
+	}
+}
+
+// content 请求体和响应只支持引用组件 schema（或其数组）的内容类型，见 irBuilder.requestBodyType
+func (f *unsupportedFinder) content(content *yaml.Node, pointer, feature, what string) {
+	if content == nil || content.Kind != yaml.MappingNode {
+		return
+	}
+	var inline *yaml.Node
+	var inlinePointer string
+	for i := 0; i+1 < len(content.Content); i += 2 {
+		schema := f.field(content.Content[i+1], "schema")
+		if schema == nil {
+			continue
+		}
+		// 与 MediaSchema 相同：组件引用或元素为组件引用的数组
+		if t := f.field(schema, "type"); f.field(schema, "$ref") != nil || t != nil && t.Value == "array" && f.field(f.field(schema, "items"), "$ref") != nil {
+			return
+		}
+		if inline == nil {
+			inline, inlinePointer = schema, pointer+"/content/"+escapePointer(content.Content[i].Value)+"/schema"
+		}
+	}
+	if inline != nil {
+		f.add(feature, inline, inlinePointer, "inline %s schema is not supported, move it to components/schemas; the %s is untyped", what, what)
+	}
+}
+
+// parameter 检查一个参数；hasBody 为 true 时接口的参数就是请求体，查询参数不会生成，见 irBuilder.operations
+func (f *unsupportedFinder) parameter(param *yaml.Node, pointer string, hasBody bool) {
+	if ref := f.field(param, "$ref"); ref != nil {
+		f.add("parameter-ref", param, pointer, "parameter $ref %s is not supported, the parameter is skipped", ref.Value)
+		return
+	}
+	name, in := f.field(param, "name"), f.field(param, "in")
+	if name == nil || in == nil {
+		return
+	}
+	if in.Value == "header" || in.Value == "cookie" {
+		f.add("parameter-location", in, pointer+"/in", "%s parameter %q is not generated", in.Value, name.Value)
+		return
+	}
+	if in.Value == "query" && hasBody {
+		f.add("body-query-parameter", in, pointer+"/in", "query parameter %q of an operation with a request body is not generated", name.Value)
+		return
+	}
+	schema := f.field(param, "schema")
+	if schema == nil {
+		return
+	}
+	if t := f.field(schema, "type"); t != nil && t.Value == "array" && f.field(schema, "items") == nil {
+		f.add("parameter-items", schema, pointer+"/schema", "array parameter %q has no items, generated as any[]", name.Value)
+	}
+	f.keywords(schema, pointer+"/schema")
+}
+
+func (f *unsupportedFinder) schemas() {
+	schemas := f.field(f.field(f.root, "components"), "schemas")
+	if schemas == nil || schemas.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(schemas.Content); i += 2 {
+		name := schemas.Content[i].Value
+		if !f.keptSchemas[name] {
+			continue
+		}
+		schema := resolveAlias(schemas.Content[i+1])
+		pointer := "/components/schemas/" + escapePointer(name)
+		if schema.Kind != yaml.MappingNode || f.field(schema, "$ref") != nil {
+			continue
+		}
+		f.keywords(schema, pointer)
+		if allOf := f.field(schema, "allOf"); allOf != nil && allOf.Kind == yaml.SequenceNode {
+			for j, member := range allOf.Content {
+				if f.field(member, "$ref") == nil && f.field(member, "properties") != nil {
+					f.add("allOf", member, pointer+"/allOf/"+strconv.Itoa(j), "inline allOf members are ignored, only $ref members are inherited")
+				}
+			}
+		}
+		if additional := f.field(schema, "additionalProperties"); additional != nil && additional.Kind == yaml.MappingNode {
+			f.add("additional-properties", additional, pointer+"/additionalProperties", "additionalProperties of component schemas are not generated")
+		}
+		f.items(schema, pointer)
+		if properties := f.field(schema, "properties"); properties != nil && properties.Kind == yaml.MappingNode {
+			for j := 0; j+1 < len(properties.Content); j += 2 {
+				f.property(resolveAlias(properties.Content[j+1]), pointer+"/properties/"+escapePointer(properties.Content[j].Value))
+			}
+		}
+	}
+}
+
+// property 组件 schema 的属性，只生成引用、基础类型、数组、元组、字符串字典和内联枚举，见 irBuilder.propertyType
+func (f *unsupportedFinder) property(prop *yaml.Node, pointer string) {
+	if prop.Kind != yaml.MappingNode || f.field(prop, "$ref") != nil {
+		return
+	}
+	f.keywords(prop, pointer)
+	if allOf := f.field(prop, "allOf"); allOf != nil && allOf.Kind == yaml.SequenceNode && len(allOf.Content) > 1 {
+		f.add("allOf", allOf, pointer+"/allOf", "only the first of %d allOf members is used", len(allOf.Content))
+	}
+	if f.field(prop, "properties") != nil {
+		f.add("inline-object", prop, pointer, "inline object properties are not generated, move the schema to components/schemas; the property is typed as object")
+	} else if f.untyped(prop) {
+		f.add("untyped", prop, pointer, "property has no type, generated as any")
+	}
+	if additional := f.field(prop, "additionalProperties"); additional != nil && additional.Kind == yaml.MappingNode {
+		if t, typ := f.field(additional, "type"), f.field(prop, "type"); t == nil || t.Value != "string" || typ == nil || typ.Value != "object" {
+			f.add("additional-properties", additional, pointer+"/additionalProperties", "only additionalProperties of type string are typed, the property is typed as object")
+		}
+	}
+	f.items(prop, pointer)
+}
+
+// untyped 属性没有声明类型，生成为 any；oneOf、anyOf 和 not 由 keywords 报告
+func (r *run) untyped(prop *yaml.Node) bool {
+	for _, key := range []string{"type", "allOf", "prefixItems", "enum", "x-moonbeam-type", "oneOf", "anyOf", "not"} {
+		if r.field(prop, key) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// items 数组和元组的元素只支持引用和基础类型，其余生成为 any
+func (f *unsupportedFinder) items(schema *yaml.Node, pointer string) {
+	var elements []*yaml.Node
+	var pointers []string
+	if items := f.field(schema, "items"); items != nil {
+		switch items.Kind {
+		case yaml.MappingNode:
+			elements, pointers = append(elements, items), append(pointers, pointer+"/items")
+		case yaml.SequenceNode:
+			for i, item := range items.Content {
+				elements, pointers = append(elements, resolveAlias(item)), append(pointers, pointer+"/items/"+strconv.Itoa(i))
+			}
+		}
+	}
+	if prefixItems := f.field(schema, "prefixItems"); prefixItems != nil && prefixItems.Kind == yaml.SequenceNode {
+		for i, item := range prefixItems.Content {
+			elements, pointers = append(elements, resolveAlias(item)), append(pointers, pointer+"/prefixItems/"+strconv.Itoa(i))
+		}
+	}
+	for i, element := range elements {
+		if element.Kind != yaml.MappingNode || f.field(element, "$ref") != nil {
+			continue
+		}
+		if t := f.field(element, "type"); t == nil || !elementTypes[t.Value] {
+			f.add("inline-items", element, pointers[i], "inline array item schemas are not supported, move the schema to components/schemas; the items are typed as any")
+			continue
+		}
+		f.keywords(element, pointers[i])
+	}
+}
+
+// keywords 任何位置的 schema 都不支持的关键字
+func (f *unsupportedFinder) keywords(schema *yaml.Node, pointer string) {
+	for _, key := range []string{"oneOf", "anyOf", "not"} {
+		if node := f.field(schema, key); node != nil {
+			f.add(key, node, pointer+"/"+key, "%s is not supported and is ignored", key)
+		}
+	}
+	if format := f.field(schema, "format"); format != nil && !knownFormats[format.Value] {
+		f.add("format", format, pointer+"/format", "unknown format %q, generated as the plain type", format.Value)
+	}
+	if enum := f.field(schema, "enum"); enum != nil && enum.Kind == yaml.SequenceNode {
+		for _, value := range enum.Content {
+			if value.Kind != yaml.ScalarNode || value.Tag != "!!str" && value.Tag != "!!null" {
+				f.add("enum", enum, pointer+"/enum", "only string enum values are generated, other values are dropped")
+				break
+			}
+		}
+	}
+}
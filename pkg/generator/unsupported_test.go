@@ -0,0 +1,61 @@
+// unsupported_test.go
+package generator
+
+import (
+	"log/slog"
+	"testing"
+)
+
+// TestUnsupportedBodyQueryParameter 有请求体的接口的查询参数不会生成，应被报告；没有请求体时查询参数正常生成，不报告
+func TestUnsupportedBodyQueryParameter(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: items
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      operationId: listItems
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: ok
+    post:
+      operationId: createItem
+      parameters:
+        - name: dry_run
+          in: query
+          schema:
+            type: boolean
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Item"
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Item:
+      type: object
+      properties:
+        name:
+          type: string
+`
+	features, err := New(Options{Source: "items.yaml", Logger: slog.New(slog.DiscardHandler)}).Unsupported([]byte(spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(features) != 1 {
+		t.Fatalf("features = %+v, want 1", features)
+	}
+	got := features[0]
+	if got.Feature != "body-query-parameter" || got.Location != "#/paths/~1items/post/parameters/0/in" || got.Line != 21 || got.Rule != "unsupported" {
+		t.Errorf("feature = %+v", got)
+	}
+}
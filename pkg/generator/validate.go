@@ -0,0 +1,450 @@
+// validate.go
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 文档问题的严重程度
+const (
+	// issueError 生成结果必然错误（例如参数缺少 in），生成失败
+	issueError = iota
+	// issueWarning 会影响生成结果（例如路径参数未声明），记录警告
+	issueWarning
+	// issueNotice 不符合规范但不影响生成结果（例如响应缺少 description），-verbose 时显示
+	issueNotice
+)
+
+// specIssue 文档校验发现的问题
+type specIssue struct {
+	Severity int
+	Rule     string // 可以用 -diagnostic 调整级别的规则，为空时级别固定
+	Location string // JSON Pointer
+	Line     int
+	Message  string
+}
+
+func (i specIssue) String() string {
+	if i.Line == 0 {
+		if i.Rule != "" {
+			return fmt.Sprintf("%s: %s (%s)", i.Location, i.Message, i.Rule)
+		}
+		return fmt.Sprintf("%s: %s", i.Location, i.Message)
+	}
+	if i.Rule != "" {
+		return fmt.Sprintf("line %d: %s: %s (%s)", i.Line, i.Location, i.Message, i.Rule)
+	}
+	return fmt.Sprintf("line %d: %s: %s", i.Line, i.Location, i.Message)
+}
+
+// specValidator 按 OpenAPI 3.0 / 3.1 规范检查文档结构，只检查生成会用到的部分
+type specValidator struct {
+	*run
+	root         *yaml.Node
+	version      string // 3.0 或 3.1
+	issues       []specIssue
+	operationIDs map[string]string // operationId -> 第一次出现的位置
+}
+
+// httpMethods 路径项中的请求方法，只有 get、post、put、delete（generatedMethods）会生成接口
+var (
+	httpMethods      = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+	generatedMethods = []string{"get", "put", "post", "delete"}
+)
+
+// pathItemFields 路径项中请求方法以外的字段
+var pathItemFields = map[string]bool{"$ref": true, "summary": true, "description": true, "servers": true, "parameters": true}
+
+// schemaTypes schema 的 type 取值，null 只在 3.1 中合法
+var schemaTypes = map[string]bool{"string": true, "number": true, "integer": true, "boolean": true, "array": true, "object": true, "null": true}
+
+// responseCodePattern 响应的状态码：三位数字、1XX-5XX 或 default
+var responseCodePattern = regexp.MustCompile(`^([1-5][0-9]{2}|[1-5]XX|default)$`)
+
+// pathTemplatePattern 路径模板中的参数
+var pathTemplatePattern = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// validateSpec 检查文档结构，返回按位置排序的全部问题；文档不是合法的 YAML 时返回错误
+func (r *run) validateSpec(data []byte) ([]specIssue, error) {
+	doc, err := r.parseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	v := &specValidator{run: r, operationIDs: make(map[string]string)}
+	if len(doc.Content) == 0 {
+		v.add(issueError, doc, "", "the document is empty")
+		return v.issues, nil
+	}
+	v.root = doc.Content[0]
+	v.document()
+	sort.SliceStable(v.issues, func(i, j int) bool { return v.issues[i].Line < v.issues[j].Line })
+	return v.issues, nil
+}
+
+func (v *specValidator) add(severity int, node *yaml.Node, pointer, format string, args ...interface{}) {
+	if pointer == "" {
+		pointer = "#"
+	}
+	line := node.Line
+	if v.specConverted {
+		// 转换而来的文档重新序列化过，行号不对应输入文件
+		line = 0
+	}
+	v.issues = append(v.issues, specIssue{Severity: severity, Location: pointer, Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+// addRule 按诊断规则的级别记录问题：ignore 记录为提示，warn 为警告，error 为错误
+func (v *specValidator) addRule(rule string, node *yaml.Node, pointer, format string, args ...interface{}) {
+	severity := map[string]int{levelIgnore: issueNotice, levelWarn: issueWarning, levelError: issueError}[v.diagnosticLevel(rule)]
+	v.add(severity, node, pointer, format, args...)
+	v.issues[len(v.issues)-1].Rule = rule
+}
+
+// field 返回映射中 key 对应的值，别名会被展开
+func (r *run) field(node *yaml.Node, key string) *yaml.Node {
+	node = resolveAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	if len(node.Content) >= 2*indexedMappingSize {
+		return resolveAlias(r.mappingIndex(node)[key])
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return resolveAlias(node.Content[i+1])
+		}
+	}
+	return nil
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+// expect 检查节点类型，不符合时记录错误并返回 false
+func (v *specValidator) expect(node *yaml.Node, kind yaml.Kind, pointer, what string) bool {
+	if node.Kind == kind {
+		return true
+	}
+	v.add(issueError, node, pointer, "%s must be %s", what, map[yaml.Kind]string{yaml.MappingNode: "an object", yaml.SequenceNode: "an array", yaml.ScalarNode: "a scalar"}[kind])
+	return false
+}
+
+// deref 展开 $ref，无法解析时返回 nil（由 checkRefs 报告）
+func (v *specValidator) deref(node *yaml.Node) *yaml.Node {
+	for i := 0; node != nil && i < 10; i++ {
+		ref := v.field(node, "$ref")
+		if ref == nil {
+			return node
+		}
+		node, _ = v.resolveRef(v.root, ref.Value)
+	}
+	return nil
+}
+
+func (v *specValidator) document() {
+	root := v.root
+	if !v.expect(root, yaml.MappingNode, "", "the document") {
+		return
+	}
+	if v.field(root, "swagger") != nil {
+		v.add(issueError, root, "", "Swagger 2.0 documents are not supported, convert the spec to OpenAPI 3 first")
+		return
+	}
+	openapi := v.field(root, "openapi")
+	switch {
+	case openapi == nil:
+		v.add(issueError, root, "", "missing openapi version")
+	case strings.HasPrefix(openapi.Value, "3.0"):
+		v.version = "3.0"
+	case strings.HasPrefix(openapi.Value, "3.1"):
+		v.version = "3.1"
+	default:
+		v.add(issueError, openapi, "#/openapi", "unsupported openapi version %q, expected 3.0.x or 3.1.x", openapi.Value)
+	}
+
+	if info := v.field(root, "info"); info == nil {
+		v.add(issueNotice, root, "", "missing info")
+	} else if v.expect(info, yaml.MappingNode, "#/info", "info") {
+		for _, key := range []string{"title", "version"} {
+			if v.field(info, key) == nil {
+				v.add(issueNotice, info, "#/info", "missing info.%s", key)
+			}
+		}
+	}
+
+	paths := v.field(root, "paths")
+	if paths == nil {
+		if v.version == "3.0" {
+			v.add(issueNotice, root, "", "missing paths")
+		}
+	} else if v.expect(paths, yaml.MappingNode, "#/paths", "paths") {
+		for i := 0; i+1 < len(paths.Content); i += 2 {
+			v.pathItem(paths.Content[i].Value, resolveAlias(paths.Content[i+1]))
+		}
+	}
+
+	if components := v.field(root, "components"); components != nil && v.expect(components, yaml.MappingNode, "#/components", "components") {
+		if schemas := v.field(components, "schemas"); schemas != nil && v.expect(schemas, yaml.MappingNode, "#/components/schemas", "components.schemas") {
+			for i := 0; i+1 < len(schemas.Content); i += 2 {
+				name := schemas.Content[i].Value
+				v.schema(resolveAlias(schemas.Content[i+1]), "#/components/schemas/"+escapePointer(name))
+			}
+		}
+	}
+}
+
+func (v *specValidator) pathItem(path string, item *yaml.Node) {
+	pointer := "#/paths/" + escapePointer(path)
+	if !strings.HasPrefix(path, "/") {
+		v.add(issueError, item, pointer, "path %q must start with /", path)
+	}
+	if !v.expect(item, yaml.MappingNode, pointer, "a path item") {
+		return
+	}
+	for i := 0; i < len(item.Content); i += 2 {
+		key := item.Content[i].Value
+		if !pathItemFields[key] && !contains(httpMethods, key) && !strings.HasPrefix(key, "x-") {
+			v.add(issueNotice, item.Content[i], pointer, "unknown field %q in path item", key)
+		}
+	}
+
+	shared := v.parameters(v.field(item, "parameters"), pointer+"/parameters")
+	for _, method := range httpMethods {
+		if op := v.field(item, method); op != nil {
+			v.operation(path, method, op, pointer+"/"+method, shared)
+		}
+	}
+}
+
+func (v *specValidator) operation(path, method string, op *yaml.Node, pointer string, shared map[string]bool) {
+	if !v.expect(op, yaml.MappingNode, pointer, "an operation") {
+		return
+	}
+	v.extensions(op, pointer, operationExtensions)
+	if id := v.field(op, "operationId"); id != nil {
+		if first, ok := v.operationIDs[id.Value]; ok {
+			v.addRule("duplicate-name", id, pointer+"/operationId", "operationId %q is also used at %s", id.Value, first)
+		} else {
+			v.operationIDs[id.Value] = pointer
+		}
+	} else if contains(generatedMethods, method) && v.field(op, "x-moonbeam-name") == nil {
+		v.addRule("missing-operation-id", op, pointer, "missing operationId, the function is named after the method and path")
+	}
+
+	// 路径模板中的参数必须在路径项或接口中声明，声明的路径参数也必须出现在模板中
+	declared := v.parameters(v.field(op, "parameters"), pointer+"/parameters")
+	for name := range shared {
+		declared[name] = true
+	}
+	inTemplate := make(map[string]bool)
+	for _, m := range pathTemplatePattern.FindAllStringSubmatch(path, -1) {
+		inTemplate[m[1]] = true
+		if !declared[m[1]] {
+			v.add(issueWarning, op, pointer, "path parameter {%s} is not declared", m[1])
+		}
+	}
+	for _, name := range sortedKeys(declared) {
+		if !inTemplate[name] {
+			v.add(issueWarning, op, pointer, "path parameter %q does not appear in %s", name, path)
+		}
+	}
+
+	if body := v.field(op, "requestBody"); body != nil {
+		if method == "get" || method == "delete" {
+			v.add(issueNotice, body, pointer+"/requestBody", "%s requests should not have a body", strings.ToUpper(method))
+		}
+		if body = v.deref(body); body != nil && v.expect(body, yaml.MappingNode, pointer+"/requestBody", "requestBody") {
+			v.content(v.field(body, "content"), body, pointer+"/requestBody")
+		}
+	}
+
+	responses := v.field(op, "responses")
+	if responses == nil {
+		v.add(issueNotice, op, pointer, "missing responses")
+		return
+	}
+	if !v.expect(responses, yaml.MappingNode, pointer+"/responses", "responses") {
+		return
+	}
+	for i := 0; i+1 < len(responses.Content); i += 2 {
+		code := responses.Content[i].Value
+		responsePointer := pointer + "/responses/" + escapePointer(code)
+		if !responseCodePattern.MatchString(code) && !strings.HasPrefix(code, "x-") {
+			v.add(issueWarning, responses.Content[i], responsePointer, "invalid response code %q", code)
+		}
+		response := v.deref(resolveAlias(responses.Content[i+1]))
+		if response == nil || !v.expect(response, yaml.MappingNode, responsePointer, "a response") {
+			continue
+		}
+		if v.field(response, "description") == nil {
+			v.add(issueNotice, response, responsePointer, "missing response description")
+		}
+		if content := v.field(response, "content"); content != nil {
+			v.content(content, response, responsePointer)
+		}
+	}
+}
+
+// parameters 检查参数列表，返回其中声明的路径参数名称
+func (v *specValidator) parameters(list *yaml.Node, pointer string) map[string]bool {
+	path := make(map[string]bool)
+	if list == nil || !v.expect(list, yaml.SequenceNode, pointer, "parameters") {
+		return path
+	}
+	for i, item := range list.Content {
+		itemPointer := pointer + "/" + strconv.Itoa(i)
+		param := v.deref(resolveAlias(item))
+		if param == nil || !v.expect(param, yaml.MappingNode, itemPointer, "a parameter") {
+			continue
+		}
+		name, in := v.field(param, "name"), v.field(param, "in")
+		if name == nil {
+			v.add(issueError, param, itemPointer, "parameter is missing name")
+		}
+		switch {
+		case in == nil:
+			v.add(issueError, param, itemPointer, "parameter is missing in")
+		case !contains([]string{"query", "header", "path", "cookie"}, in.Value):
+			v.add(issueError, in, itemPointer+"/in", "invalid parameter location %q, expected query, header, path or cookie", in.Value)
+		case in.Value == "path" && name != nil:
+			path[name.Value] = true
+			if required := v.field(param, "required"); required == nil || required.Value != "true" {
+				v.add(issueNotice, param, itemPointer, "path parameter %q must be required", name.Value)
+			}
+		}
+		if schema := v.field(param, "schema"); schema != nil {
+			v.schema(schema, itemPointer+"/schema")
+		} else if v.field(param, "content") == nil {
+			v.add(issueNotice, param, itemPointer, "parameter has neither schema nor content")
+		}
+	}
+	return path
+}
+
+// content 检查请求体或响应的 content，parent 用于缺少 content 时定位
+func (v *specValidator) content(content, parent *yaml.Node, pointer string) {
+	if content == nil {
+		v.add(issueWarning, parent, pointer, "missing content")
+		return
+	}
+	if !v.expect(content, yaml.MappingNode, pointer+"/content", "content") {
+		return
+	}
+	for i := 0; i+1 < len(content.Content); i += 2 {
+		mediaPointer := pointer + "/content/" + escapePointer(content.Content[i].Value)
+		media := resolveAlias(content.Content[i+1])
+		if !v.expect(media, yaml.MappingNode, mediaPointer, "a media type") {
+			continue
+		}
+		if schema := v.field(media, "schema"); schema != nil {
+			v.schema(schema, mediaPointer+"/schema")
+		}
+	}
+}
+
+// schema 检查 schema 及其嵌套的 schema
+func (v *specValidator) schema(schema *yaml.Node, pointer string) {
+	if schema.Kind == yaml.ScalarNode && v.version == "3.1" && (schema.Value == "true" || schema.Value == "false") {
+		return
+	}
+	if !v.expect(schema, yaml.MappingNode, pointer, "a schema") {
+		return
+	}
+	v.extensions(schema, pointer, schemaExtensions)
+	if v.field(schema, "$ref") != nil {
+		return
+	}
+
+	var types []*yaml.Node
+	if t := v.field(schema, "type"); t != nil {
+		if t.Kind == yaml.SequenceNode && v.version == "3.1" {
+			types = t.Content
+		} else if v.expect(t, yaml.ScalarNode, pointer+"/type", "type") {
+			types = []*yaml.Node{t}
+		}
+	}
+	isArray := false
+	for _, t := range types {
+		switch {
+		case !schemaTypes[t.Value] || (t.Value == "null" && v.version != "3.1"):
+			v.add(issueError, t, pointer+"/type", "unknown type %q", t.Value)
+		case t.Value == "array":
+			isArray = true
+		}
+	}
+	if isArray && v.version == "3.0" && v.field(schema, "items") == nil {
+		v.add(issueNotice, schema, pointer, "array schema is missing items")
+	}
+
+	if required := v.field(schema, "required"); required != nil && required.Kind != yaml.ScalarNode {
+		if v.expect(required, yaml.SequenceNode, pointer+"/required", "required") {
+			properties := v.field(schema, "properties")
+			for _, name := range required.Content {
+				if properties != nil && v.field(properties, name.Value) == nil && v.field(schema, "allOf") == nil {
+					v.add(issueNotice, name, pointer+"/required", "required property %q is not defined", name.Value)
+				}
+			}
+		}
+	}
+	if enum := v.field(schema, "enum"); enum != nil {
+		v.expect(enum, yaml.SequenceNode, pointer+"/enum", "enum")
+	}
+	if properties := v.field(schema, "properties"); properties != nil && v.expect(properties, yaml.MappingNode, pointer+"/properties", "properties") {
+		for i := 0; i+1 < len(properties.Content); i += 2 {
+			v.schema(resolveAlias(properties.Content[i+1]), pointer+"/properties/"+escapePointer(properties.Content[i].Value))
+		}
+	}
+	if items := v.field(schema, "items"); items != nil && items.Kind == yaml.MappingNode {
+		v.schema(items, pointer+"/items")
+	}
+	if additional := v.field(schema, "additionalProperties"); additional != nil && additional.Kind == yaml.MappingNode {
+		v.schema(additional, pointer+"/additionalProperties")
+	}
+	for _, key := range []string{"allOf", "oneOf", "anyOf", "prefixItems"} {
+		if list := v.field(schema, key); list != nil && v.expect(list, yaml.SequenceNode, pointer+"/"+key, key) {
+			for i, item := range list.Content {
+				v.schema(resolveAlias(item), pointer+"/"+key+"/"+strconv.Itoa(i))
+			}
+		}
+	}
+}
+
+// checkSpec 校验文档结构：有错误时列出全部错误并返回；-strict 时警告同样导致失败，否则记录为警告；提示记录为调试日志
+func (r *run) checkSpec(data []byte) error {
+	issues, err := r.validateSpec(data)
+	if err != nil {
+		return err
+	}
+	var failures []string
+	for _, issue := range issues {
+		args := []interface{}{"at", issue.Location}
+		if issue.Line != 0 {
+			args = append(args, "line", issue.Line)
+		}
+		if issue.Rule != "" {
+			args = append(args, "rule", issue.Rule)
+		}
+		switch {
+		case issue.Severity == issueError || issue.Severity == issueWarning && r.strict:
+			failures = append(failures, issue.String())
+		case issue.Severity == issueWarning:
+			r.logger.Warn(issue.Message, args...)
+		default:
+			r.logger.Debug(issue.Message, args...)
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("invalid spec, %d problems: %s", len(failures), strings.Join(failures, "; "))
+	}
+	return nil
+}
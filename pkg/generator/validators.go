@@ -0,0 +1,199 @@
+// validators.go
+package generator
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// validatorEmitters 各运行时校验库对应的生成器
+var validatorEmitters = map[string]validatorEmitter{
+	"zod":   zodEmitter{},
+	"io-ts": iotsEmitter{},
+}
+
+// validatorEmitter 运行时校验代码生成器，遍历 schema 的逻辑由 renderValidators 统一处理
+type validatorEmitter interface {
+	// Header 文件头部的导入语句
+	Header(typeNames, enumNames []string) string
+	// Declare 输出类型对应的校验常量及辅助函数
+	Declare(typeName, expr string) string
+	// Enum 枚举 schema
+	Enum(typeName string) string
+	// Unknown 无法确定类型（如循环别名）
+	Unknown() string
+	// Object 对象，字段按名称排序
+	Object(fields []validatorField) string
+	// Extend allOf 继承，base 为基类类型名
+	Extend(base, expr string) string
+	// Ref 引用其他类型
+	Ref(typeName string) string
+	// Tuple 元组，rest 为空表示没有剩余元素
+	Tuple(elements []string, rest string) string
+	// Array 数组，t 提供 minItems/maxItems 等约束
+	Array(element string, t ir.Type) string
+	// Record 字符串字典
+	Record() string
+	// Literals 内联的字符串枚举，values 已加引号
+	Literals(values []string) string
+	// Primitive 基础类型及其约束
+	Primitive(t ir.Type) string
+}
+
+// validatorField 对象字段
+type validatorField struct {
+	Key      string
+	Expr     string
+	Optional bool
+	Ref      string // 字段直接引用的类型名称，Expr 为 Ref(Ref) 的结果；其他字段为空
+}
+
+// identifierPattern 合法的 TypeScript 标识符
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// objectKey 对象字面量中的键，非法标识符时加引号
+func objectKey(name string) string {
+	if identifierPattern.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+// renderValidators 为模型、枚举和查询参数请求类型生成运行时校验代码，include 为 nil 时包含所有组件 schema
+func renderValidators(e validatorEmitter, api *ir.API, include map[string]bool) string {
+	type declaration struct {
+		name  string
+		model *ir.Model
+	}
+	var declarations, requests []declaration
+	for i := range api.Models {
+		model := &api.Models[i]
+		switch {
+		case model.Synthetic:
+			requests = append(requests, declaration{model.Name, model})
+		case include == nil || include[model.Name]:
+			declarations = append(declarations, declaration{model.Name, model})
+		}
+	}
+	for _, enum := range api.Enums {
+		if include == nil || include[enum.Name] {
+			declarations = append(declarations, declaration{name: enum.Name})
+		}
+	}
+	sort.Slice(declarations, func(i, j int) bool {
+		return declarations[i].name < declarations[j].name
+	})
+
+	var typeNames, enumNames []string
+	var body strings.Builder
+	for _, d := range declarations {
+		if d.model == nil {
+			typeName := cleanRef("#/" + d.name)
+			enumNames = append(enumNames, typeName)
+			body.WriteString(e.Declare(typeName, e.Enum(typeName)))
+			continue
+		}
+
+		typeName := d.model.TypeName
+		typeNames = append(typeNames, typeName)
+		var expr string
+		if alias := d.model.Alias; alias != nil {
+			if alias.Kind == ir.Unknown {
+				expr = e.Unknown()
+			} else {
+				expr = validatorType(e, *alias)
+			}
+		} else {
+			expr = validatorObject(e, d.model.Fields)
+			for _, base := range d.model.Extends {
+				expr = e.Extend(interfaceName(base), expr)
+			}
+		}
+		body.WriteString(e.Declare(typeName, expr))
+	}
+
+	// 请求类型的字段按名称排序，与其他模型一致
+	for _, d := range requests {
+		typeNames = append(typeNames, d.name)
+		body.WriteString(e.Declare(d.name, validatorObject(e, d.model.Fields)))
+	}
+
+	sort.Strings(typeNames)
+	return e.Header(typeNames, enumNames) + body.String()
+}
+
+// validatorObject 生成对象校验，字段按名称排序
+func validatorObject(e validatorEmitter, fields []ir.Field) string {
+	sorted := append([]ir.Field(nil), fields...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	var result []validatorField
+	for _, field := range sorted {
+		f := validatorField{
+			Key:      objectKey(field.Name),
+			Expr:     validatorType(e, field.Type),
+			Optional: !field.Required,
+		}
+		if field.Type.TSType == "" && field.Type.Ref != "" {
+			f.Ref = tsType(field.Type)
+		}
+		result = append(result, f)
+	}
+	return e.Object(result)
+}
+
+// validatorType 将类型转换为校验表达式，规则与 tsType 保持一致
+func validatorType(e validatorEmitter, t ir.Type) string {
+	switch {
+	case t.TSType != "":
+		// x-moonbeam-type 或 -type-mapping 指定的类型不一定与文档中的类型一致，不校验
+		return e.Primitive(ir.Type{Kind: ir.Any})
+	case t.Ref != "":
+		return e.Ref(tsType(t))
+	case t.Kind == ir.Tuple:
+		var parts []string
+		for _, element := range t.Elements {
+			parts = append(parts, validatorElement(e, element))
+		}
+		rest := ""
+		if t.Rest != nil {
+			rest = validatorElement(e, *t.Rest)
+		}
+		return e.Tuple(parts, rest)
+	case t.Kind == ir.Array:
+		element := e.Primitive(ir.Type{})
+		if t.Items != nil {
+			element = validatorElement(e, *t.Items)
+		}
+		return e.Array(element, t)
+	case t.Kind == ir.Map:
+		return e.Record()
+	case t.Kind == ir.EnumRef:
+		var values []string
+		for _, value := range t.Values {
+			values = append(values, strconv.Quote(value))
+		}
+		if len(values) > 0 {
+			return e.Literals(values)
+		}
+		return e.Primitive(ir.Type{Kind: ir.String})
+	}
+	return e.Primitive(t)
+}
+
+// validatorElement 将数组元素或元组元素转换为校验表达式
+func validatorElement(e validatorEmitter, t ir.Type) string {
+	if t.TSType != "" {
+		return e.Primitive(ir.Type{Kind: ir.Any})
+	}
+	if t.Ref != "" {
+		return e.Ref(interfaceName(t.Ref))
+	}
+	return e.Primitive(ir.Type{Kind: t.Kind})
+}
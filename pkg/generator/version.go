@@ -0,0 +1,89 @@
+// version.go
+package generator
+
+import (
+	"runtime/debug"
+	"strings"
+)
+
+// modulePath moonbeam 的模块路径，作为依赖使用时从构建信息中按它查找版本
+const modulePath = "github.com/aide-family/moonbeam"
+
+// 版本信息，发布时用 -ldflags 注入，例如
+// go build -ldflags "-X github.com/aide-family/moonbeam/pkg/generator.Version=v0.1.0 -X github.com/aide-family/moonbeam/pkg/generator.Commit=$(git rev-parse HEAD)"；
+// 没有注入的字段由 init 从 runtime/debug.ReadBuildInfo 读取
+var (
+	// Version 版本：go install 安装时为模块版本，在仓库中构建时为 Go 根据提交生成的伪版本，都没有时为 devel
+	Version string
+	// Commit 构建所在的提交（vcs.revision），工作区有未提交的修改时带 -dirty 后缀；作为依赖使用时为空
+	Commit string
+	// Date 提交的时间（vcs.time），RFC 3339 格式；作为依赖使用时为空
+	Date string
+)
+
+func init() {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		if Version == "" {
+			Version = "devel"
+		}
+		return
+	}
+	module := &info.Main
+	if module.Path != modulePath {
+		// 作为库使用时主模块是调用方，版本取自依赖列表，提交信息属于调用方，不使用
+		module = nil
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				module = dep
+				break
+			}
+		}
+	}
+	if Version == "" && module != nil && module.Version != "" && module.Version != "(devel)" {
+		Version = module.Version
+	}
+	if Version == "" {
+		Version = "devel"
+	}
+	if module != &info.Main {
+		return
+	}
+	var revision, time string
+	modified := false
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.time":
+			time = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if Commit == "" && revision != "" {
+		Commit = revision
+		if modified {
+			Commit += "-dirty"
+		}
+	}
+	if Date == "" {
+		Date = time
+	}
+}
+
+// VersionInfo 版本和提交的简短形式，例如 v0.1.0 (3f2a9c1d8e4b)，写入生成文件的头部注释、清单和快照；
+// 没有提交信息或版本是已经包含提交的伪版本（v0.0.0-20240501100000-3f2a9c1d8e4b）时只有版本
+func VersionInfo() string {
+	commit, dirty := strings.CutSuffix(Commit, "-dirty")
+	if len(commit) > 12 {
+		commit = commit[:12]
+	}
+	if commit == "" || strings.Contains(Version, commit) {
+		return Version
+	}
+	if dirty {
+		commit += "-dirty"
+	}
+	return Version + " (" + commit + ")"
+}
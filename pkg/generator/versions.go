@@ -0,0 +1,113 @@
+// versions.go
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// apiVersionModes 支持的方式：mixed 不区分，各版本的接口在同一模块中，重名时按路径改名；
+// suffix 函数名加上版本后缀，例如 listUsersV2；split 每个版本一个目录，例如 v2/user/index.ts
+var apiVersionModes = []string{"mixed", "suffix", "split"}
+
+// validateAPIVersions 校验 -api-versions 及其组合
+func validateAPIVersions(o Options) error {
+	if !contains(apiVersionModes, o.APIVersions) {
+		return fmt.Errorf("unsupported -api-versions %q, expected one of %s", o.APIVersions, strings.Join(apiVersionModes, ", "))
+	}
+	if o.APIVersions != "split" {
+		return nil
+	}
+	// 这些选项同样决定模块文件的位置
+	for _, option := range []struct {
+		name string
+		set  bool
+	}{
+		{"-single-file", o.SingleFile != ""}, {"-workspace", o.Workspace}, {"-layout split", o.Layout == "split"},
+	} {
+		if option.set {
+			return fmt.Errorf("%s is not supported with -api-versions split", option.name)
+		}
+	}
+	return nil
+}
+
+// pathVersion 路径开头的版本号，可以在 api 之后，例如 /v2/users 和 /api/v2/users 为 v2；没有版本号时返回空字符串
+func pathVersion(p string) string {
+	for _, segment := range strings.Split(p, "/") {
+		switch {
+		case segment == "" || segment == "api":
+		case versionSegment.MatchString(segment):
+			return segment
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// versionedName -api-versions suffix 时在操作名称后加上路径的版本号，例如 ListUsers -> ListUsersV2
+func (r *run) versionedName(p, base string) string {
+	if r.apiVersions != "suffix" {
+		return base
+	}
+	return withVersion(p, base)
+}
+
+// splitVersionName -api-versions split 时查询参数请求类型的名称加上版本号，例如 GetUserV2Request；
+// 函数名在各版本的目录中可以相同，请求类型都在 types/index.ts 中，不能重名
+func (r *run) splitVersionName(p, base string) string {
+	if r.apiVersions != "split" {
+		return base
+	}
+	return withVersion(p, base)
+}
+
+// withVersion 在名称后加上路径的版本号，路径没有版本号或名称已有该后缀时不变
+func withVersion(p, base string) string {
+	version := pathVersion(p)
+	if version == "" {
+		return base
+	}
+	suffix := pascalCase(version)
+	if strings.HasSuffix(base, suffix) {
+		return base
+	}
+	return base + suffix
+}
+
+// versionedModule -api-versions split 时带版本号的接口所在的模块，例如 v2-user；生成结束后由 versionFiles 移到 v2/user
+func (r *run) versionedModule(p, module string) string {
+	if version := pathVersion(p); r.apiVersions == "split" && version != "" {
+		return version + "-" + module
+	}
+	return module
+}
+
+// versionFiles -api-versions split 时把 <版本>-<模块>/ 中的文件移到 <版本>/<模块>/，所有相对导入按新的位置改写
+func (r *run) versionFiles(files Files, api *ir.API) Files {
+	dirs := make(map[string]string) // 模块 -> 新的目录
+	for _, op := range api.Operations {
+		if version := pathVersion(op.Path); version != "" && strings.HasPrefix(op.Module, version+"-") {
+			dirs[op.Module] = version + "/" + strings.TrimPrefix(op.Module, version+"-")
+		}
+	}
+	if len(dirs) == 0 {
+		return files
+	}
+	for _, op := range api.Operations {
+		if _, ok := dirs[op.Module]; !ok && versionSegment.MatchString(op.Module) {
+			r.logger.Error("module clashes with a version directory of -api-versions split, rename it with x-moonbeam-module or -group-by", "module", op.Module)
+			return files
+		}
+	}
+	moved := make(map[string]string)
+	for name := range files {
+		if module, rest, found := strings.Cut(name, "/"); found && dirs[module] != "" {
+			moved[name] = dirs[module] + "/" + rest
+		}
+	}
+	return moveFiles(files, moved)
+}
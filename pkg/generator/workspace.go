@@ -0,0 +1,154 @@
+// workspace.go
+package generator
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// workspaceCommon 公共包的目录和名称（不含 scope），包含类型、枚举、运行时和校验器等模块之外的文件
+const workspaceCommon = "api-common"
+
+// workspaceJSON workspace 根目录的 package.json
+type workspaceJSON struct {
+	Name            string            `json:"name"`
+	Version         string            `json:"version"`
+	Private         bool              `json:"private"`
+	Workspaces      []string          `json:"workspaces"`
+	Scripts         map[string]string `json:"scripts"`
+	DevDependencies map[string]string `json:"devDependencies"`
+}
+
+// validateWorkspace 校验 -workspace 及其组合
+func validateWorkspace(o Options) error {
+	if !o.Workspace {
+		return nil
+	}
+	if o.PackageName == "" {
+		return fmt.Errorf("-workspace requires -package-name, the name of the workspace root")
+	}
+	if o.SingleFile != "" {
+		return fmt.Errorf("-workspace is not supported with -single-file")
+	}
+	// 契约测试和 types/index.ts 都会导入各模块，公共包不能依赖模块包
+	if o.ContractTests != "" {
+		return fmt.Errorf("-contract-tests is not supported with -workspace")
+	}
+	if o.UnitTests != "" {
+		return fmt.Errorf("-unit-tests is not supported with -workspace")
+	}
+	if o.GroupTypes {
+		return fmt.Errorf("-group-types is not supported with -workspace")
+	}
+	if o.GroupEnums {
+		return fmt.Errorf("-group-enums is not supported with -workspace")
+	}
+	return nil
+}
+
+// workspaceFiles 把生成的文件拆分为 packages/<模块>-api 和 packages/api-common 两类包：模块目录成为各自的包，
+// 其余文件成为公共包；包之间的相对导入改为包名（例如 ../types/index.ts 改为 @acme/api-common/types），
+// 每个包有自己的 package.json 和 tsconfig.json，根目录的 package.json 按依赖顺序列出所有包
+func (r *run) workspaceFiles(files Files, api *ir.API, info packageInfo) (Files, error) {
+	modules := make(map[string]bool)
+	for _, op := range api.Operations {
+		modules[op.Module] = true
+	}
+	// packageOf 返回文件所在的包目录及其在包内的路径
+	packageOf := func(name string) (string, string) {
+		if top, rest, found := strings.Cut(name, "/"); found && modules[top] {
+			return top + "-api", rest
+		}
+		return workspaceCommon, name
+	}
+
+	packages := make(map[string]Files)
+	requires := make(map[string]map[string]string)
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		dir, rel := packageOf(name)
+		if packages[dir] == nil {
+			packages[dir] = make(Files)
+			requires[dir] = make(map[string]string)
+		}
+		var err error
+		data := rewriteImports(files[name], func(imp moduleImport) string {
+			if !isRelativeSpecifier(imp.Specifier) {
+				return imp.Specifier
+			}
+			target, targetRel := packageOf(path.Join(path.Dir(name), imp.Specifier))
+			if target == dir {
+				return imp.Specifier
+			}
+			if dir == workspaceCommon && err == nil {
+				err = fmt.Errorf("%s imports %s from module package %s", name, imp.Specifier, target)
+			}
+			targetName := r.workspacePackageName(target)
+			requires[dir][targetName] = info.Version
+			return targetName + strings.TrimPrefix(r.packageSubpath(targetRel), ".")
+		})
+		if err != nil {
+			return nil, err
+		}
+		packages[dir][rel] = data
+	}
+
+	// 公共包最先构建，模块包依赖它
+	dirs := []string{workspaceCommon}
+	for dir := range packages {
+		if dir != workspaceCommon {
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs[1:])
+	result := make(Files)
+	var workspaces []string
+	for _, dir := range dirs {
+		pkg := packages[dir]
+		if pkg == nil {
+			continue
+		}
+		pkgInfo := info
+		if module := strings.TrimSuffix(dir, "-api"); dir != workspaceCommon && info.Title != "" {
+			pkgInfo.Title = info.Title + ": " + module
+		}
+		if err := r.addPackage(pkg, r.workspacePackageName(dir), pkgInfo, requires[dir]); err != nil {
+			return nil, err
+		}
+		for name, data := range pkg {
+			result["packages/"+dir+"/"+name] = data
+		}
+		workspaces = append(workspaces, "packages/"+dir)
+	}
+	root := workspaceJSON{
+		Name:       r.packageName,
+		Version:    info.Version,
+		Private:    true,
+		Workspaces: workspaces,
+		Scripts: map[string]string{
+			"build":   "npm run build --workspaces",
+			"release": "npm publish --workspaces",
+		},
+		DevDependencies: map[string]string{"typescript": typescriptVersion},
+	}
+	if err := addJSONFiles(result, map[string]interface{}{"package.json": root}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// workspacePackageName workspace 中包的名称，使用 -package-name 的 scope，例如 @acme/user-api
+func (r *run) workspacePackageName(dir string) string {
+	if scope, _, found := strings.Cut(r.packageName, "/"); found {
+		return scope + "/" + dir
+	}
+	return dir
+}
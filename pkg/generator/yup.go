@@ -0,0 +1,225 @@
+// yup.go
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// yupEmitter 生成请求类型的 Yup 表单校验 schema（XxxForm），见 newYupEmitter
+type yupEmitter struct {
+	objects   map[string]bool // 生成为 yup.object 的表单，可选字段引用时去掉默认值，否则 undefined 会被转换为空对象再校验
+	lazy      map[string]bool // 生成为 yup.lazy 的表单（继承和引用别名），没有 required 等方法
+	recursive map[string]bool // 直接或间接引用自身的表单，需要标注类型，否则 TypeScript 推断为 any（TS7022）
+}
+
+// newYupEmitter 按 renderValidators 生成的表单（include 中的模型和查询参数请求类型）记录各表单的形式，键为类型名称
+func newYupEmitter(api *ir.API, include map[string]bool) yupEmitter {
+	e := yupEmitter{objects: make(map[string]bool), lazy: make(map[string]bool), recursive: make(map[string]bool)}
+	refs := make(map[string][]string)
+	typeNames := make(map[string]string)
+	for _, model := range api.Models {
+		if !model.Synthetic && include != nil && !include[model.Name] {
+			continue
+		}
+		refs[model.Name] = modelRefs(model)
+		typeNames[model.Name] = model.TypeName
+		switch alias := model.Alias; {
+		case alias != nil:
+			if alias.Kind != ir.Unknown && alias.TSType == "" && alias.Ref != "" {
+				e.lazy[model.TypeName] = true
+			}
+		case len(model.Extends) > 0:
+			e.lazy[model.TypeName] = true
+		default:
+			e.objects[model.TypeName] = true
+		}
+	}
+	for name := range refs {
+		seen := make(map[string]bool)
+		var reaches func(from string) bool
+		reaches = func(from string) bool {
+			for _, ref := range refs[from] {
+				if ref == name {
+					return true
+				}
+				if !seen[ref] {
+					seen[ref] = true
+					if reaches(ref) {
+						return true
+					}
+				}
+			}
+			return false
+		}
+		if reaches(name) {
+			e.recursive[typeNames[name]] = true
+		}
+	}
+	return e
+}
+
+func (e yupEmitter) Header(typeNames, enumNames []string) string {
+	var b strings.Builder
+	b.WriteString("// Yup 表单校验 schema\n")
+	b.WriteString("import * as yup from 'yup'\n")
+	var annotated []string
+	for _, name := range typeNames {
+		if e.recursive[name] {
+			annotated = append(annotated, name)
+		}
+	}
+	if len(annotated) > 0 {
+		b.WriteString("import type {\n  " + strings.Join(annotated, ",\n  ") + "\n} from './index.ts'\n")
+	}
+	if len(enumNames) > 0 {
+		b.WriteString("import {\n  " + strings.Join(enumNames, ",\n  ") + "\n} from './enum.ts'\n")
+	}
+	return b.String()
+}
+
+// Declare 递归的表单标注类型：对象为 yup.ObjectSchema，yup.lazy 和数组等其他形式为 yup.ISchema
+func (e yupEmitter) Declare(typeName, expr string) string {
+	var annotation string
+	switch {
+	case e.recursive[typeName] && e.objects[typeName]:
+		annotation = ": yup.ObjectSchema<" + typeName + ">"
+	case e.recursive[typeName]:
+		annotation = ": yup.ISchema<" + typeName + " | undefined>"
+	}
+	return fmt.Sprintf("\n/**\n * %sForm 表单校验 %s\n */\nexport const %sForm%s = %s\n", typeName, typeName, typeName, annotation, expr)
+}
+
+func (yupEmitter) Enum(typeName string) string {
+	return fmt.Sprintf("yup.mixed<%s>().oneOf(Object.values(%s))", typeName, typeName)
+}
+
+func (yupEmitter) Unknown() string {
+	return "yup.mixed()"
+}
+
+// Object 引用其他表单的字段把 required 等约束放在 yup.lazy 之内；可选的对象字段去掉默认值，undefined 不会变成空对象
+func (e yupEmitter) Object(fields []validatorField) string {
+	if len(fields) == 0 {
+		return "yup.object({})"
+	}
+	var lines []string
+	for _, field := range fields {
+		expr := field.Expr
+		switch {
+		case field.Ref == "":
+			if !field.Optional {
+				expr += ".required()"
+			}
+		case e.lazy[field.Ref]:
+			// 引用的表单本身是 yup.lazy，没有可以追加的约束
+		case !field.Optional:
+			expr = fmt.Sprintf("yup.lazy(() => %sForm.required())", field.Ref)
+		case e.objects[field.Ref]:
+			expr = fmt.Sprintf("yup.lazy(() => %sForm.default(undefined).optional())", field.Ref)
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s", field.Key, expr))
+	}
+	return "yup.object({\n" + strings.Join(lines, ",\n") + "\n})"
+}
+
+// Extend 基类可能声明在后面，使用 yup.lazy 延迟合并
+func (yupEmitter) Extend(base, expr string) string {
+	return fmt.Sprintf("yup.lazy(() => %sForm.concat(%s))", base, expr)
+}
+
+// Ref 使用 yup.lazy 引用，避免声明顺序和递归问题
+func (yupEmitter) Ref(typeName string) string {
+	return fmt.Sprintf("yup.lazy(() => %sForm)", typeName)
+}
+
+func (yupEmitter) Tuple(elements []string, rest string) string {
+	if rest != "" {
+		return "yup.array()"
+	}
+	return "yup.tuple([" + strings.Join(elements, ", ") + "])"
+}
+
+func (yupEmitter) Array(element string, t ir.Type) string {
+	c := typeConstraints(t)
+	expr := "yup.array().of(" + element + ")"
+	if c.MinItems != nil {
+		expr += fmt.Sprintf(".min(%d)", *c.MinItems)
+	}
+	if c.MaxItems != nil {
+		expr += fmt.Sprintf(".max(%d)", *c.MaxItems)
+	}
+	return expr
+}
+
+func (yupEmitter) Record() string {
+	return "yup.object()"
+}
+
+func (yupEmitter) Literals(values []string) string {
+	return "yup.string().oneOf([" + strings.Join(values, ", ") + "])"
+}
+
+func (yupEmitter) Primitive(t ir.Type) string {
+	switch t.Kind {
+	case "string":
+		return "yup.string()" + yupStringChecks(t)
+	case "integer":
+		return "yup.number().integer()" + yupNumberChecks(t)
+	case "number":
+		return "yup.number()" + yupNumberChecks(t)
+	case "boolean":
+		return "yup.boolean()"
+	case "object":
+		return "yup.object()"
+	default:
+		return "yup.mixed()"
+	}
+}
+
+// yupStringChecks 字符串格式、长度和正则约束
+func yupStringChecks(t ir.Type) string {
+	c := typeConstraints(t)
+	var checks string
+	switch t.Format {
+	case "email":
+		checks += ".email()"
+	case "uuid":
+		checks += ".uuid()"
+	case "uri", "url":
+		checks += ".url()"
+	}
+	if c.MinLength != nil {
+		checks += fmt.Sprintf(".min(%d)", *c.MinLength)
+	}
+	if c.MaxLength != nil {
+		checks += fmt.Sprintf(".max(%d)", *c.MaxLength)
+	}
+	if c.Pattern != "" {
+		checks += fmt.Sprintf(".matches(new RegExp(%q))", c.Pattern)
+	}
+	return checks
+}
+
+// yupNumberChecks 数值范围约束
+func yupNumberChecks(t ir.Type) string {
+	c := typeConstraints(t)
+	var checks string
+	if c.Minimum != nil {
+		if c.ExclusiveMinimum {
+			checks += ".moreThan(" + formatNumber(*c.Minimum) + ")"
+		} else {
+			checks += ".min(" + formatNumber(*c.Minimum) + ")"
+		}
+	}
+	if c.Maximum != nil {
+		if c.ExclusiveMaximum {
+			checks += ".lessThan(" + formatNumber(*c.Maximum) + ")"
+		} else {
+			checks += ".max(" + formatNumber(*c.Maximum) + ")"
+		}
+	}
+	return checks
+}
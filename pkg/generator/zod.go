@@ -0,0 +1,160 @@
+// zod.go
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aide-family/moonbeam/pkg/generator/ir"
+)
+
+// zodEmitter 生成 Zod schema（XxxSchema）及 parseXxx 辅助函数
+type zodEmitter struct{}
+
+func (zodEmitter) Header(typeNames, enumNames []string) string {
+	var b strings.Builder
+	b.WriteString("// Zod 运行时校验 schema\n")
+	b.WriteString("import { z } from 'zod'\n")
+	if len(typeNames) > 0 {
+		b.WriteString("import type {\n  " + strings.Join(typeNames, ",\n  ") + "\n} from './index.ts'\n")
+	}
+	if len(enumNames) > 0 {
+		b.WriteString("import {\n  " + strings.Join(enumNames, ",\n  ") + "\n} from './enum.ts'\n")
+	}
+	return b.String()
+}
+
+// Declare 使用 z.lazy 支持递归和任意声明顺序
+func (zodEmitter) Declare(typeName, expr string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n/**\n * %sSchema 校验 %s\n */\n", typeName, typeName)
+	fmt.Fprintf(&b, "export const %sSchema: z.ZodType<%s> = z.lazy(() =>\n  %s\n)\n", typeName, typeName, expr)
+	fmt.Fprintf(&b, "\n/**\n * parse%s 校验数据并返回 %s，校验失败时抛出 ZodError\n */\n", typeName, typeName)
+	fmt.Fprintf(&b, "export function parse%s(data: unknown): %s {\n  return %sSchema.parse(data)\n}\n", typeName, typeName, typeName)
+	return b.String()
+}
+
+func (zodEmitter) Enum(typeName string) string {
+	return fmt.Sprintf("z.nativeEnum(%s)", typeName)
+}
+
+func (zodEmitter) Unknown() string {
+	return "z.unknown()"
+}
+
+func (zodEmitter) Object(fields []validatorField) string {
+	if len(fields) == 0 {
+		return "z.object({})"
+	}
+	var lines []string
+	for _, field := range fields {
+		expr := field.Expr
+		if field.Optional {
+			expr += ".optional()"
+		}
+		lines = append(lines, fmt.Sprintf("    %s: %s", field.Key, expr))
+	}
+	return "z.object({\n" + strings.Join(lines, ",\n") + "\n  })"
+}
+
+func (zodEmitter) Extend(base, expr string) string {
+	return fmt.Sprintf("%sSchema.and(%s)", base, expr)
+}
+
+func (zodEmitter) Ref(typeName string) string {
+	return typeName + "Schema"
+}
+
+func (zodEmitter) Tuple(elements []string, rest string) string {
+	expr := "z.tuple([" + strings.Join(elements, ", ") + "])"
+	if rest != "" {
+		expr += ".rest(" + rest + ")"
+	}
+	return expr
+}
+
+func (zodEmitter) Array(element string, t ir.Type) string {
+	c := typeConstraints(t)
+	expr := "z.array(" + element + ")"
+	if c.MinItems != nil {
+		expr += fmt.Sprintf(".min(%d)", *c.MinItems)
+	}
+	if c.MaxItems != nil {
+		expr += fmt.Sprintf(".max(%d)", *c.MaxItems)
+	}
+	return expr
+}
+
+func (zodEmitter) Record() string {
+	return "z.record(z.string())"
+}
+
+func (zodEmitter) Literals(values []string) string {
+	return "z.enum([" + strings.Join(values, ", ") + "])"
+}
+
+func (zodEmitter) Primitive(t ir.Type) string {
+	switch t.Kind {
+	case "string":
+		return "z.string()" + zodStringChecks(t)
+	case "integer":
+		return "z.number().int()" + zodNumberChecks(t)
+	case "number":
+		return "z.number()" + zodNumberChecks(t)
+	case "boolean":
+		return "z.boolean()"
+	case "object":
+		return "z.object({}).passthrough()"
+	default:
+		return "z.any()"
+	}
+}
+
+// zodStringChecks 字符串格式和长度约束
+func zodStringChecks(t ir.Type) string {
+	c := typeConstraints(t)
+	var checks string
+	switch t.Format {
+	case "email":
+		checks += ".email()"
+	case "uuid":
+		checks += ".uuid()"
+	case "uri", "url":
+		checks += ".url()"
+	}
+	if c.MinLength != nil {
+		checks += fmt.Sprintf(".min(%d)", *c.MinLength)
+	}
+	if c.MaxLength != nil {
+		checks += fmt.Sprintf(".max(%d)", *c.MaxLength)
+	}
+	if c.Pattern != "" {
+		checks += ".regex(new RegExp(" + strconv.Quote(c.Pattern) + "))"
+	}
+	return checks
+}
+
+// zodNumberChecks 数值范围约束
+func zodNumberChecks(t ir.Type) string {
+	c := typeConstraints(t)
+	var checks string
+	if c.Minimum != nil {
+		if c.ExclusiveMinimum {
+			checks += ".gt(" + formatNumber(*c.Minimum) + ")"
+		} else {
+			checks += ".gte(" + formatNumber(*c.Minimum) + ")"
+		}
+	}
+	if c.Maximum != nil {
+		if c.ExclusiveMaximum {
+			checks += ".lt(" + formatNumber(*c.Maximum) + ")"
+		} else {
+			checks += ".lte(" + formatNumber(*c.Maximum) + ")"
+		}
+	}
+	if c.MultipleOf != nil {
+		checks += ".multipleOf(" + formatNumber(*c.MultipleOf) + ")"
+	}
+	return checks
+}
@@ -0,0 +1,63 @@
+// postcmd.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// runPostCmd 在文件写入后通过 shell 执行 -post-cmd，例如格式化生成的代码
+// {out} 替换为输出目录，{files} 替换为本次写入的文件；没有文件写入时跳过
+func runPostCmd(command, out string, files []string) error {
+	if len(files) == 0 {
+		logger.Debug("post command skipped, no files written")
+		return nil
+	}
+
+	quoted := make([]string, len(files))
+	for i, file := range files {
+		quoted[i] = shellQuote(file)
+	}
+	command = strings.NewReplacer(
+		"{out}", shellQuote(out),
+		"{files}", strings.Join(quoted, " "),
+	).Replace(command)
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	// 标准输出保留给 -diff 等数据输出，命令输出写到标准错误
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+
+	start := time.Now()
+	logger.Info("running post command", "cmd", command)
+	if err := cmd.Run(); err != nil {
+		err = fmt.Errorf("post command %q: %w", command, err)
+		logger.Error("post command failed", "err", err)
+		return err
+	}
+	logger.Debug("post command finished", "duration", time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// shellQuote 为 shell 参数加引号，仅包含安全字符时保持原样
+func shellQuote(s string) string {
+	safe := s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=@+", r))
+	}) < 0
+	if safe {
+		return s
+	}
+	if runtime.GOOS == "windows" {
+		return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,88 @@
+// profile.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+)
+
+var (
+	// cpuProfile -cpuprofile 写入 CPU profile 的文件
+	cpuProfile string
+	// memProfile -memprofile 退出时写入堆内存 profile 的文件
+	memProfile string
+)
+
+// stopProfiling 结束 profiling 并写入文件，未开启时不做任何事；exit 和正常返回前都会调用
+var stopProfiling = func() {}
+
+// startProfiling 按 -cpuprofile / -memprofile 开启 profiling，结果用 go tool pprof 查看；
+// -watch 等不会自行退出的模式下按 Ctrl-C 结束时同样写入文件
+func startProfiling() error {
+	if cpuProfile == "" && memProfile == "" {
+		return nil
+	}
+	var cpu *os.File
+	if cpuProfile != "" {
+		f, err := os.Create(cpuProfile)
+		if err != nil {
+			return err
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return err
+		}
+		cpu = f
+	}
+	var once sync.Once
+	stopProfiling = func() { once.Do(func() { writeProfiles(cpu) }) }
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		exit(130)
+	}()
+	return nil
+}
+
+// writeProfiles 结束 CPU profile，并写入堆内存 profile
+func writeProfiles(cpu *os.File) {
+	if cpu != nil {
+		pprof.StopCPUProfile()
+		cpu.Close()
+		logger.Debug("cpu profile written", "file", cpuProfile)
+	}
+	if memProfile == "" {
+		return
+	}
+	if err := writeMemProfile(memProfile); err != nil {
+		logger.Error("write memory profile failed", "file", memProfile, "err", err)
+		return
+	}
+	logger.Debug("memory profile written", "file", memProfile)
+}
+
+// writeMemProfile 写入堆内存 profile；先执行一次 GC，统计的是仍在使用的内存，分配总量见 -sample_index=alloc_space
+func writeMemProfile(filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	runtime.GC()
+	if err := pprof.Lookup("heap").WriteTo(f, 0); err != nil {
+		f.Close()
+		return fmt.Errorf("write heap profile: %w", err)
+	}
+	return f.Close()
+}
+
+// exit 写入 profile 后退出，main 包中代替 os.Exit
+func exit(code int) {
+	stopProfiling()
+	os.Exit(code)
+}
@@ -0,0 +1,163 @@
+// progress.go
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aide-family/moonbeam/pkg/generator"
+)
+
+// noProgress -no-progress 不报告生成进度
+var noProgress bool
+
+const (
+	progressDelay    = time.Second            // 生成超过这个时间才开始报告，小型文档不输出进度
+	progressRedraw   = 100 * time.Millisecond // 终端上进度条的刷新间隔
+	progressInterval = 5 * time.Second        // 非终端（CI 日志、重定向到文件）时输出进度日志的间隔
+	progressBarWidth = 30
+)
+
+// stderr 日志和进度条共用的标准错误输出：写入日志前先擦除进度条，日志不会和进度条混在一行
+var stderr = &statusWriter{w: os.Stderr}
+
+// statusWriter 在最后一行显示可以原地更新的状态行
+type statusWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	status bool // 当前显示着状态行
+}
+
+func (s *statusWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clearLocked()
+	return s.w.Write(p)
+}
+
+// show 用 line 替换当前的状态行
+func (s *statusWriter) show(line string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Fprintf(s.w, "\r\033[K%s", line)
+	s.status = true
+}
+
+// clear 擦除状态行
+func (s *statusWriter) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clearLocked()
+}
+
+func (s *statusWriter) clearLocked() {
+	if s.status {
+		io.WriteString(s.w, "\r\033[K")
+		s.status = false
+	}
+}
+
+// isTerminal 判断 f 是否为终端
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// progressReporter 报告一次生成的进度：终端上显示进度条，否则定期输出一条日志
+type progressReporter struct {
+	mu       sync.Mutex
+	current  generator.Progress
+	reported generator.Progress // 上一次输出日志时的进度，没有变化时不重复输出
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// startProgressReporter 开始报告进度，返回的 update 传给 Options.Progress，生成结束后调用 stop；
+// -quiet、-no-progress 和 -log-format json 时不报告
+func startProgressReporter() (update func(generator.Progress), stop func()) {
+	if quiet || noProgress || logFormat == "json" {
+		return nil, func() {}
+	}
+	p := &progressReporter{stop: make(chan struct{})}
+	terminal := stderrStyle.terminal
+	p.wg.Add(1)
+	go p.run(terminal)
+	return p.update, func() {
+		close(p.stop)
+		p.wg.Wait()
+		if terminal {
+			stderr.clear()
+		}
+	}
+}
+
+func (p *progressReporter) update(progress generator.Progress) {
+	p.mu.Lock()
+	p.current = progress
+	p.mu.Unlock()
+}
+
+func (p *progressReporter) run(terminal bool) {
+	defer p.wg.Done()
+	select {
+	case <-p.stop:
+		return
+	case <-time.After(progressDelay):
+	}
+	interval := progressInterval
+	if terminal {
+		interval = progressRedraw
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		p.mu.Lock()
+		current := p.current
+		p.mu.Unlock()
+		switch {
+		case terminal:
+			stderr.show(progressBar(current))
+		case current != p.reported && current.Stage != "":
+			p.reported = current
+			logger.Info(progressText(current))
+		}
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// progressText 进度的文字描述，例如 processed 1200/3000 operations
+func progressText(progress generator.Progress) string {
+	switch progress.Stage {
+	case "", generator.StageParse:
+		return "parsing spec"
+	case generator.StageFinish:
+		return "checking and assembling output"
+	}
+	return fmt.Sprintf("processed %d/%d %s", progress.Done, progress.Total, progress.Stage)
+}
+
+// progressBar 终端上的进度条，例如 operations [=========>          ] 1200/3000 40%；没有数量的阶段只显示文字
+func progressBar(progress generator.Progress) string {
+	switch progress.Stage {
+	case "", generator.StageParse, generator.StageFinish:
+		return "⏳ " + progressText(progress) + "..."
+	}
+	if progress.Total == 0 {
+		return "⏳ " + progress.Stage + "..."
+	}
+	filled := progressBarWidth * progress.Done / progress.Total
+	bar := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		bar += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+	return fmt.Sprintf("⏳ %s [%s] %d/%d %d%%", stderrStyle.paint(colorCyan, progress.Stage), stderrStyle.paint(colorGreen, bar),
+		progress.Done, progress.Total, 100*progress.Done/progress.Total)
+}
@@ -0,0 +1,127 @@
+// remote.go
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// isURL 判断文档路径是否为 http(s) 地址
+func isURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+// readSpec 读取本地文件或下载远程文档
+func readSpec(spec string) ([]byte, error) {
+	if !isURL(spec) {
+		return os.ReadFile(spec)
+	}
+	return fetchSpec(spec)
+}
+
+// fetchSpec 下载远程文档，携带 -header 指定的请求头，自动跟随重定向（最多 10 次）
+// 跨域名重定向时 Go 会丢弃 Authorization 等敏感请求头；
+// 有缓存时用 If-None-Match / If-Modified-Since 验证，未修改（304）时使用缓存，网络错误或服务端错误时也退回到缓存
+func fetchSpec(rawURL string) ([]byte, error) {
+	client, err := newSpecClient()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/yaml, application/json;q=0.9, */*;q=0.8")
+	for _, header := range headers {
+		name, value, ok := strings.Cut(header, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid header %q, expected 'Name: value'", header)
+		}
+		req.Header.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	cache := loadSpecCache(rawURL)
+	cached := cache != nil && cache.data != nil
+	if cached {
+		if cache.ETag != "" {
+			req.Header.Set("If-None-Match", cache.ETag)
+		}
+		if cache.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cache.LastModified)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if cached {
+			logger.Warn("fetch failed, using the cached spec", "url", rawURL, "fetched", cache.Fetched.Format(time.RFC3339), "err", err)
+			return cache.data, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached:
+		logger.Debug("spec not modified, using the cache", "url", rawURL)
+		return cache.data, nil
+	case resp.StatusCode >= 500 && cached:
+		logger.Warn("fetch failed, using the cached spec", "url", rawURL, "fetched", cache.Fetched.Format(time.RFC3339), "status", resp.Status)
+		return cache.data, nil
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return nil, fmt.Errorf("fetch %s: %s", rawURL, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	// 没有 ETag 和 Last-Modified 时无法验证缓存，不保存
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if cache != nil && (etag != "" || lastModified != "") {
+		if err := cache.store(data, etag, lastModified); err != nil {
+			logger.Warn("cache spec failed", "url", rawURL, "err", err)
+		}
+	}
+	return data, nil
+}
+
+// newSpecClient 根据 -insecure、-ca-cert 创建 HTTP 客户端
+func newSpecClient() (*http.Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: insecure}
+	if caCert != "" {
+		pem, err := os.ReadFile(caCert)
+		if err != nil {
+			return nil, fmt.Errorf("read ca cert: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caCert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport, Timeout: 30 * time.Second}, nil
+}
+
+// urlSpecName 远程文档对应的名称，取路径最后一段，路径为空时使用主机名
+func urlSpecName(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	base := path.Base(u.Path)
+	if base == "/" || base == "." {
+		return u.Hostname()
+	}
+	return strings.TrimSuffix(base, path.Ext(base))
+}
@@ -0,0 +1,46 @@
+// report.go
+package main
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+
+	"github.com/aide-family/moonbeam/pkg/generator"
+)
+
+// reportEntry -unsupported-report 中的一条记录，spec 区分多个文档
+type reportEntry struct {
+	Spec string `json:"spec"`
+	generator.Unsupported
+}
+
+// unsupported 本次运行中所有文档不支持的部分，generateAll 结束后写入 -unsupported-report
+var unsupported []reportEntry
+
+// collectUnsupported 记录文档中不支持的部分，只在设置了 -unsupported-report 时调用；
+// 格式转换的日志在生成时已经输出过，这里不再输出
+func collectUnsupported(o generator.Options, specFile string, data []byte) error {
+	o.Logger = slog.New(slog.DiscardHandler)
+	features, err := generator.New(o).Unsupported(data)
+	if err != nil {
+		return err
+	}
+	for _, feature := range features {
+		unsupported = append(unsupported, reportEntry{Spec: specFile, Unsupported: feature})
+	}
+	return nil
+}
+
+// writeUnsupportedReport 将不支持的部分写入 JSON 文件，没有时写入空数组，便于 CI 检查
+func writeUnsupportedReport(filename string) error {
+	entries := unsupported
+	if entries == nil {
+		entries = []reportEntry{}
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(data, '\n'), 0644)
+}
@@ -0,0 +1,297 @@
+// serve.go
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/aide-family/moonbeam/pkg/generator"
+)
+
+// serveOptions 请求可以通过同名 query 参数覆盖的生成选项，返回选项字段的指针；
+// 会执行本地程序或读取本地文件的选项只能由服务端配置，见 serverOnlyOptions
+var serveOptions = map[string]func(o *generator.Options) interface{}{
+	"lang":               func(o *generator.Options) interface{} { return &o.Lang },
+	"go-package":         func(o *generator.Options) interface{} { return &o.GoPackage },
+	"package-name":       func(o *generator.Options) interface{} { return &o.PackageName },
+	"package-version":    func(o *generator.Options) interface{} { return &o.PackageVersion },
+	"workspace":          func(o *generator.Options) interface{} { return &o.Workspace },
+	"input-format":       func(o *generator.Options) interface{} { return &o.InputFormat },
+	"client":             func(o *generator.Options) interface{} { return &o.Client },
+	"hooks":              func(o *generator.Options) interface{} { return &o.Hooks },
+	"classes":            func(o *generator.Options) interface{} { return &o.Classes },
+	"validators":         func(o *generator.Options) interface{} { return &o.Validators },
+	"forms":              func(o *generator.Options) interface{} { return &o.Forms },
+	"json-schema":        func(o *generator.Options) interface{} { return &o.JSONSchema },
+	"mocks":              func(o *generator.Options) interface{} { return &o.Mocks },
+	"contract-tests":     func(o *generator.Options) interface{} { return &o.ContractTests },
+	"unit-tests":         func(o *generator.Options) interface{} { return &o.UnitTests },
+	"validate-responses": func(o *generator.Options) interface{} { return &o.ValidateResponses },
+	"pagination":         func(o *generator.Options) interface{} { return &o.Pagination },
+	"include-tags":       func(o *generator.Options) interface{} { return &o.IncludeTags },
+	"exclude-tags":       func(o *generator.Options) interface{} { return &o.ExcludeTags },
+	"include-paths":      func(o *generator.Options) interface{} { return &o.IncludePaths },
+	"exclude-paths":      func(o *generator.Options) interface{} { return &o.ExcludePaths },
+	"include-operations": func(o *generator.Options) interface{} { return &o.IncludeOperations },
+	"exclude-operations": func(o *generator.Options) interface{} { return &o.ExcludeOperations },
+	"operation-name":     func(o *generator.Options) interface{} { return &o.OperationName },
+	"group-by":           func(o *generator.Options) interface{} { return &o.GroupBy },
+	"group-types":        func(o *generator.Options) interface{} { return &o.GroupTypes },
+	"group-enums":        func(o *generator.Options) interface{} { return &o.GroupEnums },
+	"group-types-by":     func(o *generator.Options) interface{} { return &o.GroupTypesBy },
+	"layout":             func(o *generator.Options) interface{} { return &o.Layout },
+	"api-versions":       func(o *generator.Options) interface{} { return &o.APIVersions },
+	"provenance":         func(o *generator.Options) interface{} { return &o.Provenance },
+	"type-mapping":       func(o *generator.Options) interface{} { return &o.TypeMappings },
+	"import-alias":       func(o *generator.Options) interface{} { return &o.ImportAliases },
+	"strict":             func(o *generator.Options) interface{} { return &o.Strict },
+	"warnings-as-errors": func(o *generator.Options) interface{} { return &o.WarningsAsErrors },
+	"diagnostic":         func(o *generator.Options) interface{} { return &o.Diagnostics },
+	"function-case":      func(o *generator.Options) interface{} { return &o.Naming.FunctionCase },
+	"type-prefix":        func(o *generator.Options) interface{} { return &o.Naming.TypePrefix },
+	"type-suffix":        func(o *generator.Options) interface{} { return &o.Naming.TypeSuffix },
+	"dir-case":           func(o *generator.Options) interface{} { return &o.Naming.DirCase },
+	"enum-case":          func(o *generator.Options) interface{} { return &o.Naming.EnumCase },
+	"namespaces":         func(o *generator.Options) interface{} { return &o.Naming.Namespaces },
+	"single-file":        func(o *generator.Options) interface{} { return &o.SingleFile },
+	"import-style":       func(o *generator.Options) interface{} { return &o.ImportStyle },
+}
+
+// serverOnlyOptions 只能在服务端配置文件中设置的选项：插件和 -tsc 执行本地程序，-templates 和 -proto-path 读取本地文件，
+// -emit-js 和 -ext（.d.ts）会运行 tsc
+var serverOnlyOptions = map[string]bool{
+	"plugin": true, "templates": true, "tsc": true, "proto-path": true, "emit-js": true, "ext": true,
+}
+
+// archiveName 下载文件名只保留安全字符
+var archiveName = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// moonbeam serve 的连接超时
+const (
+	serveReadHeaderTimeout = 10 * time.Second
+	serveReadTimeout       = time.Minute
+	serveWriteTimeout      = 5 * time.Minute
+	serveIdleTimeout       = 2 * time.Minute
+)
+
+// runServe 执行 moonbeam serve 子命令：POST /generate 上传文档，返回生成代码的 zip
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	var addr, config string
+	var maxSize int64
+	fs.StringVar(&addr, "addr", ":8080", "Address to listen on")
+	fs.StringVar(&config, "config", "", "Config file with the default generation options; requests override them with query parameters named like the flags, e.g. ?client=fetch&validators=zod")
+	fs.Int64Var(&maxSize, "max-size", 10<<20, "Maximum size in bytes of an uploaded spec")
+	fs.Parse(args)
+	rejectArgs(fs)
+
+	// 配置文件中的生成选项作为默认值
+	if usedConfig := loadFlagsFromConfig(flag.CommandLine, config); usedConfig != "" {
+		logger.Info("using config file", "file", usedConfig)
+	}
+	opts.Logger = logger
+	if err := loadHeaderFile(); err != nil {
+		fatal("read header file failed", "err", err)
+	}
+	if err := opts.Validate(); err != nil {
+		fatal("invalid options", "err", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/generate", func(w http.ResponseWriter, r *http.Request) {
+		serveGenerate(w, r, opts, maxSize)
+	})
+	logger.Info("generation service listening", "addr", addr, "endpoint", "POST /generate")
+	// 慢速客户端不能无限期占用连接：请求头和上传的文档必须在限定时间内读完，响应（包括排队等待生成的时间）也有上限
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: serveReadHeaderTimeout,
+		ReadTimeout:       serveReadTimeout,
+		WriteTimeout:      serveWriteTimeout,
+		IdleTimeout:       serveIdleTimeout,
+	}
+	if err := server.ListenAndServe(); err != nil {
+		fatal("generation service stopped", "err", err)
+	}
+}
+
+// serveGenerate 处理一次生成请求；文档为请求体本身，或 multipart 表单中的 spec 文件
+func serveGenerate(w http.ResponseWriter, r *http.Request, base generator.Options, maxSize int64) {
+	start := time.Now()
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "use POST with the OpenAPI document as the body", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	name := query.Get("name")
+	if name == "" {
+		name = "api"
+	}
+	if !archiveName.MatchString(name) {
+		http.Error(w, "invalid name, use letters, digits, '.', '-' and '_'", http.StatusBadRequest)
+		return
+	}
+	query.Del("name")
+	o, err := requestOptions(base, query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+	spec, source, err := readUpload(r)
+	if err != nil {
+		status := http.StatusBadRequest
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	o.Source = source
+
+	files, err := generator.New(o).Generate(spec)
+	if err != nil {
+		logger.Warn("generate failed", "remote", r.RemoteAddr, "err", err)
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	archive, err := zipFiles(files)
+	if err != nil {
+		logger.Error("create zip failed", "err", err)
+		http.Error(w, "create zip failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".zip"))
+	w.Header().Set("Content-Length", strconv.Itoa(len(archive)))
+	w.Write(archive)
+	logger.Info("generated", "remote", r.RemoteAddr, "source", source, "files", len(files), "bytes", len(archive), "duration", time.Since(start).Round(time.Millisecond))
+}
+
+// requestOptions 在 base 上应用 query 参数，列表参数可以重复；未知参数返回错误
+func requestOptions(base generator.Options, query map[string][]string) (generator.Options, error) {
+	o := base
+	// 列表选项复制一份，追加时不写入服务端默认选项的底层数组，并发的请求之间互不影响
+	for _, field := range serveOptions {
+		if list, ok := field(&o).(*[]string); ok {
+			*list = append([]string(nil), *list...)
+		}
+	}
+
+	var keys []string
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		field, ok := serveOptions[key]
+		if serverOnlyOptions[key] {
+			return o, fmt.Errorf("option %q can only be set in the server's config file", key)
+		}
+		if !ok {
+			return o, fmt.Errorf("unsupported option %q", key)
+		}
+		values := query[key]
+		switch p := field(&o).(type) {
+		case *string:
+			*p = values[len(values)-1]
+		case *bool:
+			value, err := strconv.ParseBool(values[len(values)-1])
+			if err != nil {
+				return o, fmt.Errorf("option %s: %w", key, err)
+			}
+			*p = value
+		case *[]string:
+			*p = append(*p, values...)
+		}
+	}
+	if o.SingleFile != "" && !filepath.IsLocal(o.SingleFile) {
+		return o, fmt.Errorf("single-file must be a relative path inside the archive")
+	}
+	return o, o.Validate()
+}
+
+// readUpload 读取上传的文档，返回内容和写入头部注释的来源名称；
+// multipart 表单读取 spec 文件，其他 Content-Type 都把请求体当作文档
+func readUpload(r *http.Request) ([]byte, string, error) {
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		file, header, err := r.FormFile("spec")
+		if err != nil {
+			return nil, "", fmt.Errorf("read form file 'spec': %w", err)
+		}
+		defer file.Close()
+		data, err := io.ReadAll(file)
+		return data, uploadSource(header.Filename), err
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, "", fmt.Errorf("empty request body, expected an OpenAPI document")
+	}
+	return data, "uploaded spec", nil
+}
+
+// uploadSource 上传的文件名写入生成文件的头部注释，只保留文件名且不允许控制字符
+func uploadSource(filename string) string {
+	name := path.Base(strings.ReplaceAll(filename, `\`, "/"))
+	if name == "." || name == "/" || strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return "uploaded spec"
+	}
+	return name
+}
+
+// zipModified 压缩包中文件的修改时间，使用固定值使相同的请求得到逐字节相同的压缩包
+var zipModified = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// zipFiles 将生成的文件按路径排序打包为 zip，路径必须在压缩包内
+func zipFiles(files generator.Files) ([]byte, error) {
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range names {
+		if !filepath.IsLocal(name) {
+			return nil, fmt.Errorf("file %q is outside the archive", name)
+		}
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: zipModified})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(files[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,73 @@
+// serve_test.go
+package main
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aide-family/moonbeam/pkg/generator"
+)
+
+// TestRequestOptionsServerOnly 会运行本地程序（包括 tsc）或读取本地文件的选项不能由请求设置
+func TestRequestOptionsServerOnly(t *testing.T) {
+	for _, key := range []string{"emit-js", "ext", "plugin", "templates", "tsc", "proto-path"} {
+		t.Run(key, func(t *testing.T) {
+			value := "true"
+			if key == "ext" {
+				value = ".d.ts"
+			}
+			_, err := requestOptions(generator.Options{}, map[string][]string{key: {value}})
+			if err == nil || !strings.Contains(err.Error(), "config file") {
+				t.Fatalf("requestOptions(%s=%s) error = %v, want it rejected as a config-only option", key, value, err)
+			}
+		})
+	}
+}
+
+// TestRequestOptions 请求参数覆盖服务端的选项，列表参数追加且不修改服务端的默认选项
+func TestRequestOptions(t *testing.T) {
+	base := generator.Options{Client: "axios", IncludeTags: []string{"users"}}
+	o, err := requestOptions(base, map[string][]string{"client": {"fetch"}, "include-tags": {"teams", "roles"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Client != "fetch" {
+		t.Errorf("Client = %q, want fetch", o.Client)
+	}
+	if got := strings.Join(o.IncludeTags, ","); got != "users,teams,roles" {
+		t.Errorf("IncludeTags = %s, want users,teams,roles", got)
+	}
+	if got := strings.Join(base.IncludeTags, ","); got != "users" {
+		t.Errorf("base IncludeTags = %s, want users", got)
+	}
+}
+
+// TestRequestOptionsConcurrent 默认选项的列表有剩余容量时，并发请求追加的列表参数互不影响；配合 -race 检查没有写入共享的底层数组
+func TestRequestOptionsConcurrent(t *testing.T) {
+	base := generator.Options{TypeMappings: make([]string, 1, 4)}
+	base.TypeMappings[0] = "string/uuid=string"
+	mappings := []string{"string/decimal=Big from big.js", "integer/int64=bigint"}
+	results := make([]generator.Options, len(mappings))
+	errs := make([]error, len(mappings))
+	var wg sync.WaitGroup
+	for i, mapping := range mappings {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = requestOptions(base, map[string][]string{"type-mapping": {mapping}})
+		}()
+	}
+	wg.Wait()
+	for i, mapping := range mappings {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+		if got, want := strings.Join(results[i].TypeMappings, ","), "string/uuid=string,"+mapping; got != want {
+			t.Errorf("request %d TypeMappings = %s, want %s", i, got, want)
+		}
+	}
+	if got := strings.Join(base.TypeMappings, ","); got != "string/uuid=string" {
+		t.Errorf("base TypeMappings = %s, want string/uuid=string", got)
+	}
+}
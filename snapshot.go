@@ -0,0 +1,132 @@
+// snapshot.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/aide-family/moonbeam/pkg/generator"
+)
+
+var (
+	// snapshotFile 记录生成结果哈希的快照文件
+	snapshotFile string
+	// updateSnapshot snapshot -update 重新生成并写入快照
+	updateSnapshot bool
+	// checkSnapshot snapshot -check 重新生成并与快照比较，有差异时失败
+	checkSnapshot bool
+)
+
+// snapshot 快照文件的内容
+type snapshot struct {
+	Generator string            `json:"generator"`
+	Files     map[string]string `json:"files"` // 相对输出目录的路径（使用 /）-> 内容哈希，见 generator.OutputHash
+}
+
+// runSnapshot 执行 moonbeam snapshot：在内存中生成，-update 把每个文件的哈希写入快照，
+// -check 与快照比较并列出新增、变化和删除的文件，有差异时以 exitError 退出；不写入输出目录
+func runSnapshot(_ string, args []string) {
+	parseFlags(args)
+	if updateSnapshot == checkSnapshot {
+		fatalUsage("snapshot needs exactly one of -update and -check")
+	}
+	if outputDir == stdoutOutput {
+		fatalUsage("snapshot is not supported with -o -")
+	}
+	if err := loadHeaderFile(); err != nil {
+		fatalUsage("read header file failed", "err", err)
+	}
+	if err := opts.Validate(); err != nil {
+		fatalUsage("invalid options", "err", err)
+	}
+	specFiles, err := expandSpecFiles(apiFiles)
+	if err != nil {
+		fatal("invalid spec input", "err", err)
+	}
+
+	root := outputDir
+	changes, err := runPreview(func() error {
+		return generateAll(specFiles, root)
+	})
+	if err != nil {
+		// 错误已在 generate 中输出
+		exit(exitError)
+	}
+	current := snapshot{Generator: "moonbeam " + generator.VersionInfo(), Files: make(map[string]string)}
+	for _, change := range changes {
+		if change.Action == "delete" || filepath.Base(change.Path) == manifestName {
+			continue
+		}
+		rel, err := filepath.Rel(root, change.Path)
+		if err != nil {
+			rel = change.Path
+		}
+		current.Files[filepath.ToSlash(rel)] = generator.OutputHash(change.New)
+	}
+
+	if updateSnapshot {
+		data, _ := json.MarshalIndent(current, "", "  ")
+		if err := os.WriteFile(snapshotFile, append(data, '\n'), 0644); err != nil {
+			fatal("write snapshot failed", "file", snapshotFile, "err", err)
+		}
+		logger.Info("snapshot written", "file", snapshotFile, "files", len(current.Files))
+		return
+	}
+
+	data, err := os.ReadFile(snapshotFile)
+	if err != nil {
+		fatal("read snapshot failed, create it with snapshot -update", "file", snapshotFile, "err", err)
+	}
+	var stored snapshot
+	if err := json.Unmarshal(data, &stored); err != nil {
+		fatal("invalid snapshot", "file", snapshotFile, "err", err)
+	}
+	if differences := compareSnapshots(stored, current); differences > 0 {
+		files := "files differ"
+		if differences == 1 {
+			files = "file differs"
+		}
+		fmt.Printf("%d %s from %s (generated by %s); run snapshot -update if the changes are expected\n", differences, files, snapshotFile, stored.Generator)
+		exit(exitError)
+	}
+	fmt.Println(stdoutStyle.symbol("✅ ", "") + "output matches " + snapshotFile)
+}
+
+// compareSnapshots 按路径输出 current 相对 stored 新增（create）、变化（update）和删除（delete）的文件，返回差异的数量
+func compareSnapshots(stored, current snapshot) int {
+	names := make(map[string]bool)
+	for name := range stored.Files {
+		names[name] = true
+	}
+	for name := range current.Files {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	differences := 0
+	for _, name := range sorted {
+		old, inStored := stored.Files[name]
+		hash, inCurrent := current.Files[name]
+		var action string
+		switch {
+		case !inStored:
+			action = "create"
+		case !inCurrent:
+			action = "delete"
+		case old != hash:
+			action = "update"
+		default:
+			continue
+		}
+		differences++
+		fmt.Printf("  %s %s\n", stdoutStyle.paint(actionColors[action], fmt.Sprintf("%-7s", action)), name)
+	}
+	return differences
+}
@@ -0,0 +1,92 @@
+// specs.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// stringList 可重复的字符串参数，例如 -f a.yaml -f b.yaml
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// UnmarshalYAML 配置文件中既可以写单个字符串也可以写列表
+func (l *stringList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = stringList{value.Value}
+		return nil
+	}
+	var values []string
+	if err := value.Decode(&values); err != nil {
+		return err
+	}
+	*l = values
+	return nil
+}
+
+// UnmarshalJSON 与 UnmarshalYAML 相同，支持字符串或字符串数组
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*l = stringList{value}
+		return nil
+	}
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*l = values
+	return nil
+}
+
+// expandSpecFiles 展开 glob 模式并去重，未指定时使用 openapi.yaml
+func expandSpecFiles(patterns []string) ([]string, error) {
+	if len(patterns) == 0 {
+		return []string{"openapi.yaml"}, nil
+	}
+	seen := make(map[string]bool)
+	var files []string
+	for _, pattern := range patterns {
+		matches := []string{pattern}
+		if !isURL(pattern) && strings.ContainsAny(pattern, "*?[") {
+			var err error
+			matches, err = filepath.Glob(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid spec pattern %s: %w", pattern, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no spec matches %s", pattern)
+			}
+			sort.Strings(matches)
+		}
+		for _, file := range matches {
+			if !seen[file] {
+				seen[file] = true
+				files = append(files, file)
+			}
+		}
+	}
+	return files, nil
+}
+
+// specName 文档对应的输出目录名，例如 specs/user.openapi.yaml -> user.openapi，https://host/v1/openapi.json -> openapi
+func specName(file string) string {
+	if isURL(file) {
+		return urlSpecName(file)
+	}
+	base := filepath.Base(file)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
@@ -0,0 +1,72 @@
+// validate.go
+package main
+
+import (
+	"fmt"
+
+	"github.com/aide-family/moonbeam/pkg/generator"
+)
+
+// runValidate 执行 moonbeam validate：与生成相同地解析和检查每个文档，记录问题和不支持的特性，不生成文件；
+// 有文档无效时以 exitError 退出
+func runValidate(_ string, args []string) {
+	parseFlags(args)
+	if err := loadHeaderFile(); err != nil {
+		fatalUsage("read header file failed", "err", err)
+	}
+	if err := opts.Validate(); err != nil {
+		fatalUsage("invalid options", "err", err)
+	}
+	specFiles, err := expandSpecFiles(apiFiles)
+	if err != nil {
+		fatal("invalid spec input", "err", err)
+	}
+	failed := false
+	for _, specFile := range specFiles {
+		if err := validateSpecFile(specFile); err != nil {
+			logger.Error("invalid spec", "spec", specFile, "err", err)
+			failed = true
+		}
+	}
+	if failed {
+		exit(exitError)
+	}
+}
+
+// validateSpecFile 检查一个文档：结构错误、无法解析的 $ref 和 error 级别的诊断返回错误（-strict 时包括警告），
+// 不支持的特性按规则的级别记录；-warnings-as-errors 时与 -strict 相同，并且有 warn 级别的不支持的特性时同样返回错误
+func validateSpecFile(specFile string) error {
+	diagnosticSpec = specFile
+	data, err := readSpec(specFile)
+	if err != nil {
+		return err
+	}
+	o := specOptions(specFile)
+	o.Strict = o.Strict || o.WarningsAsErrors
+	api, features, err := generator.New(o).Validate(data)
+	if err != nil {
+		return err
+	}
+	counts := make(map[string]int)
+	for _, feature := range features {
+		counts[feature.Severity]++
+		args := []interface{}{"feature", feature.Feature, "at", feature.Location, "line", feature.Line, "rule", feature.Rule}
+		switch feature.Severity {
+		case "ignore":
+			logger.Debug(feature.Message, args...)
+		case "error":
+			logger.Error(feature.Message, args...)
+		default:
+			logger.Warn(feature.Message, args...)
+		}
+	}
+	if counts["error"] > 0 {
+		return fmt.Errorf("%d unsupported features with level error", counts["error"])
+	}
+	if o.WarningsAsErrors && counts["warn"] > 0 {
+		return fmt.Errorf("%d unsupported features with -warnings-as-errors", counts["warn"])
+	}
+	logger.Info("spec is valid", "spec", specFile, "operations", len(api.Operations), "models", len(api.Models),
+		"enums", len(api.Enums), "unsupported", len(features))
+	return nil
+}
@@ -0,0 +1,193 @@
+// watch.go
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// watchInterval 轮询文件变化的间隔
+	watchInterval = 300 * time.Millisecond
+	// watchDebounce 最后一次变化后等待的时间，避免编辑器多次写入触发多次生成
+	watchDebounce = 500 * time.Millisecond
+	// watchRemoteInterval 检查远程文档变化的间隔，有缓存时每次检查是一个条件请求
+	watchRemoteInterval = 30 * time.Second
+)
+
+// externalRefPattern 匹配 YAML/JSON 中指向其他文件的 $ref，例如 $ref: './common.yaml#/components/schemas/Page'
+var externalRefPattern = regexp.MustCompile(`"?\$ref"?\s*:\s*["']?([^"'#\s,}]+)`)
+
+// protoImportPattern 匹配 .proto 文件中的 import 语句
+var protoImportPattern = regexp.MustCompile(`(?m)^\s*import\s+(?:public\s+|weak\s+)?["']([^"']+)["']`)
+
+// watchSpecs 轮询文档及其引用的外部文件，变化后等待 watchDebounce 再调用 regenerate；
+// 远程文档每 watchRemoteInterval 下载一次，内容变化时同样重新生成
+func watchSpecs(specFiles []string, regenerate func() error) {
+	var roots, urls []string
+	for _, file := range specFiles {
+		if isURL(file) {
+			urls = append(urls, file)
+		} else {
+			roots = append(roots, file)
+		}
+	}
+
+	snapshot := watchSnapshot(roots)
+	remote := remoteSnapshot(urls, nil)
+	polled := time.Now()
+	logger.Info("watching, press Ctrl+C to stop", "files", len(snapshot), "urls", len(urls))
+
+	var changedAt time.Time
+	var changed []string
+	for {
+		time.Sleep(watchInterval)
+		current := watchSnapshot(roots)
+		diff := diffSnapshot(snapshot, current)
+		if len(urls) > 0 && time.Since(polled) >= watchRemoteInterval {
+			latest := remoteSnapshot(urls, remote)
+			diff = mergeNames(diff, diffSnapshot(remote, latest))
+			remote, polled = latest, time.Now()
+		}
+		if len(diff) > 0 {
+			snapshot = current
+			changedAt = time.Now()
+			changed = mergeNames(changed, diff)
+			continue
+		}
+		if len(changed) == 0 || time.Since(changedAt) < watchDebounce {
+			continue
+		}
+
+		start := time.Now()
+		logger.Info("regenerating", "changed", strings.Join(changed, ","))
+		if err := regenerate(); err != nil {
+			logger.Error("regenerate failed", "duration", time.Since(start).Round(time.Millisecond), "err", err)
+		} else {
+			logger.Info("regenerated", "duration", time.Since(start).Round(time.Millisecond))
+		}
+		changed = nil
+		// 外部引用可能随文档变化而增减
+		snapshot = watchSnapshot(roots)
+	}
+}
+
+// remoteSnapshot 下载远程文档，返回内容的哈希；下载失败时保留 previous 中的状态，不触发重新生成
+func remoteSnapshot(urls []string, previous map[string]string) map[string]string {
+	snapshot := make(map[string]string)
+	for _, rawURL := range urls {
+		data, err := fetchSpec(rawURL)
+		if err != nil {
+			logger.Warn("check remote spec failed", "url", rawURL, "err", err)
+			snapshot[rawURL] = previous[rawURL]
+			continue
+		}
+		sum := sha256.Sum256(data)
+		snapshot[rawURL] = hex.EncodeToString(sum[:])
+	}
+	return snapshot
+}
+
+// watchSnapshot 返回需要监听的文件及其修改时间和大小
+func watchSnapshot(roots []string) map[string]string {
+	snapshot := make(map[string]string)
+	var visit func(file string)
+	visit = func(file string) {
+		if _, seen := snapshot[file]; seen {
+			return
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			snapshot[file] = "missing"
+			return
+		}
+		snapshot[file] = fmt.Sprintf("%d-%d", info.ModTime().UnixNano(), info.Size())
+		for _, ref := range externalRefs(file) {
+			visit(ref)
+		}
+	}
+	for _, root := range roots {
+		visit(root)
+	}
+	return snapshot
+}
+
+// externalRefs 返回文件中 $ref 引用的其他本地文件，.proto 文件返回 import 的文件
+func externalRefs(file string) []string {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	if strings.EqualFold(filepath.Ext(file), ".proto") {
+		return protoImports(file, data)
+	}
+	var refs []string
+	for _, match := range externalRefPattern.FindAllSubmatch(data, -1) {
+		ref := string(match[1])
+		if isURL(ref) {
+			continue
+		}
+		if !filepath.IsAbs(ref) {
+			ref = filepath.Join(filepath.Dir(file), ref)
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// protoImports 在 -proto-path 和文件所在目录中查找 import 的文件，找不到的（例如 google/api）不监听
+func protoImports(file string, data []byte) []string {
+	var refs []string
+	dirs := append(append([]string(nil), opts.ProtoPaths...), filepath.Dir(file))
+	for _, match := range protoImportPattern.FindAllSubmatch(data, -1) {
+		for _, dir := range dirs {
+			ref := filepath.Join(dir, filepath.FromSlash(string(match[1])))
+			if _, err := os.Stat(ref); err == nil {
+				refs = append(refs, ref)
+				break
+			}
+		}
+	}
+	return refs
+}
+
+// diffSnapshot 返回新增、删除或修改过的文件
+func diffSnapshot(before, after map[string]string) []string {
+	var changed []string
+	for file, state := range after {
+		if before[file] != state {
+			changed = append(changed, file)
+		}
+	}
+	for file := range before {
+		if _, exists := after[file]; !exists {
+			changed = append(changed, file)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// mergeNames 合并去重文件名
+func mergeNames(names, more []string) []string {
+	for _, name := range more {
+		found := false
+		for _, existing := range names {
+			if existing == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			names = append(names, name)
+		}
+	}
+	return names
+}